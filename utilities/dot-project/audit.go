@@ -2,7 +2,12 @@ package projects
 
 import (
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
+	"net/url"
+	"path"
+	"regexp"
 	"strings"
 )
 
@@ -17,14 +22,19 @@ type AuditResult struct {
 
 // AuditCheck represents a single URL accessibility check
 type AuditCheck struct {
-	Field      string `json:"field"`
-	URL        string `json:"url"`
-	Status     string `json:"status"` // "pass", "fail", "skip"
-	StatusCode int    `json:"status_code,omitempty"`
-	Error      string `json:"error,omitempty"`
+	Field       string   `json:"field"`
+	URL         string   `json:"url"`
+	Status      string   `json:"status"` // "pass", "fail", "skip"
+	StatusCode  int      `json:"status_code,omitempty"`
+	Method      string   `json:"method,omitempty"`       // "GET" when the server refused HEAD
+	Redirects   []string `json:"redirects,omitempty"`    // Each URL redirected to, in order
+	ContentType string   `json:"content_type,omitempty"` // Media type of the final response
+	Error       string   `json:"error,omitempty"`
+	Suggestion  string   `json:"suggestion,omitempty"` // e.g. the new location of a moved link
 }
 
 // AuditProject checks that all referenced URLs in a project are accessible
+// and serve what their field promises. See auditURL for what is checked.
 func AuditProject(project Project, client *http.Client) AuditResult {
 	if client == nil {
 		client = &http.Client{Timeout: DefaultHTTPTimeout}
@@ -42,22 +52,11 @@ func AuditProject(project Project, client *http.Client) AuditResult {
 			check.Status = "skip"
 			result.SkipCount++
 		} else {
-			resp, err := client.Head(check.URL)
-			if err != nil {
-				check.Status = "fail"
-				check.Error = err.Error()
-				result.FailCount++
+			check = auditURL(client, check)
+			if check.Status == "pass" {
+				result.PassCount++
 			} else {
-				resp.Body.Close()
-				check.StatusCode = resp.StatusCode
-				if resp.StatusCode >= 200 && resp.StatusCode < 400 {
-					check.Status = "pass"
-					result.PassCount++
-				} else {
-					check.Status = "fail"
-					check.Error = fmt.Sprintf("HTTP %d", resp.StatusCode)
-					result.FailCount++
-				}
+				result.FailCount++
 			}
 		}
 		result.Checks = append(result.Checks, check)
@@ -66,6 +65,192 @@ func AuditProject(project Project, client *http.Client) AuditResult {
 	return result
 }
 
+// maxAuditRedirects is how many redirects an audited URL may go through.
+const maxAuditRedirects = 10
+
+// maxAuditBody is how much of an HTML page is read to look for a soft 404.
+const maxAuditBody = 64 << 10
+
+// auditResponse is the outcome of one audited request after redirects.
+type auditResponse struct {
+	statusCode  int
+	contentType string
+	redirects   []string
+	permanent   bool // some redirect was 301 or 308
+	body        []byte
+}
+
+// auditRequest sends one request, following and recording redirects. The
+// body is read, up to maxAuditBody, only for GET requests.
+func auditRequest(client *http.Client, method, rawURL string) (*auditResponse, error) {
+	out := &auditResponse{}
+	c := *client
+	c.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxAuditRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxAuditRedirects)
+		}
+		out.redirects = append(out.redirects, req.URL.String())
+		if code := req.Response.StatusCode; code == http.StatusMovedPermanently || code == http.StatusPermanentRedirect {
+			out.permanent = true
+		}
+		return nil
+	}
+	req, err := http.NewRequest(method, rawURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	out.statusCode = resp.StatusCode
+	if mt, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type")); err == nil {
+		out.contentType = mt
+	}
+	if method == http.MethodGet {
+		out.body, _ = io.ReadAll(io.LimitReader(resp.Body, maxAuditBody))
+	}
+	return out, nil
+}
+
+// auditURL checks one URL. HEAD comes first; servers that refuse it get a
+// GET. HTML pages are fetched to catch soft 404s, pages that answer 200 with
+// a "not found" title. A link that redirects from a page to a bare site
+// homepage fails, as does a response whose content type the field does not
+// expect. A link that was moved permanently passes with the new location
+// suggested.
+func auditURL(client *http.Client, check AuditCheck) AuditCheck {
+	resp, err := auditRequest(client, http.MethodHead, check.URL)
+	if err == nil && (resp.statusCode == http.StatusMethodNotAllowed || resp.statusCode == http.StatusNotImplemented) {
+		check.Method = http.MethodGet
+		resp, err = auditRequest(client, http.MethodGet, check.URL)
+	}
+	if err != nil {
+		check.Status = "fail"
+		check.Error = err.Error()
+		return check
+	}
+	if check.Method == "" && resp.statusCode < 300 && (resp.contentType == "" || resp.contentType == "text/html") {
+		// Look at the page itself; a failed GET leaves the HEAD result.
+		if page, err := auditRequest(client, http.MethodGet, check.URL); err == nil && page.statusCode == resp.statusCode {
+			resp = page
+		}
+	}
+
+	check.StatusCode = resp.statusCode
+	check.Redirects = resp.redirects
+	check.ContentType = resp.contentType
+	final := check.URL
+	if len(resp.redirects) > 0 {
+		final = resp.redirects[len(resp.redirects)-1]
+	}
+
+	switch {
+	case resp.statusCode < 200 || resp.statusCode >= 400:
+		check.Status = "fail"
+		check.Error = fmt.Sprintf("HTTP %d", resp.statusCode)
+	case isHomepageRedirect(check.URL, final):
+		check.Status = "fail"
+		check.Error = fmt.Sprintf("redirects to the site homepage %s", final)
+	case isSoftNotFound(resp.contentType, resp.body):
+		check.Status = "fail"
+		check.Error = "page says it was not found (soft 404)"
+	case !contentTypeExpected(check.Field, final, resp.contentType):
+		check.Status = "fail"
+		check.Error = fmt.Sprintf("expected %s, got %s", describeContentTypes(check.Field), resp.contentType)
+	default:
+		check.Status = "pass"
+	}
+	if resp.permanent && check.Status == "pass" {
+		check.Suggestion = fmt.Sprintf("moved permanently; update to %s", final)
+	}
+	return check
+}
+
+// isHomepageRedirect reports whether a link to a page ended at the root of a
+// site, which is how many sites answer for pages they removed.
+func isHomepageRedirect(from, to string) bool {
+	if from == to {
+		return false
+	}
+	u, err1 := url.Parse(from)
+	v, err2 := url.Parse(to)
+	if err1 != nil || err2 != nil {
+		return false
+	}
+	return strings.Trim(u.Path, "/") != "" && strings.Trim(v.Path, "/") == "" && v.RawQuery == ""
+}
+
+var (
+	htmlTitlePattern   = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlHeadingPattern = regexp.MustCompile(`(?is)<h1[^>]*>(.*?)</h1>`)
+)
+
+// softNotFoundPhrases mark an error page when they appear in its title or
+// first heading. Body text is not searched, since real pages mention 404s.
+var softNotFoundPhrases = []string{"404", "not found", "page does not exist", "page doesn't exist", "no longer available"}
+
+// isSoftNotFound reports whether an HTML page is an error page served with a
+// success status.
+func isSoftNotFound(contentType string, body []byte) bool {
+	if contentType != "text/html" || len(body) == 0 {
+		return false
+	}
+	var texts []string
+	if m := htmlTitlePattern.FindSubmatch(body); m != nil {
+		texts = append(texts, string(m[1]))
+	}
+	if m := htmlHeadingPattern.FindSubmatch(body); m != nil {
+		texts = append(texts, string(m[1]))
+	}
+	for _, t := range texts {
+		t = strings.ToLower(t)
+		for _, phrase := range softNotFoundPhrases {
+			if strings.Contains(t, phrase) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// contentTypeExpected reports whether a field's URL may serve contentType.
+// Audit reports are PDF or HTML documents. Artwork is an image, or an
+// artwork folder page on GitHub. Other fields, and responses without a
+// content type, are not checked. Generic types, which raw file hosts send
+// for PDFs and SVGs, are judged by the URL's file extension instead.
+func contentTypeExpected(field, rawURL, contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return true
+	}
+	if contentType == "application/octet-stream" || contentType == "text/plain" {
+		if t, _, err := mime.ParseMediaType(mime.TypeByExtension(path.Ext(u.Path))); err == nil {
+			contentType = t
+		}
+	}
+	switch {
+	case strings.HasPrefix(field, "audits["):
+		return contentType == "application/pdf" || contentType == "text/html"
+	case field == "artwork":
+		return strings.HasPrefix(contentType, "image/") || (u.Hostname() == "github.com" && contentType == "text/html")
+	}
+	return true
+}
+
+// describeContentTypes names what contentTypeExpected accepts for field.
+func describeContentTypes(field string) string {
+	if field == "artwork" {
+		return "an image"
+	}
+	return "a PDF or HTML document"
+}
+
 // collectProjectURLs gathers all URL references from a project for checking
 func collectProjectURLs(project Project) []AuditCheck {
 	var checks []AuditCheck
@@ -85,6 +270,16 @@ func collectProjectURLs(project Project) []AuditCheck {
 		checks = append(checks, AuditCheck{Field: fmt.Sprintf("repositories[%d]", i), URL: repo.URL})
 	}
 
+	// Subproject websites and repositories
+	for i, sp := range project.Subprojects {
+		if sp.Website != "" {
+			checks = append(checks, AuditCheck{Field: fmt.Sprintf("subprojects[%d].website", i), URL: sp.Website})
+		}
+		for j, repo := range sp.Repositories {
+			checks = append(checks, AuditCheck{Field: fmt.Sprintf("subprojects[%d].repositories[%d]", i, j), URL: repo.URL})
+		}
+	}
+
 	// Audit report URLs
 	for i, audit := range project.Audits {
 		checks = append(checks, AuditCheck{Field: fmt.Sprintf("audits[%d].url", i), URL: audit.URL})
@@ -147,6 +342,12 @@ func FormatAuditResult(result AuditResult) string {
 			b.WriteString(fmt.Sprintf(" (%s)", check.Error))
 		}
 		b.WriteString("\n")
+		if len(check.Redirects) > 0 {
+			b.WriteString(fmt.Sprintf("         redirects: %s\n", strings.Join(check.Redirects, " -> ")))
+		}
+		if check.Suggestion != "" {
+			b.WriteString(fmt.Sprintf("         suggestion: %s\n", check.Suggestion))
+		}
 	}
 
 	b.WriteString(fmt.Sprintf("\nSummary: %d passed, %d failed, %d skipped\n",
@@ -0,0 +1,114 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AuditFreshness reports incubating and graduated projects whose most
+// recent security audit is older than MaxAgeYears or missing, and projects
+// that graduated without a security.threat_model. It runs independently of
+// the maturity policy, so -skip-policy does not turn it off.
+type AuditFreshness struct {
+	MaxAgeYears int              // 0 means DefaultAuditMaxAgeYears; negative disables the audit age checks
+	Now         func() time.Time // nil means time.Now
+}
+
+// SetAuditFreshness sets the audit freshness rule applied after structural
+// validation. NewValidator checks against DefaultAuditMaxAgeYears; nil
+// disables the rule.
+func (pv *ProjectValidator) SetAuditFreshness(a *AuditFreshness) {
+	pv.audits = a
+}
+
+// auditFreshnessFromConfig returns the audit freshness rule for a config:
+// DefaultAuditMaxAgeYears unless AuditMaxAgeYears overrides it.
+func auditFreshnessFromConfig(config *Config) *AuditFreshness {
+	years := DefaultAuditMaxAgeYears
+	if config != nil && config.AuditMaxAgeYears != 0 {
+		years = config.AuditMaxAgeYears
+	}
+	return &AuditFreshness{MaxAgeYears: years}
+}
+
+// isSecurityAudit reports whether an audit entry is a security audit. Types
+// such as "security", "third-party security" or "Security Audit" count.
+func isSecurityAudit(a Audit) bool {
+	return strings.Contains(strings.ToLower(a.Type), "security")
+}
+
+// LatestSecurityAudit returns the index of the most recent security audit,
+// or -1 if the project has none with a date.
+func LatestSecurityAudit(project Project) int {
+	latest := -1
+	for i, a := range project.Audits {
+		if !isSecurityAudit(a) || a.Date.IsZero() {
+			continue
+		}
+		if latest < 0 || a.Date.After(project.Audits[latest].Date) {
+			latest = i
+		}
+	}
+	return latest
+}
+
+// Evaluate returns the audit age and threat model diagnostics for an
+// incubating or graduated project.
+func (a *AuditFreshness) Evaluate(project Project) []Diagnostic {
+	if a == nil {
+		return nil
+	}
+	phase := CurrentMaturityPhase(project)
+	if phase != "incubating" && phase != "graduated" {
+		return nil
+	}
+	var diags []Diagnostic
+	if project.Security == nil || project.Security.ThreatModel == nil {
+		d := Diagnostic{
+			Path:     "security.threat_model",
+			Rule:     RuleThreatModel,
+			Severity: SeverityWarning,
+			Message:  "incubating projects should document a threat model before graduation",
+		}
+		if phase == "graduated" {
+			d.Severity = SeverityError
+			d.Message = "graduated projects must document a threat model"
+		}
+		diags = append(diags, d.withFix("add security.threat_model pointing at the threat model document"))
+	}
+	if a.MaxAgeYears < 0 {
+		return diags
+	}
+	years := a.MaxAgeYears
+	if years == 0 {
+		years = DefaultAuditMaxAgeYears
+	}
+
+	i := LatestSecurityAudit(project)
+	if i < 0 {
+		d := Diagnostic{
+			Path:     "audits",
+			Rule:     RuleAuditFreshness,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("no security audit is on record; %s projects should be audited at least every %d years", phase, years),
+		}
+		return append(diags, d.withFix(`add the latest security audit to audits with a type such as "security"`))
+	}
+	now := time.Now()
+	if a.Now != nil {
+		now = a.Now()
+	}
+	audit := project.Audits[i]
+	if !audit.Date.Before(now.AddDate(-years, 0, 0)) {
+		return diags
+	}
+	d := Diagnostic{
+		Path:     fmt.Sprintf("audits[%d].date", i),
+		Rule:     RuleAuditFreshness,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("the last security audit (%s) is more than %d years old; %s projects should be re-audited",
+			audit.Date.Format("2006-01-02"), years, phase),
+	}
+	return append(diags, d.withFix("schedule a new security audit and add it to audits"))
+}
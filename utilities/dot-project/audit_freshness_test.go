@@ -0,0 +1,129 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func auditedProject(phase string, audits ...Audit) Project {
+	p := validBaseProject()
+	p.MaturityLog[len(p.MaturityLog)-1].Phase = phase
+	p.Audits = audits
+	p.Security = &SecurityConfig{ThreatModel: &PathRef{Path: "THREAT_MODEL.md"}}
+	return p
+}
+
+func TestAuditFreshness(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := &AuditFreshness{MaxAgeYears: 3, Now: func() time.Time { return now }}
+	old := Audit{Date: time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), Type: "security", URL: "https://example.com/2022.pdf"}
+	recent := Audit{Date: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Type: "Third-party Security Audit", URL: "https://example.com/2025.pdf"}
+	perf := Audit{Date: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Type: "performance", URL: "https://example.com/perf.pdf"}
+
+	tests := []struct {
+		name    string
+		project Project
+		want    string // expected diagnostic path, or "" for none
+	}{
+		{"stale graduated", auditedProject("graduated", old, perf), "audits[0].date"},
+		{"stale incubating", auditedProject("incubating", old), "audits[0].date"},
+		{"recent audit wins", auditedProject("graduated", recent, old), ""},
+		{"sandbox not checked", auditedProject("sandbox", old), ""},
+		{"no security audit", auditedProject("graduated", perf), "audits"},
+		{"no audits", auditedProject("incubating"), "audits"},
+	}
+	for _, tt := range tests {
+		diags := a.Evaluate(tt.project)
+		switch {
+		case tt.want == "" && len(diags) != 0:
+			t.Errorf("%s: unexpected diagnostics %+v", tt.name, diags)
+		case tt.want != "" && (len(diags) != 1 || diags[0].Path != tt.want || diags[0].Rule != RuleAuditFreshness || diags[0].Severity != SeverityWarning):
+			t.Errorf("%s: diagnostics = %+v, want one warning at %s", tt.name, diags, tt.want)
+		}
+	}
+
+	if diags := (&AuditFreshness{Now: a.Now}).Evaluate(auditedProject("graduated", old)); len(diags) != 1 {
+		t.Errorf("MaxAgeYears 0 should use the default threshold, got %+v", diags)
+	}
+	for _, p := range []Project{auditedProject("graduated", old), auditedProject("graduated")} {
+		if diags := (&AuditFreshness{MaxAgeYears: -1}).Evaluate(p); len(diags) != 0 {
+			t.Errorf("negative MaxAgeYears should disable the audit checks, got %+v", diags)
+		}
+	}
+}
+
+func TestAuditFutureDate(t *testing.T) {
+	p := validBaseProject()
+	p.Audits = []Audit{{Date: time.Now().AddDate(1, 0, 0), Type: "security", URL: "https://example.com/a.pdf"}}
+	d, ok := findDiagnostic(ValidateProjectDiagnostics(p), "audits[0].date")
+	if !ok || d.Rule != RuleFutureDate || d.Severity != SeverityError {
+		t.Errorf("diagnostic = %+v, want a future-date error", d)
+	}
+
+	p.Audits[0].Date = time.Now()
+	if _, ok := findDiagnostic(ValidateProjectDiagnostics(p), "audits[0].date"); ok {
+		t.Error("today's date should be accepted")
+	}
+}
+
+func TestValidatorAuditFreshnessConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	content := strings.Replace(validProjectYAML(), "phase: sandbox", "phase: incubating", 1) +
+		"audits:\n  - date: 2015-01-01\n    type: security\n    url: https://example.com/audit.pdf\n"
+	writeFile(t, path, content)
+
+	pv := newTestValidator(t)
+	pv.SetPolicy(nil)
+	result, err := pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d, ok := findDiagnostic(result.Diagnostics, "audits[0].date"); !ok || d.Rule != RuleAuditFreshness || d.Line == 0 {
+		t.Errorf("diagnostic = %+v, want a located audit-freshness warning", d)
+	}
+
+	pv.SetAuditFreshness(nil)
+	result, err = pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := findDiagnostic(result.Diagnostics, "audits[0].date"); ok {
+		t.Error("SetAuditFreshness(nil) should disable the check")
+	}
+
+	if a := auditFreshnessFromConfig(&Config{AuditMaxAgeYears: -1}); a == nil || a.MaxAgeYears != -1 {
+		t.Errorf("negative audit_max_age_years should be kept to disable the audit checks, got %+v", a)
+	}
+	if a := auditFreshnessFromConfig(&Config{}); a == nil || a.MaxAgeYears != DefaultAuditMaxAgeYears {
+		t.Errorf("default config = %+v", a)
+	}
+}
+
+func TestThreatModelBeforeGraduation(t *testing.T) {
+	a := &AuditFreshness{MaxAgeYears: -1}
+	for phase, want := range map[string]Severity{"incubating": SeverityWarning, "graduated": SeverityError} {
+		p := auditedProject(phase)
+		p.Security = nil
+		d, ok := findDiagnostic(a.Evaluate(p), "security.threat_model")
+		if !ok || d.Rule != RuleThreatModel || d.Severity != want {
+			t.Errorf("%s: threat_model diagnostic = %+v, want severity %s", phase, d, want)
+		}
+	}
+	p := auditedProject("sandbox")
+	p.Security = nil
+	if diags := a.Evaluate(p); len(diags) != 0 {
+		t.Errorf("sandbox projects need no threat model, got %+v", diags)
+	}
+
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatal(err)
+	}
+	p = auditedProject("graduated")
+	p.Security = nil
+	if d, ok := findDiagnostic(policy.Evaluate(p), "security.threat_model"); ok {
+		t.Errorf("the maturity policy should leave threat_model to the audit rules, got %+v", d)
+	}
+}
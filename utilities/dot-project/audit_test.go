@@ -87,3 +87,70 @@ func TestFormatAuditResult(t *testing.T) {
 		t.Error("expected summary counts in output")
 	}
 }
+
+func TestAuditProjectDeepChecks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/no-head":
+			if r.Method == http.MethodHead {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			w.Write([]byte("<html><title>Governance</title></html>"))
+		case "/old":
+			http.Redirect(w, r, "/new", http.StatusMovedPermanently)
+		case "/docs/removed":
+			http.Redirect(w, r, "/", http.StatusFound)
+		case "/soft":
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><head><title>Page Not Found | Example</title></head></html>"))
+		case "/audit.zip":
+			w.Header().Set("Content-Type", "application/zip")
+		case "/audit.pdf":
+			w.Header().Set("Content-Type", "application/octet-stream")
+		case "/logo.png":
+			w.Header().Set("Content-Type", "image/png")
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html><title>Example</title><h1>Welcome</h1></html>"))
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		field, path, wantStatus, wantError string
+		check                              func(t *testing.T, c AuditCheck)
+	}{
+		{field: "website", path: "/no-head", wantStatus: "pass", check: func(t *testing.T, c AuditCheck) {
+			if c.Method != http.MethodGet || c.ContentType != "text/html" {
+				t.Errorf("method = %q, content type = %q; want a GET fallback to text/html", c.Method, c.ContentType)
+			}
+		}},
+		{field: "website", path: "/old", wantStatus: "pass", check: func(t *testing.T, c AuditCheck) {
+			if len(c.Redirects) != 1 || c.Redirects[0] != server.URL+"/new" {
+				t.Errorf("redirects = %v", c.Redirects)
+			}
+			if c.Suggestion != "moved permanently; update to "+server.URL+"/new" {
+				t.Errorf("suggestion = %q", c.Suggestion)
+			}
+		}},
+		{field: "governance.contributing.path", path: "/docs/removed", wantStatus: "fail", wantError: "site homepage"},
+		{field: "documentation.readme.path", path: "/soft", wantStatus: "fail", wantError: "soft 404"},
+		{field: "audits[0].url", path: "/audit.zip", wantStatus: "fail", wantError: "expected a PDF or HTML document, got application/zip"},
+		{field: "audits[0].url", path: "/audit.pdf", wantStatus: "pass"},
+		{field: "artwork", path: "/logo.png", wantStatus: "pass"},
+		{field: "artwork", path: "/page", wantStatus: "fail", wantError: "expected an image"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.field+tt.path, func(t *testing.T) {
+			c := auditURL(server.Client(), AuditCheck{Field: tt.field, URL: server.URL + tt.path})
+			if c.Status != tt.wantStatus || !strings.Contains(c.Error, tt.wantError) {
+				t.Errorf("status = %s, error = %q; want %s with %q", c.Status, c.Error, tt.wantStatus, tt.wantError)
+			}
+			if tt.check != nil {
+				tt.check(t, c)
+			}
+		})
+	}
+}
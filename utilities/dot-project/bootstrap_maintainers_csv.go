@@ -1,6 +1,7 @@
 package projects
 
 import (
+	"bytes"
 	"encoding/csv"
 	"fmt"
 	"io"
@@ -14,15 +15,58 @@ import (
 const (
 	csvColStatus     = 0 // maturity, only set on a project block's first row
 	csvColProject    = 1 // project label, only set on a block's first row
+	csvColName       = 2 // the maintainer's full name
+	csvColCompany    = 3 // the maintainer's employer
 	csvColGithubName = 4 // the GitHub handle (no @ prefix)
 )
 
+// csvColumns holds the column index of each maintainer field. Copies of the
+// CSV that add Email or Role columns are read by header name; -1 means the
+// column is absent.
+type csvColumns struct {
+	name, company, email, role, handle int
+}
+
+// detectCSVColumns maps header names to columns, falling back to the
+// standard layout for the ones it does not find.
+func detectCSVColumns(header []string) csvColumns {
+	cols := csvColumns{name: csvColName, company: csvColCompany, email: -1, role: -1, handle: csvColGithubName}
+	for i, h := range header {
+		switch strings.ToLower(strings.TrimSpace(h)) {
+		case "maintainer name", "name":
+			cols.name = i
+		case "company", "affiliation":
+			cols.company = i
+		case "email", "e-mail", "email address":
+			cols.email = i
+		case "role":
+			cols.role = i
+		case "github name", "github", "github handle":
+			cols.handle = i
+		}
+	}
+	return cols
+}
+
+// member reads the maintainer on a CSV row.
+func (c csvColumns) member(record []string) TeamMember {
+	return TeamMember{
+		Handle:  strings.TrimSpace(strings.TrimPrefix(field(record, c.handle), "@")),
+		Name:    strings.TrimSpace(field(record, c.name)),
+		Email:   strings.TrimSpace(field(record, c.email)),
+		Company: strings.TrimSpace(field(record, c.company)),
+		Role:    strings.TrimSpace(field(record, c.role)),
+	}
+}
+
 // MaintainerBlock is a contiguous group of maintainers listed under a single
-// project label in the foundation CSV.
+// project label in the foundation CSV. Members carries the name and company
+// of each handle, in the same order as Handles.
 type MaintainerBlock struct {
 	Project string
 	Status  string
 	Handles []string
+	Members []TeamMember
 }
 
 // continuationLabels are generic sub-group labels that occasionally appear in
@@ -45,19 +89,43 @@ var continuationLabels = map[string]bool{
 // csvPath is an optional local file path. When empty, the CSV is fetched fresh
 // from DefaultFoundationMaintainersCSVURL.
 func FetchFoundationMaintainers(csvPath string, client *http.Client) ([]MaintainerBlock, error) {
+	data, err := ReadFoundationMaintainersCSV(csvPath, client)
+	if err != nil {
+		return nil, err
+	}
+	return ParseFoundationMaintainersCSV(data)
+}
+
+// ParseFoundationMaintainersCSV parses CSV content returned by
+// ReadFoundationMaintainersCSV into maintainer blocks.
+func ParseFoundationMaintainersCSV(data []byte) ([]MaintainerBlock, error) {
+	return parseFoundationMaintainersCSV(bytes.NewReader(data))
+}
+
+// ReadFoundationMaintainersCSV returns the raw foundation maintainers CSV from
+// csvPath, or from DefaultFoundationMaintainersCSVURL when csvPath is empty.
+func ReadFoundationMaintainersCSV(csvPath string, client *http.Client) ([]byte, error) {
 	if csvPath != "" {
-		f, err := os.Open(csvPath)
+		data, err := os.ReadFile(csvPath)
 		if err != nil {
 			return nil, fmt.Errorf("opening maintainers CSV %q: %w", csvPath, err)
 		}
-		defer func() { _ = f.Close() }()
-		return parseFoundationMaintainersCSV(f)
+		return data, nil
 	}
-	return fetchMaintainersCSVFromURL(DefaultFoundationMaintainersCSVURL, client)
+	return downloadMaintainersCSV(DefaultFoundationMaintainersCSVURL, client)
 }
 
 // fetchMaintainersCSVFromURL downloads and parses the CSV from url.
 func fetchMaintainersCSVFromURL(url string, client *http.Client) ([]MaintainerBlock, error) {
+	data, err := downloadMaintainersCSV(url, client)
+	if err != nil {
+		return nil, err
+	}
+	return parseFoundationMaintainersCSV(bytes.NewReader(data))
+}
+
+// downloadMaintainersCSV returns the raw CSV served at url.
+func downloadMaintainersCSV(url string, client *http.Client) ([]byte, error) {
 	if client == nil {
 		client = &http.Client{Timeout: DefaultHTTPTimeout}
 	}
@@ -69,19 +137,54 @@ func fetchMaintainersCSVFromURL(url string, client *http.Client) ([]MaintainerBl
 	if resp.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("maintainers CSV returned HTTP %d for %s", resp.StatusCode, url)
 	}
-	return parseFoundationMaintainersCSV(resp.Body)
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading maintainers CSV from %s: %w", url, err)
+	}
+	return data, nil
 }
 
 // parseFoundationMaintainersCSV parses the foundation CSV, forward-filling the
 // sparse status/project columns and associating bare continuation sub-groups
 // with their owning project block.
 func parseFoundationMaintainersCSV(r io.Reader) ([]MaintainerBlock, error) {
-	reader := csv.NewReader(r)
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("reading maintainers CSV: %w", err)
+	}
+	csvFile, err := scanFoundationCSV(data)
+	if err != nil {
+		return nil, err
+	}
+	return csvFile.blocks, nil
+}
+
+// foundationCSV is a parsed foundation CSV that remembers the raw text of
+// each row, so that a patch can rewrite single rows and leave the rest of the
+// file byte-for-byte intact.
+type foundationCSV struct {
+	rows   []foundationCSVRow
+	blocks []MaintainerBlock
+	cols   csvColumns
+}
+
+// foundationCSVRow is one CSV record. Block is the index of the block the
+// row belongs to, or -1 for the header and rows before the first block.
+type foundationCSVRow struct {
+	raw    string
+	record []string
+	block  int
+}
+
+// scanFoundationCSV parses data row by row; see parseFoundationMaintainersCSV.
+func scanFoundationCSV(data []byte) (*foundationCSV, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
 	reader.FieldsPerRecord = -1 // rows have a variable number of columns
 	reader.LazyQuotes = true
 
-	var blocks []MaintainerBlock
+	out := &foundationCSV{cols: detectCSVColumns(nil)}
 	current := -1 // index into blocks of the block currently being filled
+	var offset int64
 
 	for i := 0; ; i++ {
 		record, err := reader.Read()
@@ -91,30 +194,38 @@ func parseFoundationMaintainersCSV(r io.Reader) ([]MaintainerBlock, error) {
 		if err != nil {
 			return nil, fmt.Errorf("parsing maintainers CSV at row %d: %w", i+1, err)
 		}
+		end := reader.InputOffset()
+		row := foundationCSVRow{raw: string(data[offset:end]), record: record, block: -1}
+		offset = end
 		if i == 0 {
+			out.cols = detectCSVColumns(record)
+			out.rows = append(out.rows, row)
 			continue // header row
 		}
 
 		project := strings.TrimSpace(field(record, csvColProject))
 		status := strings.TrimSpace(field(record, csvColStatus))
-		handle := strings.TrimSpace(strings.TrimPrefix(field(record, csvColGithubName), "@"))
+		member := out.cols.member(record)
 
 		if project != "" {
 			// A bare continuation label belongs to the preceding project block.
 			if current >= 0 && continuationLabels[normalizeProjectKey(project)] {
 				// keep filling the current block; do not start a new one
 			} else {
-				blocks = append(blocks, MaintainerBlock{Project: project, Status: status})
-				current = len(blocks) - 1
+				out.blocks = append(out.blocks, MaintainerBlock{Project: project, Status: status})
+				current = len(out.blocks) - 1
 			}
 		}
 
-		if handle != "" && current >= 0 {
-			blocks[current].Handles = append(blocks[current].Handles, handle)
+		row.block = current
+		if member.Handle != "" && current >= 0 {
+			out.blocks[current].Handles = append(out.blocks[current].Handles, member.Handle)
+			out.blocks[current].Members = append(out.blocks[current].Members, member)
 		}
+		out.rows = append(out.rows, row)
 	}
 
-	return blocks, nil
+	return out, nil
 }
 
 // MatchProjectMaintainers returns the merged, de-duplicated set of handles for
@@ -135,6 +246,32 @@ func MatchProjectMaintainers(blocks []MaintainerBlock, names ...string) []string
 	return handles
 }
 
+// MatchProjectMaintainerMembers is MatchProjectMaintainers with the CSV name
+// and company kept. Members are de-duplicated by handle, case-insensitively,
+// keeping the first occurrence.
+func MatchProjectMaintainerMembers(blocks []MaintainerBlock, names ...string) []TeamMember {
+	candidates := normalizeCandidates(names...)
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	var members []TeamMember
+	for _, b := range blocks {
+		if !blockMatches(b.Project, candidates) {
+			continue
+		}
+		for _, m := range b.Members {
+			key := strings.ToLower(m.Handle)
+			if !seen[key] {
+				seen[key] = true
+				members = append(members, m)
+			}
+		}
+	}
+	return members
+}
+
 // normalizeCandidates returns the de-duplicated set of normalized candidate
 // names (length >= 2).
 func normalizeCandidates(names ...string) map[string]bool {
@@ -184,9 +321,10 @@ func normalizeProjectKey(s string) string {
 	return strings.Join(strings.Fields(strings.ToLower(s)), " ")
 }
 
-// field safely returns the column at idx, or "" if the row is shorter.
+// field safely returns the column at idx, or "" if the row is shorter or
+// idx is negative.
 func field(record []string, idx int) string {
-	if idx < len(record) {
+	if idx >= 0 && idx < len(record) {
 		return record[idx]
 	}
 	return ""
@@ -77,6 +77,27 @@ func TestParseFoundationMaintainersCSV_QuotedCompany(t *testing.T) {
 	if maint == nil || len(maint.Handles) == 0 || maint.Handles[0] != "puerco" {
 		t.Fatalf("expected first maintainer handle 'puerco', got %+v", maint)
 	}
+	want := TeamMember{Handle: "puerco", Name: "Adolfo García Veytia", Company: "Carabiner Systems, Inc"}
+	if len(maint.Members) != len(maint.Handles) || maint.Members[0] != want {
+		t.Errorf("members = %+v, want first %+v", maint.Members, want)
+	}
+}
+
+func TestMatchProjectMaintainerMembers(t *testing.T) {
+	blocks := parseSample(t)
+	blocks = append(blocks, MaintainerBlock{Project: "Kubernetes SIG Docs", Members: []TeamMember{{Handle: "AOJEA", Company: "Elsewhere"}}})
+
+	members := MatchProjectMaintainerMembers(blocks, "Kubernetes")
+	var handles []string
+	for _, m := range members {
+		handles = append(handles, m.Handle)
+	}
+	if got := strings.Join(handles, ","); got != "aojea,BenTheElder,puerco,ameukam" {
+		t.Errorf("handles = %q", got)
+	}
+	if members[0].Company != "Google" || members[0].Name != "Antonio Ojea" {
+		t.Errorf("first member = %+v, want the CSV name and company kept", members[0])
+	}
 }
 
 func TestParseFoundationMaintainersCSV_ContinuationLabel(t *testing.T) {
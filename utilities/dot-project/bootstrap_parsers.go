@@ -125,12 +125,15 @@ var packageManagerURLPatterns = []struct {
 	registry string // registry key (e.g., "docker", "npm")
 	// segments is the number of path segments after the prefix to capture as identifier
 	segments int
+	// image is the registry host prepended to the identifier, so images
+	// outside Docker Hub keep their registry (e.g. "ghcr.io/org/image")
+	image string
 }{
 	{host: "hub.docker.com", prefix: "/r/", registry: "docker", segments: 2},
 	{host: "hub.docker.com", prefix: "/u/", registry: "docker", segments: 1},
-	{host: "gallery.ecr.aws", prefix: "/", registry: "docker", segments: 1},
-	{host: "ghcr.io", prefix: "/", registry: "docker", segments: 2},
-	{host: "quay.io", prefix: "/repository/", registry: "docker", segments: 2},
+	{host: "gallery.ecr.aws", prefix: "/", registry: "docker", segments: 2, image: "public.ecr.aws"},
+	{host: "ghcr.io", prefix: "/", registry: "docker", segments: 2, image: "ghcr.io"},
+	{host: "quay.io", prefix: "/repository/", registry: "docker", segments: 2, image: "quay.io"},
 	{host: "pypi.org", prefix: "/project/", registry: "pypi", segments: 1},
 	{host: "www.npmjs.com", prefix: "/package/", registry: "npm", segments: 0},
 	{host: "npmjs.com", prefix: "/package/", registry: "npm", segments: 0},
@@ -187,9 +190,13 @@ func parsePackageManagerURL(rawURL string) (registry, identifier string) {
 			continue
 		}
 		id := strings.Join(parts[:p.segments], "/")
-		if id != "" {
-			return p.registry, id
+		if id == "" {
+			continue
+		}
+		if p.image != "" {
+			id = p.image + "/" + id
 		}
+		return p.registry, id
 	}
 
 	return "", ""
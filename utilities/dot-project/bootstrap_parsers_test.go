@@ -177,8 +177,9 @@ func TestParsePackageManagerURL(t *testing.T) {
 		{"rubygems", "https://rubygems.org/gems/grpc", "rubygems", "grpc"},
 		{"go pkg.go.dev", "https://pkg.go.dev/github.com/open-telemetry/opentelemetry-go", "go", "github.com/open-telemetry/opentelemetry-go"},
 		{"artifacthub helm", "https://artifacthub.io/packages/helm/prometheus-community/kube-prometheus-stack", "helm", "prometheus-community/kube-prometheus-stack"},
-		{"ghcr.io", "https://ghcr.io/envoyproxy/envoy", "docker", "envoyproxy/envoy"},
-		{"quay.io", "https://quay.io/repository/prometheus/prometheus", "docker", "prometheus/prometheus"},
+		{"ghcr.io", "https://ghcr.io/envoyproxy/envoy", "docker", "ghcr.io/envoyproxy/envoy"},
+		{"quay.io", "https://quay.io/repository/prometheus/prometheus", "docker", "quay.io/prometheus/prometheus"},
+		{"ecr public gallery", "https://gallery.ecr.aws/karpenter/controller", "docker", "public.ecr.aws/karpenter/controller"},
 		{"empty", "", "", ""},
 		{"unknown host", "https://example.com/foo/bar", "", ""},
 		{"no path", "https://hub.docker.com", "", ""},
@@ -197,6 +198,41 @@ func TestParsePackageManagerURL(t *testing.T) {
 	}
 }
 
+// Identifiers bootstrap derives from landscape URLs must resolve against the
+// registry they came from, not Docker Hub.
+func TestParsePackageManagerURLResolves(t *testing.T) {
+	srv := newFakeRegistries(t,
+		"/v2/repositories/envoyproxy/envoy/",
+		"/v2/fluxcd/flux/tags/list",
+		"/api/v1/repository/prometheus/prometheus",
+	)
+	defer srv.Close()
+	r := newTestPackageResolver(srv)
+
+	tests := []struct {
+		url  string
+		want PackageLookup
+	}{
+		{"https://hub.docker.com/r/envoyproxy/envoy", PackageFound},
+		{"https://ghcr.io/fluxcd/flux", PackageFound},
+		{"https://quay.io/repository/prometheus/prometheus", PackageFound},
+		{"https://gallery.ecr.aws/karpenter/controller", PackageUnchecked},
+	}
+	for _, tt := range tests {
+		reg, id := parsePackageManagerURL(tt.url)
+		if err := ValidatePackageIdentifier(reg, id); err != nil {
+			t.Errorf("%s: bootstrap emitted %s: %q, which does not validate: %v", tt.url, reg, id, err)
+		}
+		got, err := r.Resolve(reg, id)
+		if err != nil {
+			t.Errorf("%s: Resolve(%q, %q) error: %v", tt.url, reg, id, err)
+		}
+		if got != tt.want {
+			t.Errorf("%s: Resolve(%q, %q) = %v, want %v", tt.url, reg, id, got, tt.want)
+		}
+	}
+}
+
 func TestDetectPackageManagerFromFilename(t *testing.T) {
 	tests := []struct {
 		filename string
@@ -213,6 +213,8 @@ jobs:
       - uses: cncf/automation/.github/actions/validate-project@53810a548b46f33421cd67e57d16e4b7251416d9
         with:
           project_file: 'project.yaml'
+          # Emit ::error annotations so failures show inline on the PR diff.
+          output_format: 'github'
 
   validate-maintainers:
     runs-on: ubuntu-latest
@@ -224,6 +226,7 @@ jobs:
       - uses: cncf/automation/.github/actions/validate-maintainers@53810a548b46f33421cd67e57d16e4b7251416d9
         with:
           maintainers_file: 'maintainers.yaml'
+          output_format: 'github'
           # Disabled until the LFX LLT issue is resolved. Validation is done manually for now.
           verify_maintainers: 'false'
         env:
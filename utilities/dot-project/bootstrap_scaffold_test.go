@@ -364,6 +364,9 @@ func TestWriteScaffold(t *testing.T) {
 		if strings.Contains(lsStr, "uses: cncf/automation/.github/workflows/") {
 			t.Error("update-landscape.yml should use composite action pattern, not reusable workflow")
 		}
+		if strings.Contains(lsStr, "output_format") {
+			t.Error("update-landscape.yml should not pass output_format, which landscape-update does not accept")
+		}
 
 		// Spot-check .gitignore content
 		giData, _ := os.ReadFile(filepath.Join(dir, ".gitignore"))
@@ -0,0 +1,191 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bundle file names looked up inside a .project directory, in order.
+var (
+	bundleProjectFiles     = []string{"project.yaml", "project.yml"}
+	bundleMaintainersFiles = []string{"maintainers.yaml", "maintainers.yml"}
+)
+
+// ValidateBundle validates a whole .project directory: project.yaml and
+// maintainers.yaml are each validated as usual, then checked against each
+// other. Cross-file findings are attached to the file holding the field that
+// has to change:
+//
+//   - maintainers[i].project_id must equal the project slug
+//   - maintainers[i].org must be the GitHub org of one of the repositories
+//   - every project_lead handle must be a member of some maintainers team
+//
+// A missing maintainers file is reported as a warning on the project result,
+// and the maintainer results are then empty.
+func (pv *ProjectValidator) ValidateBundle(dir string, verify bool) ([]ValidationResult, []MaintainerValidationResult, error) {
+	projectPath := findBundleFile(dir, bundleProjectFiles)
+	if projectPath == "" {
+		return nil, nil, fmt.Errorf("no project.yaml found in %s", dir)
+	}
+
+	projectResult, err := pv.validateProject(projectPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	projectResults := []ValidationResult{projectResult}
+
+	maintainersPath := findBundleFile(dir, bundleMaintainersFiles)
+	if maintainersPath == "" {
+		addResultDiagnostics(&projectResults[0], []Diagnostic{{
+			Rule:     RuleBundleMissingFile,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("no maintainers.yaml found in %s; cross-file checks skipped", dir),
+			Fix:      "add maintainers.yaml listing the project-maintainers team",
+		}})
+		return projectResults, nil, nil
+	}
+
+	maintainerResults, err := pv.ValidateMaintainersFile(maintainersPath, verify)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Cross-file checks need both files decoded; decoding failures are
+	// already reported by the per-file validation above.
+	projectData, err := os.ReadFile(projectPath)
+	if err != nil {
+		return projectResults, maintainerResults, nil
+	}
+	project, _, parsed := validateProjectContent(string(projectData))
+	if !parsed {
+		return projectResults, maintainerResults, nil
+	}
+	maintainersData, err := os.ReadFile(maintainersPath)
+	if err != nil {
+		return projectResults, maintainerResults, nil
+	}
+	var config MaintainersConfig
+	if err := yaml.Unmarshal(maintainersData, &config); err != nil || len(config.Maintainers) != len(maintainerResults) {
+		return projectResults, maintainerResults, nil
+	}
+
+	projectDiags, entryDiags := crossCheckBundle(project, config)
+
+	if doc, err := parseYAMLDocument(projectData); err == nil {
+		LocateDiagnostics(projectDiags, doc)
+	}
+	addResultDiagnostics(&projectResults[0], projectDiags)
+
+	var maintainersRoot yaml.Node
+	hasRoot := yaml.Unmarshal(maintainersData, &maintainersRoot) == nil
+	for i, diags := range entryDiags {
+		if hasRoot {
+			LocateDiagnostics(diags, &maintainersRoot)
+		}
+		addMaintainerDiagnostics(&maintainerResults[i], diags)
+	}
+
+	return projectResults, maintainerResults, nil
+}
+
+// crossCheckBundle compares a project with its maintainers roster. It returns
+// diagnostics for project.yaml and, per maintainers entry, diagnostics for
+// maintainers.yaml with full "maintainers[i]..." paths.
+func crossCheckBundle(project Project, config MaintainersConfig) ([]Diagnostic, [][]Diagnostic) {
+	entryDiags := make([][]Diagnostic, len(config.Maintainers))
+
+	repoOrgs := repositoryOrgs(project.Repositories)
+	members := make(map[string]bool)
+	for i, entry := range config.Maintainers {
+		prefix := fmt.Sprintf("maintainers[%d]", i)
+
+		if project.Slug != "" && entry.ProjectID != "" && entry.ProjectID != project.Slug {
+			entryDiags[i] = append(entryDiags[i], errorDiag(prefix+".project_id", RuleBundleProjectID,
+				"project_id %q does not match project.yaml slug %q", entry.ProjectID, project.Slug).
+				withFix("set project_id: %q", project.Slug))
+		}
+
+		if entry.Org != "" && len(repoOrgs) > 0 && !repoOrgs[strings.ToLower(entry.Org)] {
+			d := errorDiag(prefix+".org", RuleBundleOrg,
+				"org %q does not match the GitHub org of any repository in project.yaml (%s)", entry.Org, strings.Join(sortedKeys(repoOrgs), ", "))
+			if len(repoOrgs) == 1 {
+				d = d.withFix("set org: %q", sortedKeys(repoOrgs)[0])
+			}
+			entryDiags[i] = append(entryDiags[i], d)
+		}
+
+		for _, team := range entry.Teams {
+			for _, m := range team.Members {
+				if h := normalizeHandle(m); h != "" {
+					members[h] = true
+				}
+			}
+		}
+	}
+
+	var projectDiags []Diagnostic
+	for i, lead := range project.ProjectLeads {
+		handle := strings.TrimPrefix(strings.TrimSpace(lead), "@")
+		// Team references (org/team-name) cannot be resolved offline.
+		if handle == "" || strings.Contains(handle, "/") {
+			continue
+		}
+		if !members[strings.ToLower(handle)] {
+			projectDiags = append(projectDiags, errorDiag(fmt.Sprintf("project_lead[%d]", i), RuleBundleProjectLead,
+				"project_lead %q is not a member of any team in maintainers.yaml", handle).
+				withFix("add %s to the project-maintainers team in maintainers.yaml", handle))
+		}
+	}
+	return projectDiags, entryDiags
+}
+
+// repositoryOrgs returns the lowercased GitHub orgs of the repositories.
+// Non-GitHub URLs are ignored.
+func repositoryOrgs(repos []RepositoryEntry) map[string]bool {
+	orgs := make(map[string]bool)
+	for _, r := range repos {
+		if !strings.Contains(r.URL, "github.com/") {
+			continue
+		}
+		if org, _, err := ParseGitHubURL(r.URL); err == nil && org != "" {
+			orgs[strings.ToLower(org)] = true
+		}
+	}
+	return orgs
+}
+
+// normalizeHandle trims, strips a leading '@' and lowercases a handle.
+func normalizeHandle(h string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(h), "@"))
+}
+
+// findBundleFile returns the first of names that exists in dir.
+func findBundleFile(dir string, names []string) string {
+	for _, name := range names {
+		p := filepath.Join(dir, name)
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p
+		}
+	}
+	return ""
+}
+
+// addResultDiagnostics merges extra diagnostics into a project result,
+// keeping Errors and Valid consistent.
+func addResultDiagnostics(r *ValidationResult, diags []Diagnostic) {
+	r.Diagnostics = append(r.Diagnostics, diags...)
+	r.Errors = append(r.Errors, diagnosticMessages(diags, SeverityError)...)
+	r.Valid = r.Valid && !hasErrorDiagnostics(diags)
+}
+
+// addMaintainerDiagnostics merges extra diagnostics into a maintainer result,
+// keeping Errors and Valid consistent.
+func addMaintainerDiagnostics(r *MaintainerValidationResult, diags []Diagnostic) {
+	r.Diagnostics = append(r.Diagnostics, diags...)
+	r.Errors = append(r.Errors, diagnosticMessages(diags, SeverityError)...)
+	r.Valid = r.Valid && !hasErrorDiagnostics(diags)
+}
@@ -0,0 +1,112 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateBundleExample(t *testing.T) {
+	pv := newTestValidator(t)
+	projectResults, maintainerResults, err := pv.ValidateBundle("example", false)
+	if err != nil {
+		t.Fatalf("ValidateBundle: %v", err)
+	}
+	if len(projectResults) != 1 || !projectResults[0].Valid {
+		t.Errorf("example project invalid: %+v", projectResults)
+	}
+	if len(maintainerResults) != 1 || !maintainerResults[0].Valid {
+		t.Errorf("example maintainers invalid: %+v", maintainerResults)
+	}
+}
+
+func TestValidateBundleCrossFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "project.yaml"), validProjectYAML()+`project_lead:
+  - "@Alice"
+  - "bob"
+  - "test/leads"
+`)
+	writeFile(t, filepath.Join(dir, "maintainers.yaml"), `maintainers:
+  - project_id: other-project
+    org: elsewhere
+    teams:
+      - name: project-maintainers
+        members:
+          - alice
+          - carol
+`)
+
+	pv := newTestValidator(t)
+	projectResults, maintainerResults, err := pv.ValidateBundle(dir, false)
+	if err != nil {
+		t.Fatalf("ValidateBundle: %v", err)
+	}
+
+	project := projectResults[0]
+	if project.Valid {
+		t.Error("project should be invalid: bob is not a maintainer")
+	}
+	d, ok := findDiagnostic(project.Diagnostics, "project_lead[1]")
+	if !ok || d.Rule != RuleBundleProjectLead {
+		t.Fatalf("missing project lead diagnostic: %+v", project.Diagnostics)
+	}
+	if d.Line == 0 {
+		t.Error("project lead diagnostic should be located")
+	}
+	if _, ok := findDiagnostic(project.Diagnostics, "project_lead[0]"); ok {
+		t.Error("@Alice matches alice case-insensitively and should pass")
+	}
+	if _, ok := findDiagnostic(project.Diagnostics, "project_lead[2]"); ok {
+		t.Error("team references should not be checked")
+	}
+
+	entry := maintainerResults[0]
+	if entry.Valid {
+		t.Error("maintainers entry should be invalid")
+	}
+	d, ok = findDiagnostic(entry.Diagnostics, "maintainers[0].project_id")
+	if !ok || d.Rule != RuleBundleProjectID || !strings.Contains(d.Fix, `"test-project"`) {
+		t.Errorf("project_id diagnostic = %+v, %v", d, ok)
+	}
+	if d.Line != 2 {
+		t.Errorf("project_id line = %d, want 2", d.Line)
+	}
+	d, ok = findDiagnostic(entry.Diagnostics, "maintainers[0].org")
+	if !ok || d.Rule != RuleBundleOrg || !strings.Contains(d.Fix, `"test"`) {
+		t.Errorf("org diagnostic = %+v, %v", d, ok)
+	}
+}
+
+func TestValidateBundleMissingMaintainers(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "project.yaml"), validProjectYAML())
+
+	pv := newTestValidator(t)
+	projectResults, maintainerResults, err := pv.ValidateBundle(dir, false)
+	if err != nil {
+		t.Fatalf("ValidateBundle: %v", err)
+	}
+	if maintainerResults != nil {
+		t.Errorf("maintainerResults = %+v, want nil", maintainerResults)
+	}
+	if !projectResults[0].Valid {
+		t.Errorf("missing maintainers.yaml should only warn: %+v", projectResults[0].Errors)
+	}
+	found := false
+	for _, d := range projectResults[0].Diagnostics {
+		if d.Rule == RuleBundleMissingFile && d.Severity == SeverityWarning {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected a bundle-missing-file warning")
+	}
+}
+
+func TestValidateBundleMissingProject(t *testing.T) {
+	pv := newTestValidator(t)
+	if _, _, err := pv.ValidateBundle(t.TempDir(), false); err == nil {
+		t.Error("expected an error for a directory without project.yaml")
+	}
+}
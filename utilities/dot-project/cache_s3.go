@@ -0,0 +1,255 @@
+package projects
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3CacheStore keeps the cache as one JSON object (the cache.json format) in
+// an S3-compatible bucket such as AWS S3 or MinIO, addressed path-style as
+// Endpoint/Bucket/Key. Requests are signed with AWS Signature Version 4.
+//
+// Concurrent writers are serialized optimistically: Save re-reads the
+// object, merges, and writes it back conditionally on the ETag it read
+// (If-Match, or If-None-Match: * for a new object), retrying on 412.
+type S3CacheStore struct {
+	Endpoint     string // e.g. https://s3.us-east-1.amazonaws.com or http://localhost:9000
+	Region       string
+	Bucket       string
+	Key          string
+	AccessKey    string
+	SecretKey    string
+	SessionToken string // Optional, for temporary credentials
+	Client       *http.Client
+}
+
+// NewS3CacheStoreFromEnv builds a store for an s3://bucket/prefix location.
+// The object is prefix/cache.json. Credentials and endpoint come from
+// AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY, AWS_SESSION_TOKEN, AWS_REGION
+// (default us-east-1) and AWS_ENDPOINT_URL (default the regional AWS
+// endpoint).
+func NewS3CacheStoreFromEnv(location string) (*S3CacheStore, error) {
+	rest := strings.TrimPrefix(location, "s3://")
+	bucket, prefix, _ := strings.Cut(rest, "/")
+	if bucket == "" {
+		return nil, fmt.Errorf("invalid S3 cache location %q (expected s3://bucket[/prefix])", location)
+	}
+	key := "cache.json"
+	if prefix = strings.Trim(prefix, "/"); prefix != "" {
+		key = prefix + "/" + key
+	}
+
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = "us-east-1"
+	}
+	endpoint := os.Getenv("AWS_ENDPOINT_URL")
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	store := &S3CacheStore{
+		Endpoint:     endpoint,
+		Region:       region,
+		Bucket:       bucket,
+		Key:          key,
+		AccessKey:    os.Getenv("AWS_ACCESS_KEY_ID"),
+		SecretKey:    os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		SessionToken: os.Getenv("AWS_SESSION_TOKEN"),
+	}
+	if store.AccessKey == "" || store.SecretKey == "" {
+		return nil, fmt.Errorf("S3 cache %s needs AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY", location)
+	}
+	return store, nil
+}
+
+// Load fetches the cache object. A missing object is an empty cache.
+func (s *S3CacheStore) Load() (map[string]CacheEntry, error) {
+	entries, _, err := s.get()
+	return entries, err
+}
+
+// Save merges entries into the stored object and writes it back, retrying
+// up to DefaultCacheSaveAttempts times if another writer got there first.
+func (s *S3CacheStore) Save(entries map[string]CacheEntry) error {
+	for attempt := 0; attempt < DefaultCacheSaveAttempts; attempt++ {
+		stored, etag, err := s.get()
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(mergeCacheEntries(stored, entries), "", "  ")
+		if err != nil {
+			return err
+		}
+
+		header := http.Header{"Content-Type": {"application/json"}}
+		if etag == "" {
+			header.Set("If-None-Match", "*")
+		} else {
+			header.Set("If-Match", etag)
+		}
+		resp, err := s.do(http.MethodPut, data, header)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return nil
+		case resp.StatusCode == http.StatusPreconditionFailed || resp.StatusCode == http.StatusConflict:
+			continue // concurrent write; merge again
+		default:
+			return fmt.Errorf("S3 PUT %s/%s returned HTTP %d", s.Bucket, s.Key, resp.StatusCode)
+		}
+	}
+	return fmt.Errorf("S3 cache %s/%s: gave up after %d conflicting writes", s.Bucket, s.Key, DefaultCacheSaveAttempts)
+}
+
+// get returns the stored entries and the object's ETag ("" if absent).
+func (s *S3CacheStore) get() (map[string]CacheEntry, string, error) {
+	resp, err := s.do(http.MethodGet, nil, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	entries := make(map[string]CacheEntry)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return entries, "", nil
+	default:
+		return nil, "", fmt.Errorf("S3 GET %s/%s returned HTTP %d", s.Bucket, s.Key, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, "", fmt.Errorf("S3 cache object %s/%s is corrupted: %w", s.Bucket, s.Key, err)
+	}
+	return entries, resp.Header.Get("ETag"), nil
+}
+
+// do sends a signed request for the cache object.
+func (s *S3CacheStore) do(method string, body []byte, header http.Header) (*http.Response, error) {
+	u, err := url.Parse(strings.TrimRight(s.Endpoint, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", s.Endpoint, err)
+	}
+	u.Path += "/" + s.Bucket + "/" + s.Key
+
+	req, err := http.NewRequest(method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	signS3Request(req, body, s.Region, s.AccessKey, s.SecretKey, s.SessionToken, time.Now().UTC())
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	return client.Do(req)
+}
+
+// signS3Request adds AWS Signature Version 4 headers for the s3 service.
+func signS3Request(req *http.Request, body []byte, region, accessKey, secretKey, sessionToken string, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := t.Format("20060102")
+	payloadHash := sha256Hex(body)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	canonicalRequest, signedHeaders := canonicalS3Request(req, payloadHash)
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, region)
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, sha256Hex([]byte(canonicalRequest))}, "\n")
+
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, signedHeaders, signature))
+}
+
+// canonicalS3Request builds the SigV4 canonical request. It signs the host
+// header and every x-amz-* header.
+func canonicalS3Request(req *http.Request, payloadHash string) (string, string) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+	headers := map[string]string{"host": host}
+	for k, v := range req.Header {
+		if lk := strings.ToLower(k); strings.HasPrefix(lk, "x-amz-") {
+			headers[lk] = strings.TrimSpace(strings.Join(v, ","))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for k := range headers {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, k := range names {
+		canonicalHeaders.WriteString(k + ":" + headers[k] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonical := strings.Join([]string{
+		req.Method,
+		awsURIEscape(req.URL.Path),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	return canonical, signedHeaders
+}
+
+// awsURIEscape percent-encodes a path as SigV4 requires: everything except
+// unreserved characters and '/'.
+func awsURIEscape(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if c == '/' || c == '-' || c == '_' || c == '.' || c == '~' ||
+			('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
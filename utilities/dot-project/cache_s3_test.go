@@ -0,0 +1,187 @@
+package projects
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 is a minimal S3-compatible object server: GET and PUT of single
+// objects with ETags and If-Match / If-None-Match preconditions. It checks
+// every request's SigV4 signature.
+type fakeS3 struct {
+	secretKey string
+	region    string
+
+	mu       sync.Mutex
+	objects  map[string][]byte
+	versions map[string]int
+	puts     int
+	// beforePut, if set, runs before a PUT is applied, e.g. to simulate a
+	// concurrent writer.
+	beforePut func()
+}
+
+func newFakeS3(t *testing.T) (*fakeS3, *httptest.Server) {
+	t.Helper()
+	f := &fakeS3{secretKey: "secret", region: "us-east-1", objects: map[string][]byte{}, versions: map[string]int{}}
+	return f, httptest.NewServer(f)
+}
+
+func (f *fakeS3) etag(key string) string {
+	return fmt.Sprintf(`"v%d"`, f.versions[key])
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	if !f.validSignature(r, body) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodPut && f.beforePut != nil {
+		hook := f.beforePut
+		f.beforePut = nil
+		hook()
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	key := r.URL.Path
+	data, exists := f.objects[key]
+	switch r.Method {
+	case http.MethodGet:
+		if !exists {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("ETag", f.etag(key))
+		w.Write(data)
+	case http.MethodPut:
+		if m := r.Header.Get("If-Match"); m != "" && (!exists || m != f.etag(key)) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		if r.Header.Get("If-None-Match") == "*" && exists {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		f.objects[key] = body
+		f.versions[key]++
+		f.puts++
+		w.Header().Set("ETag", f.etag(key))
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// validSignature recomputes the SigV4 signature from the request as the
+// server received it. Failures surface to the client as HTTP 403.
+func (f *fakeS3) validSignature(r *http.Request, body []byte) bool {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") {
+		return false
+	}
+	if r.Header.Get("X-Amz-Content-Sha256") != sha256Hex(body) {
+		return false
+	}
+	t, err := time.Parse("20060102T150405Z", r.Header.Get("X-Amz-Date"))
+	if err != nil {
+		return false
+	}
+	check := r.Clone(r.Context())
+	check.Header = r.Header.Clone()
+	signS3Request(check, body, f.region, "AKID", f.secretKey, "", t)
+	return check.Header.Get("Authorization") == auth
+}
+
+func newTestS3Store(server *httptest.Server) *S3CacheStore {
+	return &S3CacheStore{
+		Endpoint:  server.URL,
+		Region:    "us-east-1",
+		Bucket:    "validator",
+		Key:       "ci/cache.json",
+		AccessKey: "AKID",
+		SecretKey: "secret",
+		Client:    server.Client(),
+	}
+}
+
+func TestS3CacheStoreMerge(t *testing.T) {
+	_, server := newFakeS3(t)
+	defer server.Close()
+	testCacheStoreMerge(t, newTestS3Store(server))
+}
+
+func TestS3CacheStoreMissingObject(t *testing.T) {
+	_, server := newFakeS3(t)
+	defer server.Close()
+	entries, err := newTestS3Store(server).Load()
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Load = %v, %v; want empty cache", entries, err)
+	}
+}
+
+func TestS3CacheStoreRetriesOnConflict(t *testing.T) {
+	fake, server := newFakeS3(t)
+	defer server.Close()
+
+	store := newTestS3Store(server)
+	other := newTestS3Store(server)
+	now := time.Now()
+
+	// Another validator saves between our read and our write.
+	fake.beforePut = func() {
+		if err := other.Save(map[string]CacheEntry{"other": {URL: "other", Content: "x", LastChecked: now}}); err != nil {
+			t.Errorf("concurrent Save: %v", err)
+		}
+	}
+	if err := store.Save(map[string]CacheEntry{"mine": {URL: "mine", Content: "y", LastChecked: now}}); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entries["other"]; !ok {
+		t.Error("concurrent writer's entry was lost")
+	}
+	if _, ok := entries["mine"]; !ok {
+		t.Error("own entry missing after retry")
+	}
+	if fake.puts != 2 {
+		t.Errorf("successful PUTs = %d, want 2", fake.puts)
+	}
+}
+
+func TestS3CacheStoreRejectedSignature(t *testing.T) {
+	_, server := newFakeS3(t)
+	defer server.Close()
+
+	store := newTestS3Store(server)
+	store.SecretKey = "wrong"
+	if _, err := store.Load(); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Errorf("Load with a bad key = %v, want HTTP 403", err)
+	}
+}
+
+func TestNewS3CacheStoreFromEnv(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_REGION", "eu-west-1")
+	t.Setenv("AWS_ENDPOINT_URL", "http://localhost:9000")
+
+	store, err := NewS3CacheStoreFromEnv("s3://bucket/dot-project/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if store.Bucket != "bucket" || store.Key != "dot-project/cache.json" || store.Region != "eu-west-1" || store.Endpoint != "http://localhost:9000" {
+		t.Errorf("store = %+v", store)
+	}
+}
@@ -0,0 +1,306 @@
+package projects
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CacheStore persists the validator cache between runs.
+//
+// Save must not lose entries written by another validator sharing the
+// store since Load: implementations merge with what is stored, keeping the
+// most recently checked entry per URL, under a lock or an equivalent
+// optimistic-concurrency check.
+type CacheStore interface {
+	Load() (map[string]CacheEntry, error)
+	Save(entries map[string]CacheEntry) error
+}
+
+// OpenCacheStore returns the store for a cache location:
+//
+//	.cache, file://.cache    FileCacheStore: one cache.json in the directory
+//	cas://.cache             DirCacheStore: content-addressed directory
+//	s3://bucket/prefix       S3CacheStore configured from AWS_* environment variables
+func OpenCacheStore(location string) (CacheStore, error) {
+	switch {
+	case strings.HasPrefix(location, "s3://"):
+		return NewS3CacheStoreFromEnv(location)
+	case strings.HasPrefix(location, "cas://"):
+		return NewDirCacheStore(strings.TrimPrefix(location, "cas://"))
+	default:
+		return NewFileCacheStore(strings.TrimPrefix(location, "file://"))
+	}
+}
+
+// mergeCacheEntries merges entries into stored, keeping the entry with the
+// later LastChecked for each URL.
+func mergeCacheEntries(stored, entries map[string]CacheEntry) map[string]CacheEntry {
+	merged := make(map[string]CacheEntry, len(stored)+len(entries))
+	for url, e := range stored {
+		merged[url] = e
+	}
+	for url, e := range entries {
+		if prev, ok := merged[url]; ok && prev.LastChecked.After(e.LastChecked) {
+			continue
+		}
+		merged[url] = e
+	}
+	return merged
+}
+
+// FileCacheStore keeps the whole cache in DIR/cache.json. This is the
+// original cache format.
+type FileCacheStore struct {
+	dir string
+}
+
+// NewFileCacheStore creates dir if needed and returns a store for
+// dir/cache.json.
+func NewFileCacheStore(dir string) (*FileCacheStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory %q: %w", dir, err)
+	}
+	return &FileCacheStore{dir: dir}, nil
+}
+
+func (s *FileCacheStore) path() string {
+	return filepath.Join(s.dir, "cache.json")
+}
+
+// Load reads cache.json. A missing file is an empty cache; a corrupted one
+// is removed and also treated as empty.
+func (s *FileCacheStore) Load() (map[string]CacheEntry, error) {
+	unlock, err := lockPath(s.path())
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+	return s.read()
+}
+
+func (s *FileCacheStore) read() (map[string]CacheEntry, error) {
+	entries := make(map[string]CacheEntry)
+	cachePath := s.path()
+	data, err := os.ReadFile(cachePath)
+	if os.IsNotExist(err) {
+		return entries, nil // New cache
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cache file %q: %w", cachePath, err)
+	}
+
+	if err := json.Unmarshal(data, &entries); err != nil {
+		log.Printf("Warning: cache file %q is corrupted, removing and starting fresh: %v", cachePath, err)
+		if removeErr := os.Remove(cachePath); removeErr != nil {
+			log.Printf("Warning: failed to remove corrupted cache file %q: %v", cachePath, removeErr)
+		}
+		return make(map[string]CacheEntry), nil
+	}
+	return entries, nil
+}
+
+// Save merges entries into cache.json under a lock and replaces the file
+// atomically.
+func (s *FileCacheStore) Save(entries map[string]CacheEntry) error {
+	unlock, err := lockPath(s.path())
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	stored, err := s.read()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(mergeCacheEntries(stored, entries), "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(), data)
+}
+
+// DirCacheStore is a content-addressed cache directory. File contents are
+// stored once under objects/<hash[:2]>/<hash>, keyed by their SHA-256, and
+// each URL has a small entry file under entries/ that refers to its content
+// by hash. Unchanged content is therefore never rewritten, only the small
+// entry file with its check time, and identical content is shared.
+type DirCacheStore struct {
+	dir string
+}
+
+// NewDirCacheStore creates the directory layout if needed.
+func NewDirCacheStore(dir string) (*DirCacheStore, error) {
+	for _, sub := range []string{"objects", "entries"} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, fmt.Errorf("failed to create cache directory %q: %w", dir, err)
+		}
+	}
+	return &DirCacheStore{dir: dir}, nil
+}
+
+func (s *DirCacheStore) objectPath(hash string) string {
+	return filepath.Join(s.dir, "objects", hash[:2], hash)
+}
+
+func (s *DirCacheStore) entryPath(url string) string {
+	sum := sha256.Sum256([]byte(url))
+	return filepath.Join(s.dir, "entries", hex.EncodeToString(sum[:])+".json")
+}
+
+// Load reads every entry and its content. Entries whose content object is
+// missing are returned without content. Unreadable entry files are skipped
+// with a warning.
+func (s *DirCacheStore) Load() (map[string]CacheEntry, error) {
+	unlock, err := lockPath(filepath.Join(s.dir, "entries"))
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	files, err := filepath.Glob(filepath.Join(s.dir, "entries", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]CacheEntry, len(files))
+	for _, f := range files {
+		entry, err := readDirCacheEntry(f)
+		if err != nil {
+			log.Printf("Warning: skipping unreadable cache entry %q: %v", f, err)
+			continue
+		}
+		if len(entry.Hash) > 2 {
+			if content, err := os.ReadFile(s.objectPath(entry.Hash)); err == nil {
+				entry.Content = string(content)
+			}
+		}
+		entries[entry.URL] = entry
+	}
+	return entries, nil
+}
+
+func readDirCacheEntry(path string) (CacheEntry, error) {
+	var entry CacheEntry
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return entry, err
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return entry, err
+	}
+	if entry.URL == "" {
+		return entry, fmt.Errorf("entry has no url")
+	}
+	return entry, nil
+}
+
+// Save writes new content objects and updates entry files, leaving an entry
+// alone when the stored one was checked more recently or is identical. An
+// entry without content keeps the hash it was given, so it still refers to
+// content stored earlier.
+func (s *DirCacheStore) Save(entries map[string]CacheEntry) error {
+	unlock, err := lockPath(filepath.Join(s.dir, "entries"))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	for _, url := range sortedKeys(entries) {
+		entry := entries[url]
+		entry.URL = url
+		entryPath := s.entryPath(url)
+		if stored, err := readDirCacheEntry(entryPath); err == nil && stored.LastChecked.After(entry.LastChecked) {
+			continue
+		}
+
+		if entry.Content != "" || entry.Hash == "" {
+			entry.Hash = calculateHash(entry.Content)
+			objectPath := s.objectPath(entry.Hash)
+			if _, err := os.Stat(objectPath); os.IsNotExist(err) {
+				if err := os.MkdirAll(filepath.Dir(objectPath), 0755); err != nil {
+					return err
+				}
+				if err := writeFileAtomic(objectPath, []byte(entry.Content)); err != nil {
+					return err
+				}
+			}
+		}
+
+		entry.Content = ""
+		data, err := json.MarshalIndent(entry, "", "  ")
+		if err != nil {
+			return err
+		}
+		if stored, err := os.ReadFile(entryPath); err == nil && bytes.Equal(stored, data) {
+			continue
+		}
+		if err := writeFileAtomic(entryPath, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it into place, so readers never see a partial file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Chmod(tmpName, 0644); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	if err := os.Rename(tmpName, path); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+	return nil
+}
+
+// lockPath takes an exclusive lock on path by creating path+".lock". It
+// waits up to DefaultCacheLockTimeout, and breaks locks older than
+// DefaultCacheLockStaleAfter left behind by crashed runs. The returned
+// function releases the lock.
+func lockPath(path string) (func(), error) {
+	lockFile := path + ".lock"
+	deadline := time.Now().Add(DefaultCacheLockTimeout)
+	for {
+		f, err := os.OpenFile(lockFile, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return func() { os.Remove(lockFile) }, nil
+		}
+		if !os.IsExist(err) {
+			return nil, fmt.Errorf("failed to lock cache %q: %w", path, err)
+		}
+		if info, statErr := os.Stat(lockFile); statErr == nil && time.Since(info.ModTime()) > DefaultCacheLockStaleAfter {
+			log.Printf("Warning: removing stale cache lock %q", lockFile)
+			os.Remove(lockFile)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for cache lock %q; remove it if no other validator is running", lockFile)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
@@ -0,0 +1,200 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestOpenCacheStore(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		location string
+		want     string
+	}{
+		{filepath.Join(dir, "plain"), "*projects.FileCacheStore"},
+		{"file://" + filepath.Join(dir, "file"), "*projects.FileCacheStore"},
+		{"cas://" + filepath.Join(dir, "cas"), "*projects.DirCacheStore"},
+	}
+	for _, tt := range tests {
+		store, err := OpenCacheStore(tt.location)
+		if err != nil {
+			t.Fatalf("OpenCacheStore(%q): %v", tt.location, err)
+		}
+		if got := fmt.Sprintf("%T", store); got != tt.want {
+			t.Errorf("OpenCacheStore(%q) = %s, want %s", tt.location, got, tt.want)
+		}
+	}
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "")
+	if _, err := OpenCacheStore("s3://bucket/prefix"); err == nil {
+		t.Error("expected an error for S3 without credentials")
+	}
+}
+
+// testCacheStoreMerge checks that two writers sharing a store keep each
+// other's entries and that the most recently checked entry wins.
+func testCacheStoreMerge(t *testing.T, store CacheStore) {
+	t.Helper()
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := older.Add(time.Hour)
+
+	if err := store.Save(map[string]CacheEntry{
+		"a": {URL: "a", Hash: calculateHash("a1"), Content: "a1", LastChecked: newer},
+		"b": {URL: "b", Hash: calculateHash("b1"), Content: "b1", LastChecked: older},
+	}); err != nil {
+		t.Fatalf("first Save: %v", err)
+	}
+	// A second writer that loaded before the first save.
+	if err := store.Save(map[string]CacheEntry{
+		"a": {URL: "a", Hash: calculateHash("a0"), Content: "a0", LastChecked: older},
+		"b": {URL: "b", Hash: calculateHash("b2"), Content: "b2", LastChecked: newer},
+		"c": {URL: "c", Hash: calculateHash("c1"), Content: "c1", LastChecked: older, ETag: `"c"`},
+	}); err != nil {
+		t.Fatalf("second Save: %v", err)
+	}
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	want := map[string]string{"a": "a1", "b": "b2", "c": "c1"}
+	for url, content := range want {
+		if got := entries[url]; got.Content != content || got.Hash != calculateHash(content) {
+			t.Errorf("entries[%q] = %+v, want content %q", url, got, content)
+		}
+	}
+	if entries["c"].ETag != `"c"` {
+		t.Errorf("ETag not preserved: %+v", entries["c"])
+	}
+}
+
+func TestFileCacheStoreMerge(t *testing.T) {
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCacheStoreMerge(t, store)
+}
+
+func TestDirCacheStoreMerge(t *testing.T) {
+	store, err := NewDirCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCacheStoreMerge(t, store)
+}
+
+func TestDirCacheStoreSharesContent(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDirCacheStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	if err := store.Save(map[string]CacheEntry{
+		"https://a.example/project.yaml": {Content: "same", LastChecked: now},
+		"https://b.example/project.yaml": {Content: "same", LastChecked: now},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	objects, _ := filepath.Glob(filepath.Join(dir, "objects", "*", "*"))
+	if len(objects) != 1 {
+		t.Errorf("expected 1 content object, got %v", objects)
+	}
+	entries, _ := filepath.Glob(filepath.Join(dir, "entries", "*.json"))
+	if len(entries) != 2 {
+		t.Errorf("expected 2 entry files, got %v", entries)
+	}
+}
+
+func TestDirCacheStoreSave(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewDirCacheStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const url = "https://a.example/project.yaml"
+	now := time.Now().UTC()
+	entry := CacheEntry{Content: "content", LastChecked: now}
+	if err := store.Save(map[string]CacheEntry{url: entry}); err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.Stat(store.entryPath(url))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Saving the same entry again leaves its file alone.
+	if err := store.Save(map[string]CacheEntry{url: entry}); err != nil {
+		t.Fatal(err)
+	}
+	if after, err := os.Stat(store.entryPath(url)); err != nil || !os.SameFile(before, after) {
+		t.Errorf("unchanged entry was rewritten")
+	}
+
+	// An entry without content keeps its hash and still finds its content.
+	hash := calculateHash("content")
+	if err := store.Save(map[string]CacheEntry{url: {Hash: hash, LastChecked: now.Add(time.Hour)}}); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loaded[url]; got.Hash != hash || got.Content != "content" {
+		t.Errorf("entry saved without content = %+v, want hash %s with its content", got, hash)
+	}
+}
+
+func TestFileCacheStoreConcurrentSaves(t *testing.T) {
+	store, err := NewFileCacheStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			url := fmt.Sprintf("p%d", i)
+			if err := store.Save(map[string]CacheEntry{url: {URL: url, Content: url, LastChecked: time.Now()}}); err != nil {
+				t.Errorf("Save %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	entries, err := store.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != writers {
+		t.Errorf("got %d entries, want %d: concurrent saves lost data", len(entries), writers)
+	}
+}
+
+func TestLockPathBreaksStaleLock(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.json")
+	writeFile(t, path+".lock", "12345\n")
+	old := time.Now().Add(-2 * DefaultCacheLockStaleAfter)
+	if err := os.Chtimes(path+".lock", old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	unlock, err := lockPath(path)
+	if err != nil {
+		t.Fatalf("lockPath: %v", err)
+	}
+	unlock()
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Error("lock file should be removed on unlock")
+	}
+}
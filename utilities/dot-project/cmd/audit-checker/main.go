@@ -19,6 +19,9 @@ func main() {
 		projectFile  = flag.String("project", "", "Path to project.yaml file (required)")
 		outputFormat = flag.String("output", "text", "Output format: text, json, yaml")
 		timeout      = flag.Int("timeout", 10, "HTTP request timeout in seconds")
+		repoDir      = flag.String("repo-dir", "", "Local checkout of the primary repository to verify relative paths against (default: GitHub contents API)")
+		githubToken  = flag.String("github-token", "", "GitHub token for the contents API (or set GITHUB_TOKEN env)")
+		skipPaths    = flag.Bool("skip-paths", false, "Skip verifying relative paths in the primary repository")
 	)
 	flag.Parse()
 
@@ -36,6 +39,19 @@ func main() {
 	client := &http.Client{Timeout: time.Duration(*timeout) * time.Second}
 	result := projects.AuditProject(project, client)
 
+	if !*skipPaths {
+		token := *githubToken
+		if token == "" {
+			token = os.Getenv("GITHUB_TOKEN")
+		}
+		branch, checker, err := pathChecker(project, *repoDir, token, client)
+		if err != nil {
+			log.Printf("Warning: relative paths not verified: %v", err)
+		} else {
+			result.AddChecks(projects.AuditPathRefs(project, branch, checker))
+		}
+	}
+
 	switch *outputFormat {
 	case "json":
 		data, _ := json.MarshalIndent(result, "", "  ")
@@ -51,3 +67,27 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// pathChecker picks how relative paths are verified: against the local
+// checkout when repoDir is set, otherwise through the GitHub contents API on
+// the primary repository's default branch.
+func pathChecker(project projects.Project, repoDir, token string, client *http.Client) (string, projects.RepoFileChecker, error) {
+	if repoDir != "" {
+		branch, err := projects.LocalDefaultBranch(repoDir)
+		if err != nil {
+			return "", nil, err
+		}
+		return branch, projects.LocalRepoChecker{Dir: repoDir}, nil
+	}
+
+	repoURL := projects.PrimaryRepositoryURL(project.Repositories)
+	org, repo, err := projects.ParseGitHubURL(repoURL)
+	if err != nil || repo == "" {
+		return "", nil, fmt.Errorf("primary repository %q is not a GitHub repository; use -repo-dir", repoURL)
+	}
+	branch, err := projects.GitHubDefaultBranch(org, repo, token, client, "")
+	if err != nil {
+		return "", nil, err
+	}
+	return branch, projects.GitHubContentsChecker{Org: org, Repo: repo, Ref: branch, Token: token, Client: client}, nil
+}
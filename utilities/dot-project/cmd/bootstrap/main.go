@@ -0,0 +1,448 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+
+	"projects"
+)
+
+func main() {
+	var (
+		name           = flag.String("name", "", "Project display name to search for (e.g., 'Kubernetes')")
+		githubOrg      = flag.String("github-org", "", "GitHub organization (e.g., 'kubernetes')")
+		githubRepo     = flag.String("github-repo", "", "Primary GitHub repository name (e.g., 'kubernetes')")
+		githubToken    = flag.String("github-token", "", "GitHub personal access token (or set GITHUB_TOKEN env)")
+		outputDir      = flag.String("output-dir", ".", "Directory to write scaffold output")
+		skipLandscape  = flag.Bool("skip-landscape", false, "Skip CNCF landscape YAML lookup")
+		skipCLO        = flag.Bool("skip-clomonitor", false, "Skip CLOMonitor API lookup")
+		skipGH         = flag.Bool("skip-github", false, "Skip GitHub API lookup")
+		maintainersCSV = flag.String("maintainers-csv", "", "Optional path to a local project-maintainers.csv (default: fetch from cncf/foundation)")
+		dryRun         = flag.Bool("dry-run", false, "Print generated YAML to stdout without writing files")
+		force          = flag.Bool("force", false, "Overwrite auxiliary files (never overwrites project.yaml or maintainers.yaml)")
+		envFile        = flag.String("env-file", ".env", "Path to a .env file to load (e.g. GITHUB_TOKEN=...); real env vars take precedence")
+	)
+	flag.Parse()
+
+	// Load a .env file (if present) before resolving the token below. Real
+	// environment variables always take precedence over file values.
+	if applied, err := projects.LoadDotEnv(*envFile); err != nil {
+		fmt.Fprintf(os.Stderr, "  Warning: could not read %s: %v\n", *envFile, err)
+	} else if len(applied) > 0 {
+		fmt.Fprintf(os.Stderr, "  Loaded %d variable(s) from %s\n", len(applied), *envFile)
+	}
+
+	// Validate required inputs
+	if *name == "" && *githubOrg == "" {
+		fmt.Fprintln(os.Stderr, "Error: at least one of -name or -github-org is required")
+		fmt.Fprintln(os.Stderr)
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	// Normalize --github-org and --github-repo: accept full GitHub URLs or plain slugs.
+	// e.g. https://github.com/meshery/meshery → org="meshery", repo="meshery"
+	var org, repo string
+	if *githubOrg != "" {
+		var impliedRepo string
+		var err error
+		org, impliedRepo, err = projects.ParseGitHubURL(*githubOrg)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --github-org: %v\n", err)
+			os.Exit(1)
+		}
+		// If the org URL contained a repo segment and --github-repo wasn't set, use it.
+		if impliedRepo != "" && *githubRepo == "" {
+			repo = impliedRepo
+		}
+	}
+	if *githubRepo != "" {
+		var repoOrg string
+		var err error
+		repoOrg, repo, err = projects.ParseGitHubURL(*githubRepo)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --github-repo: %v\n", err)
+			os.Exit(1)
+		}
+		// URL had only one segment (github.com/repo) — the org segment is the repo name.
+		if repo == "" {
+			repo = repoOrg
+		}
+	}
+
+	// Derive defaults
+	projectName := *name
+	if projectName == "" {
+		projectName = org
+	}
+	if repo == "" && org != "" {
+		repo = org // Common pattern: org name == primary repo name
+	}
+
+	// Slug: lowercase, hyphenated
+	slug := strings.ToLower(strings.ReplaceAll(projectName, " ", "-"))
+	slug = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			return r
+		}
+		return -1
+	}, slug)
+	// Clean up multiple consecutive hyphens
+	for strings.Contains(slug, "--") {
+		slug = strings.ReplaceAll(slug, "--", "-")
+	}
+	slug = strings.Trim(slug, "-")
+
+	// GitHub token from env if not provided via flag (GITHUB_TOKEN, then GH_TOKEN). The value
+	// may come from the shell or from the .env file loaded above.
+	token := *githubToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		token = os.Getenv("GH_TOKEN")
+	}
+	if token == "" {
+		fmt.Fprintf(os.Stderr, "  Note: no GitHub token set (-github-token / GITHUB_TOKEN / GH_TOKEN / %s).\n", *envFile)
+		fmt.Fprintln(os.Stderr, "        Unauthenticated GitHub API requests are rate-limited (HTTP 403 once exceeded).")
+		fmt.Fprintln(os.Stderr, "        Provide a token via any of:")
+		fmt.Fprintf(os.Stderr, "          - an env file (%s) containing:  GITHUB_TOKEN=ghp_xxx\n", *envFile)
+		fmt.Fprintln(os.Stderr, "          - the environment:         GITHUB_TOKEN=ghp_xxx go run ./cmd/bootstrap ...")
+		fmt.Fprintln(os.Stderr, "          - the flag:                -github-token ghp_xxx")
+	}
+
+	client := &http.Client{Timeout: projects.DefaultHTTPTimeout}
+
+	fmt.Fprintf(os.Stderr, "Bootstrapping project: %s (slug: %s)\n", projectName, slug)
+
+	// Phase 1: Fetch from CNCF Landscape
+	var landscapeData *projects.LandscapeData
+	if !*skipLandscape {
+		fmt.Fprintf(os.Stderr, "  Fetching from CNCF landscape...\n")
+		var err error
+		landscapeData, err = projects.FetchFromLandscape(projectName, client, "")
+		if err != nil {
+			log.Printf("  Warning: Landscape fetch failed: %v", err)
+		} else if landscapeData != nil {
+			fmt.Fprintf(os.Stderr, "  Found in landscape: %s (maturity: %s, category: %s / %s)\n",
+				landscapeData.Name, landscapeData.Maturity, landscapeData.Category, landscapeData.Subcategory)
+		} else {
+			fmt.Fprintf(os.Stderr, "  Not found in landscape\n")
+		}
+	}
+
+	// Phase 2: Fetch from CLOMonitor
+	// Build a de-duplicated list of name variants to try, most specific first:
+	//   1. Landscape display name
+	//   2. User-supplied project name
+	//   3. GitHub org name
+	var cloProject *projects.CLOMonitorProject
+	if !*skipCLO {
+		fmt.Fprintf(os.Stderr, "  Fetching from CLOMonitor...\n")
+
+		seen := map[string]bool{}
+		var cloSearchNames []string
+		landscapeName := ""
+		if landscapeData != nil {
+			landscapeName = landscapeData.Name
+		}
+		for _, n := range []string{landscapeName, projectName, org} {
+			if n != "" && !seen[n] {
+				seen[n] = true
+				cloSearchNames = append(cloSearchNames, n)
+			}
+		}
+
+		for _, sn := range cloSearchNames {
+			var err error
+			cloProject, err = projects.FetchFromCLOMonitor(sn, client, "")
+			if err != nil {
+				log.Printf("  Warning: CLOMonitor fetch failed for %q: %v", sn, err)
+				continue
+			}
+			if cloProject != nil {
+				break
+			}
+		}
+
+		if cloProject != nil {
+			fmt.Fprintf(os.Stderr, "  Found on CLOMonitor: %s (maturity: %s, score: %.0f)\n",
+				cloProject.DisplayName, cloProject.Maturity, cloProject.Score.Global)
+		} else {
+			fmt.Fprintf(os.Stderr, "  Not found on CLOMonitor\n")
+		}
+	}
+
+	// Phase 3: Fetch from GitHub
+	var ghData *projects.GitHubData
+	if !*skipGH && org != "" {
+		fmt.Fprintf(os.Stderr, "  Fetching from GitHub: %s/%s...\n", org, repo)
+		var err error
+		ghData, err = projects.FetchFromGitHub(org, repo, token, client, "")
+		if err != nil {
+			log.Printf("  Warning: GitHub fetch failed: %v", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "  Found on GitHub: %s\n", ghData.Repo.FullName)
+		}
+	}
+
+	// Phase 3.5: Search for TOC/sandbox onboarding issue (if no URL from landscape)
+	// Note: Works without a token (unauthenticated, lower rate limit) but
+	// a GITHUB_TOKEN is recommended to avoid hitting rate limits.
+	var tocURL string
+	if !*skipGH && (landscapeData == nil || landscapeData.AnnualReviewURL == "") {
+		fmt.Fprintf(os.Stderr, "  Searching for TOC/sandbox onboarding issue...\n")
+
+		// Build a de-duplicated list of name variants to try, most specific first:
+		//   1. Landscape display name
+		//   2. User-supplied project name
+		//   3. GitHub org name
+		seen := map[string]bool{}
+		var tocSearchNames []string
+		for _, n := range []string{
+			func() string {
+				if landscapeData != nil {
+					return landscapeData.Name
+				}
+				return ""
+			}(),
+			projectName,
+			org,
+		} {
+			if n != "" && !seen[n] {
+				seen[n] = true
+				tocSearchNames = append(tocSearchNames, n)
+			}
+		}
+
+		var tocErr error
+		for _, sn := range tocSearchNames {
+			tocURL, tocErr = projects.SearchTOCIssues(sn, org, token, client, "")
+			if tocErr != nil {
+				log.Printf("  Warning: TOC issue search failed for %q: %v", sn, tocErr)
+				continue
+			}
+			if tocURL != "" {
+				break
+			}
+		}
+		if tocURL != "" {
+			fmt.Fprintf(os.Stderr, "  Found TOC/onboarding issue: %s\n", tocURL)
+		} else {
+			fmt.Fprintf(os.Stderr, "  No TOC/onboarding issue found\n")
+		}
+	}
+
+	// Phase 3.7: Discover maintainers from the CNCF foundation maintainers CSV.
+	var csvMaintainers []string
+	{
+		seen := map[string]bool{}
+		var searchNames []string
+		add := func(n string) {
+			n = strings.TrimSpace(n)
+			if n != "" && !seen[strings.ToLower(n)] {
+				seen[strings.ToLower(n)] = true
+				searchNames = append(searchNames, n)
+			}
+		}
+		if landscapeData != nil {
+			add(landscapeData.Name)
+		}
+		add(projectName)
+		add(org)
+		add(repo)
+		if cloProject != nil {
+			add(cloProject.DisplayName)
+		}
+
+		// Report the CSV source (local path or remote URL) for visibility; any
+		// fetch/parse issue with it is surfaced in the warning logged below.
+		csvSource := *maintainersCSV
+		if csvSource == "" {
+			csvSource = projects.DefaultFoundationMaintainersCSVURL
+		}
+		fmt.Fprintf(os.Stderr, "  Discovering maintainers from foundation CSV (%s)...\n", csvSource)
+		blocks, err := projects.FetchFoundationMaintainers(*maintainersCSV, client)
+		if err != nil {
+			log.Printf("  Warning: maintainers CSV lookup failed: %v", err)
+		} else {
+			csvMaintainers = projects.MatchProjectMaintainers(blocks, searchNames...)
+			if len(csvMaintainers) > 0 {
+				fmt.Fprintf(os.Stderr, "  Discovered %d maintainer(s) from foundation CSV\n", len(csvMaintainers))
+			} else {
+				fmt.Fprintf(os.Stderr, "  No maintainers matched in foundation CSV for: %s\n", strings.Join(searchNames, ", "))
+			}
+		}
+	}
+
+	// Phase 3.8: Discover maintainer suggestions and Slack channels from org repos.
+	// Maintainer suggestions are advisory only — the foundation CSV remains the
+	// source of truth. Slack channels are merged in as candidates to verify.
+	var suggestions []projects.MaintainerSuggestion
+	var orgSlackChannels []string
+	if !*skipGH && org != "" {
+		csvSet := map[string]bool{}
+		for _, h := range csvMaintainers {
+			csvSet[strings.ToLower(h)] = true
+		}
+		fmt.Fprintf(os.Stderr, "  Discovering maintainer suggestions and Slack channels from org repos...\n")
+		suggestions, orgSlackChannels = projects.DiscoverGovernanceSuggestions(org, repo, token, client, "", csvSet)
+		if len(suggestions) > 0 {
+			fmt.Fprintf(os.Stderr, "  Found %d maintainer suggestion(s) not yet in the CSV\n", len(suggestions))
+		} else {
+			fmt.Fprintf(os.Stderr, "  No additional maintainer suggestions found\n")
+		}
+		if len(orgSlackChannels) > 0 {
+			fmt.Fprintf(os.Stderr, "  Found %d Slack channel(s) across org repos\n", len(orgSlackChannels))
+		}
+	}
+
+	// Phase 4: Merge data
+	fmt.Fprintf(os.Stderr, "  Merging data sources...\n")
+	result := projects.MergeBootstrapData(slug, landscapeData, cloProject, ghData)
+
+	// Merge org-wide discovered Slack channels as additional candidates to verify.
+	if len(orgSlackChannels) > 0 {
+		projects.AddDiscoveredSlackChannels(result, orgSlackChannels)
+		result.TODOs = removeTODO(result.TODOs, "Set slack_channels")
+	}
+
+	// Maintainers come exclusively from the foundation CSV. On no match, leave
+	// the roster empty and flag a TODO.
+	result.Maintainers = csvMaintainers
+	result.TODOs = removeTODO(result.TODOs, "Add maintainer GitHub handles")
+	if len(csvMaintainers) > 0 {
+		result.Sources["maintainers"] = "foundation-csv"
+		if result.ProjectLead == "" {
+			result.ProjectLead = csvMaintainers[0]
+			result.Sources["project_lead"] = "foundation-csv"
+			result.TODOs = removeTODO(result.TODOs, "Set project_lead GitHub handle")
+		}
+	} else {
+		result.TODOs = append(result.TODOs,
+			"No maintainers found in cncf/foundation project-maintainers.csv — add maintainer handles manually")
+	}
+
+	// Apply TOC issue URL from search if not already set by landscape
+	if result.TOCIssueURL == "" && tocURL != "" {
+		result.TOCIssueURL = tocURL
+		result.Sources["toc_issue_url"] = "github_search"
+		// Remove the TOC issue TODO since we found one
+		var filteredTODOs []string
+		for _, todo := range result.TODOs {
+			if todo != "Add maturity_log entry with TOC issue URL" {
+				filteredTODOs = append(filteredTODOs, todo)
+			}
+		}
+		result.TODOs = filteredTODOs
+	}
+
+	// Ensure org/repo are set even if GitHub fetch was skipped
+	if result.GitHubOrg == "" && org != "" {
+		result.GitHubOrg = org
+	}
+	if result.GitHubRepo == "" && repo != "" {
+		result.GitHubRepo = repo
+	}
+
+	// Phase 5: Generate output
+	if *dryRun {
+		fmt.Fprintln(os.Stderr, "\n--- project.yaml ---")
+		projectYAML, err := projects.GenerateProjectYAML(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating project.yaml: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(projectYAML))
+
+		fmt.Fprintln(os.Stderr, "--- maintainers.yaml ---")
+		maintainersYAML, err := projects.GenerateMaintainersYAML(result)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error generating maintainers.yaml: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(maintainersYAML))
+	} else {
+		fmt.Fprintf(os.Stderr, "  Writing scaffold to %s...\n", *outputDir)
+		var opts []projects.WriteScaffoldOption
+		if *force {
+			opts = append(opts, projects.WithForce())
+		}
+		if err := projects.WriteScaffold(*outputDir, result, opts...); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "\nScaffold written to %s:\n", *outputDir)
+		fmt.Fprintf(os.Stderr, "  - project.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - maintainers.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - README.md\n")
+		if result.SecurityPolicyURL == "" {
+			fmt.Fprintf(os.Stderr, "  - SECURITY.md\n")
+		} else {
+			fmt.Fprintf(os.Stderr, "  - SECURITY.md (skipped: using %s)\n", result.SecurityPolicyURL)
+		}
+		fmt.Fprintf(os.Stderr, "  - CODEOWNERS\n")
+		fmt.Fprintf(os.Stderr, "  - .gitignore\n")
+		fmt.Fprintf(os.Stderr, "  - .github/workflows/validate.yaml\n")
+		fmt.Fprintf(os.Stderr, "  - .github/workflows/update-landscape.yml\n")
+
+		// Report discovered file URLs
+		if result.SecurityPolicyURL != "" || result.ContributingURL != "" || result.CodeOfConductURL != "" || result.LicenseURL != "" {
+			fmt.Fprintln(os.Stderr, "\nDiscovered existing files:")
+			if result.SecurityPolicyURL != "" {
+				fmt.Fprintf(os.Stderr, "  SECURITY.md: %s\n", result.SecurityPolicyURL)
+			}
+			if result.ContributingURL != "" {
+				fmt.Fprintf(os.Stderr, "  CONTRIBUTING.md: %s\n", result.ContributingURL)
+			}
+			if result.CodeOfConductURL != "" {
+				fmt.Fprintf(os.Stderr, "  CODE_OF_CONDUCT: %s\n", result.CodeOfConductURL)
+			}
+			if result.LicenseURL != "" {
+				fmt.Fprintf(os.Stderr, "  LICENSE: %s\n", result.LicenseURL)
+			}
+		}
+	}
+
+	if section := projects.BuildSuggestionsSection(suggestions); section != "" {
+		fmt.Fprintf(os.Stderr, "\nMaintainer suggestions (from org governance files, not yet in the CSV):\n\n%s\n", section)
+		if !*dryRun {
+			if path, err := projects.WriteSuggestionsFile(*outputDir, suggestions); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not write suggestions file: %v\n", err)
+			} else if path != "" {
+				fmt.Fprintf(os.Stderr, "  Wrote maintainer suggestions to %s\n", path)
+			}
+		}
+	}
+
+	// Show TODOs
+	if len(result.TODOs) > 0 {
+		fmt.Fprintln(os.Stderr, "\nRemaining TODOs:")
+		for _, todo := range result.TODOs {
+			fmt.Fprintf(os.Stderr, "  - %s\n", todo)
+		}
+	}
+
+	// Show data sources
+	if len(result.Sources) > 0 {
+		fmt.Fprintln(os.Stderr, "\nData sources used:")
+		for field, source := range result.Sources {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", field, source)
+		}
+	}
+}
+
+// removeTODO returns todos without any entries equal to target.
+func removeTODO(todos []string, target string) []string {
+	var out []string
+	for _, t := range todos {
+		if t != target {
+			out = append(out, t)
+		}
+	}
+	return out
+}
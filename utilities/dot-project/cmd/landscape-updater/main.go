@@ -7,6 +7,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -59,7 +60,11 @@ func main() {
 
 	// Update using line-level edits
 	newLines, updated := updateLandscape(&root, &project, lines)
-	if !updated {
+	newLines, subprojectsUpdated, err := updateSubprojects(&project, newLines)
+	if err != nil {
+		log.Fatalf("Failed to parse updated landscape YAML: %v", err)
+	}
+	if !updated && !subprojectsUpdated {
 		log.Printf("No matching entry found or no changes needed for project %s", project.Name)
 		os.Exit(0)
 	}
@@ -177,6 +182,36 @@ func createPullRequest(landscapePath, landscapeRepo string, project *projects.Pr
 // updateLandscape navigates the YAML node tree to find the matching project
 // entry, then applies line-level edits to the raw file lines.
 func updateLandscape(root *yaml.Node, project *projects.Project, lines []string) ([]string, bool) {
+	var repoURLs []string
+	for _, repo := range project.Repositories {
+		repoURLs = append(repoURLs, repo.URL)
+	}
+	return updateLandscapeEntry(root, projects.ProjectToLandscapeEntry(*project), repoURLs, lines)
+}
+
+// updateSubprojects updates the landscape entry of each subproject, matched
+// by name and primary repository. Edits shift line numbers, so the landscape
+// is parsed again for each subproject.
+func updateSubprojects(project *projects.Project, lines []string) ([]string, bool, error) {
+	updated := false
+	for _, entry := range projects.SubprojectLandscapeEntries(*project) {
+		if entry.RepoURL == "" {
+			continue
+		}
+		var root yaml.Node
+		if err := yaml.Unmarshal([]byte(strings.Join(lines, "\n")), &root); err != nil {
+			return lines, updated, err
+		}
+		var changed bool
+		lines, changed = updateLandscapeEntry(&root, entry, []string{entry.RepoURL}, lines)
+		updated = updated || changed
+	}
+	return lines, updated, nil
+}
+
+// updateLandscapeEntry finds the landscape item matching entry's name and one
+// of repoURLs, then applies line-level edits to the raw file lines.
+func updateLandscapeEntry(root *yaml.Node, entry projects.LandscapeEntry, repoURLs []string, lines []string) ([]string, bool) {
 	if root.Kind != yaml.DocumentNode {
 		return lines, false
 	}
@@ -226,7 +261,7 @@ func updateLandscape(root *yaml.Node, project *projects.Project, lines []string)
 			}
 
 			for _, itemNode := range itemsSeq.Content {
-				newLines, matched := matchAndUpdateItem(itemNode, project, lines)
+				newLines, matched := matchAndUpdateItem(itemNode, entry, repoURLs, lines)
 				if matched {
 					return newLines, true
 				}
@@ -237,9 +272,9 @@ func updateLandscape(root *yaml.Node, project *projects.Project, lines []string)
 	return lines, false
 }
 
-// matchAndUpdateItem checks if the given item node matches the project (by name
+// matchAndUpdateItem checks if the given item node matches the entry (by name
 // and repo_url), and if so, detects changes and applies line-level edits.
-func matchAndUpdateItem(itemNode *yaml.Node, project *projects.Project, lines []string) ([]string, bool) {
+func matchAndUpdateItem(itemNode *yaml.Node, entry projects.LandscapeEntry, repoURLs []string, lines []string) ([]string, bool) {
 	var nameNode *yaml.Node
 	var repoURLNode *yaml.Node
 
@@ -257,10 +292,10 @@ func matchAndUpdateItem(itemNode *yaml.Node, project *projects.Project, lines []
 		return lines, false
 	}
 
-	nameMatch := strings.EqualFold(nameNode.Value, project.Name)
+	nameMatch := strings.EqualFold(nameNode.Value, entry.Name)
 	repoMatch := false
-	for _, repo := range project.Repositories {
-		if strings.EqualFold(repoURLNode.Value, repo.URL) {
+	for _, repoURL := range repoURLs {
+		if strings.EqualFold(repoURLNode.Value, repoURL) {
 			repoMatch = true
 			break
 		}
@@ -270,7 +305,7 @@ func matchAndUpdateItem(itemNode *yaml.Node, project *projects.Project, lines []
 		return lines, false
 	}
 
-	edits := detectChanges(itemNode, project)
+	edits := detectChanges(itemNode, entry)
 	if len(edits) == 0 {
 		return lines, false
 	}
@@ -279,9 +314,13 @@ func matchAndUpdateItem(itemNode *yaml.Node, project *projects.Project, lines []
 	return newLines, true
 }
 
-// detectChanges compares the YAML node tree values against the project and
+// unsyncedExtraKeys are extra fields of a LandscapeEntry that the updater
+// leaves to landscape maintainers.
+var unsyncedExtraKeys = map[string]bool{"slug": true}
+
+// detectChanges compares the YAML node tree values against the entry and
 // returns a list of field edits needed. This is read-only on the node tree.
-func detectChanges(itemNode *yaml.Node, project *projects.Project) []fieldEdit {
+func detectChanges(itemNode *yaml.Node, entry projects.LandscapeEntry) []fieldEdit {
 	var edits []fieldEdit
 
 	checkField := func(key, newValue string, isExtra bool, node *yaml.Node) {
@@ -304,29 +343,26 @@ func detectChanges(itemNode *yaml.Node, project *projects.Project) []fieldEdit {
 	}
 
 	// Top-level fields
-	checkField("homepage_url", project.Website, false, itemNode)
-	checkField("description", project.Description, false, itemNode)
-
-	if val, ok := project.Social["twitter"]; ok {
-		checkField("twitter", val, false, itemNode)
-	}
+	checkField("homepage_url", entry.HomepageURL, false, itemNode)
+	checkField("description", entry.Description, false, itemNode)
 
-	// Extra fields
-	extraMappings := map[string]string{
-		"slack":    "slack_url",
-		"linkedin": "linkedin_url",
-		"youtube":  "youtube_url",
-	}
+	// Social links, with handles already expanded to URLs
+	checkField("twitter", entry.Twitter, false, itemNode)
 
-	needsExtra := false
-	for key := range extraMappings {
-		if _, ok := project.Social[key]; ok {
-			needsExtra = true
-			break
+	// Extra fields: social links and, for subprojects, parent_project
+	extras := make(map[string]string)
+	for key, val := range entry.Extra {
+		if s, ok := val.(string); ok && !unsyncedExtraKeys[key] {
+			extras[key] = s
 		}
 	}
+	var extraKeys []string
+	for key := range extras {
+		extraKeys = append(extraKeys, key)
+	}
+	sort.Strings(extraKeys)
 
-	if needsExtra {
+	if len(extraKeys) > 0 {
 		var extraNode *yaml.Node
 		for i := 0; i < len(itemNode.Content); i += 2 {
 			if itemNode.Content[i].Value == "extra" {
@@ -335,11 +371,8 @@ func detectChanges(itemNode *yaml.Node, project *projects.Project) []fieldEdit {
 			}
 		}
 
-		for socialKey, landscapeKey := range extraMappings {
-			val, ok := project.Social[socialKey]
-			if !ok || val == "" {
-				continue
-			}
+		for _, landscapeKey := range extraKeys {
+			val := extras[landscapeKey]
 			if extraNode != nil {
 				checkField(landscapeKey, val, true, extraNode)
 			} else {
@@ -920,6 +920,58 @@ func TestAllPRsNoNoiseRegression(t *testing.T) {
 	}
 }
 
+func TestUpdateSubprojects(t *testing.T) {
+	landscapeYAML := `landscape:
+  - category:
+    name: Orchestration & Management
+    subcategories:
+      - subcategory:
+        name: Scheduling
+        items:
+          - item:
+            name: Kubernetes
+            repo_url: https://github.com/kubernetes/kubernetes
+            homepage_url: https://kubernetes.io
+          - item:
+            name: Kind
+            repo_url: https://github.com/kubernetes-sigs/kind
+            homepage_url: https://old.kind.sigs.k8s.io
+          - item:
+            name: Kubectl
+            repo_url: https://github.com/kubernetes/kubectl
+            homepage_url: https://kubernetes.io
+            extra:
+              parent_project: Kubernetes`
+
+	_, lines := setupTest(landscapeYAML)
+	project := &projects.Project{
+		Name:         "Kubernetes",
+		Website:      "https://kubernetes.io",
+		Repositories: []projects.RepositoryEntry{{URL: "https://github.com/kubernetes/kubernetes"}},
+		Subprojects: []projects.Subproject{
+			{Name: "Kind", Slug: "kind", Website: "https://kind.sigs.k8s.io", Repositories: []projects.RepositoryEntry{{URL: "https://github.com/kubernetes-sigs/kind"}}},
+			{Name: "Kubectl", Repositories: []projects.RepositoryEntry{{URL: "https://github.com/kubernetes/kubectl"}}},
+			{Name: "Not In Landscape", Repositories: []projects.RepositoryEntry{{URL: "https://github.com/kubernetes/missing"}}},
+		},
+	}
+
+	newLines, updated, err := updateSubprojects(project, lines)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !updated {
+		t.Fatal("Expected the Kind entry to be updated")
+	}
+	want := strings.Replace(landscapeYAML, `            homepage_url: https://old.kind.sigs.k8s.io
+`, `            homepage_url: https://kind.sigs.k8s.io
+            extra:
+              parent_project: Kubernetes
+`, 1)
+	if got := strings.Join(newLines, "\n"); got != want {
+		t.Errorf("Unexpected output.\nGot:\n%s\nWant:\n%s", got, want)
+	}
+}
+
 func TestYamlQuoteIfNeeded(t *testing.T) {
 	tests := []struct {
 		input    string
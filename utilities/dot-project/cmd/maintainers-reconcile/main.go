@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"projects"
+)
+
+func main() {
+	var (
+		maintainersFile = flag.String("maintainers", "maintainers.yaml", "Path to maintainers.yaml")
+		projectFile     = flag.String("project", "", "Optional project.yaml whose name and slug are also matched against the CSV")
+		projectID       = flag.String("project-id", "", "Only reconcile this project_id (default: every entry)")
+		csvPath         = flag.String("csv", "", "Local foundation maintainers CSV (default: fetch the published CSV)")
+		names           = flag.String("names", "", "Comma-separated extra names to match against the CSV project labels")
+		patch           = flag.String("patch", "", "Print a patch that brings one side in line with the other: yaml (apply the CSV to maintainers.yaml) or csv (apply maintainers.yaml to the CSV)")
+		outputFormat    = flag.String("output", "text", "Output format: text, json")
+	)
+	flag.Parse()
+
+	if *patch != "" && *patch != "yaml" && *patch != "csv" {
+		fmt.Fprintln(os.Stderr, "Error: -patch must be yaml or csv")
+		flag.Usage()
+		os.Exit(1)
+	}
+
+	yamlContent, err := os.ReadFile(*maintainersFile)
+	if err != nil {
+		log.Fatalf("Failed to read maintainers file: %v", err)
+	}
+	var config projects.MaintainersConfig
+	if err := yaml.Unmarshal(yamlContent, &config); err != nil {
+		log.Fatalf("Failed to parse maintainers file: %v", err)
+	}
+
+	extra := splitNames(*names)
+	if *projectFile != "" {
+		project, err := projects.LoadProjectFromFile(*projectFile)
+		if err != nil {
+			log.Fatalf("Failed to load project: %v", err)
+		}
+		extra = append(extra, project.Name, project.Slug)
+	}
+
+	csvContent, err := projects.ReadFoundationMaintainersCSV(*csvPath, &http.Client{Timeout: projects.DefaultHTTPTimeout})
+	if err != nil {
+		log.Fatalf("Failed to load maintainers CSV: %v", err)
+	}
+	blocks, err := projects.ParseFoundationMaintainersCSV(csvContent)
+	if err != nil {
+		log.Fatalf("Failed to parse maintainers CSV: %v", err)
+	}
+
+	var results []projects.MaintainerReconciliation
+	for _, entry := range config.Maintainers {
+		if *projectID != "" && entry.ProjectID != *projectID {
+			continue
+		}
+		r, err := projects.ReconcileMaintainers(entry, blocks, extra...)
+		if err != nil {
+			log.Fatalf("Project %s: %v", entry.ProjectID, err)
+		}
+		results = append(results, r)
+	}
+	if len(results) == 0 {
+		log.Fatalf("No maintainers entry for project_id %q in %s", *projectID, *maintainersFile)
+	}
+
+	outOfSync := false
+	for _, r := range results {
+		outOfSync = outOfSync || !r.InSync()
+	}
+
+	if *patch != "" {
+		fmt.Print(buildPatch(*patch, *maintainersFile, *csvPath, yamlContent, csvContent, results))
+	} else if *outputFormat == "json" {
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(projects.FormatReconciliation(results))
+	}
+
+	if outOfSync {
+		os.Exit(1)
+	}
+}
+
+// buildPatch applies every result to the chosen side and returns the
+// unified diff against the original content.
+func buildPatch(side, maintainersFile, csvPath string, yamlContent, csvContent []byte, results []projects.MaintainerReconciliation) string {
+	name, original, patched := maintainersFile, yamlContent, yamlContent
+	if side == "csv" {
+		name, original, patched = csvPath, csvContent, csvContent
+		if name == "" {
+			name = "maintainers.csv"
+		}
+	}
+	for _, r := range results {
+		if r.InSync() {
+			continue
+		}
+		var err error
+		if side == "csv" {
+			patched, err = projects.PatchFoundationCSV(patched, r)
+		} else {
+			patched, err = projects.PatchMaintainersYAML(patched, r)
+		}
+		if err != nil {
+			log.Fatalf("Failed to patch %s for %s: %v", name, r.ProjectID, err)
+		}
+	}
+	name = strings.TrimPrefix(filepath.ToSlash(name), "/")
+	return projects.UnifiedDiff("a/"+name, "b/"+name, original, patched)
+}
+
+func splitNames(s string) []string {
+	var names []string
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
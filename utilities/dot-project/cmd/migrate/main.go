@@ -13,6 +13,10 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "upgrade" {
+		os.Exit(runUpgrade(os.Args[2:]))
+	}
+
 	var (
 		slug         = flag.String("slug", "", "Project slug (required, lowercase with hyphens)")
 		name         = flag.String("name", "", "Project display name (required)")
@@ -77,7 +81,7 @@ func main() {
 	}
 
 	project := projects.Project{
-		SchemaVersion: "1.0.0",
+		SchemaVersion: projects.LatestSchemaVersion(),
 		Slug:          *slug,
 		Name:          *name,
 		Description:   *description,
@@ -1,60 +1,26 @@
 package main
 
 import (
-	"bytes"
-	"encoding/json"
 	"flag"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
 	"sort"
 	"strings"
-	"sync"
 	"time"
 
-	"projects"
-)
+	"gopkg.in/yaml.v3"
 
-const (
-	userAgent = "cncf-dot-project-onboarding-report"
+	"projects"
 )
 
 // onboardedOrg holds an org and when its .project repo was created.
 type onboardedOrg struct {
-	Login     string
-	CreatedAt time.Time
-}
-
-// GraphQL types
-
-type graphQLRequest struct {
-	Query     string         `json:"query"`
-	Variables map[string]any `json:"variables,omitempty"`
-}
-
-type orgNode struct {
-	Login string `json:"login"`
-}
-
-type pageInfo struct {
-	HasNextPage bool   `json:"hasNextPage"`
-	EndCursor   string `json:"endCursor"`
-}
-
-type graphQLResponse struct {
-	Data struct {
-		Enterprise struct {
-			Organizations struct {
-				Nodes    []orgNode `json:"nodes"`
-				PageInfo pageInfo  `json:"pageInfo"`
-			} `json:"organizations"`
-		} `json:"enterprise"`
-	} `json:"data"`
-	Errors []struct {
-		Message string `json:"message"`
-	} `json:"errors"`
+	Login         string
+	CreatedAt     time.Time
+	DefaultBranch string
+	Archived      bool
 }
 
 func main() {
@@ -62,6 +28,7 @@ func main() {
 		enterprise = flag.String("enterprise", projects.DefaultEnterprise, "GitHub Enterprise slug")
 		outputFile = flag.String("output", "ONBOARDED.md", "Output markdown file path")
 		token      = flag.String("token", "", "GitHub token (or set GITHUB_TOKEN env)")
+		listFile   = flag.String("projectlist", "", "Also write a projectlist.yaml of the onboarded orgs to this path")
 	)
 	flag.Parse()
 
@@ -83,33 +50,10 @@ func main() {
 	log.Printf("Found %d organizations", len(orgs))
 
 	var onboarded []onboardedOrg
-	var mu sync.Mutex
-	var wg sync.WaitGroup
-
-	// Use a semaphore to limit concurrency (avoid hitting rate limits)
-	concurrency := 20
-	sem := make(chan struct{}, concurrency)
-
-	for _, o := range orgs {
-		wg.Add(1)
-		go func(org string) {
-			defer wg.Done()
-			sem <- struct{}{}        // acquire
-			defer func() { <-sem }() // release
-
-			createdAt, found, err := getDotProjectCreatedAt(client, ghToken, org)
-			if err != nil {
-				log.Printf("Warning: error checking %s/.project: %v", org, err)
-				return
-			}
-			if found {
-				mu.Lock()
-				onboarded = append(onboarded, onboardedOrg{Login: org, CreatedAt: createdAt})
-				mu.Unlock()
-			}
-		}(o)
+	// Check up to 20 orgs at a time (avoid hitting rate limits)
+	for _, repo := range projects.FindDotProjectRepos(client, projects.DefaultGitHubAPIURL, ghToken, orgs, 20) {
+		onboarded = append(onboarded, onboardedOrg{Login: repo.Org, CreatedAt: repo.CreatedAt, DefaultBranch: repo.DefaultBranch, Archived: repo.Archived})
 	}
-	wg.Wait()
 
 	// Sort by most recently onboarded first
 	sort.Slice(onboarded, func(i, j int) bool {
@@ -124,163 +68,42 @@ func main() {
 		log.Fatalf("Failed to write output file: %v", err)
 	}
 	log.Printf("Report written to %s", *outputFile)
-}
-
-// listEnterpriseOrgs uses the GitHub GraphQL API to paginate through all
-// organizations in the enterprise. Organizations that block the token (e.g.
-// orgs that forbid classic PATs) are skipped with a warning rather than
-// failing the whole listing.
-func listEnterpriseOrgs(client *http.Client, apiURL, token, enterprise string) ([]string, error) {
-	var allOrgs []string
-	var cursor *string
 
-	query := `
-query($enterprise: String!, $first: Int!, $after: String) {
-  enterprise(slug: $enterprise) {
-    organizations(first: $first, after: $after) {
-      nodes {
-        login
-      }
-      pageInfo {
-        hasNextPage
-        endCursor
-      }
-    }
-  }
-}`
-
-	for {
-		variables := map[string]any{
-			"enterprise": enterprise,
-			"first":      100,
-		}
-		if cursor != nil {
-			variables["after"] = *cursor
+	if *listFile != "" {
+		if err := writeProjectList(*listFile, onboarded); err != nil {
+			log.Fatalf("Failed to write project list: %v", err)
 		}
-
-		reqBody := graphQLRequest{
-			Query:     query,
-			Variables: variables,
-		}
-
-		bodyBytes, err := json.Marshal(reqBody)
-		if err != nil {
-			return nil, fmt.Errorf("marshaling request: %w", err)
-		}
-
-		req, err := http.NewRequest("POST", apiURL, bytes.NewReader(bodyBytes))
-		if err != nil {
-			return nil, err
-		}
-		req.Header.Set("Authorization", "Bearer "+token)
-		req.Header.Set("Content-Type", "application/json")
-		req.Header.Set("User-Agent", userAgent)
-
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, fmt.Errorf("request failed: %w", err)
-		}
-
-		respBody, err := io.ReadAll(resp.Body)
-		if closeErr := resp.Body.Close(); closeErr != nil {
-			log.Printf("Warning: failed to close response body: %v", closeErr)
-		}
-		if err != nil {
-			return nil, fmt.Errorf("reading response: %w", err)
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			return nil, fmt.Errorf("GraphQL API returned %d: %s", resp.StatusCode, string(respBody))
-		}
-
-		var gqlResp graphQLResponse
-		if err := json.Unmarshal(respBody, &gqlResp); err != nil {
-			return nil, fmt.Errorf("parsing response: %w", err)
-		}
-
-		if len(gqlResp.Errors) > 0 {
-			// Errors without any data mean the request itself failed (bad
-			// credentials, missing scope). Errors alongside data are per-org
-			// access blocks; keep the orgs that did come back.
-			if gqlResp.Data.Enterprise.Organizations.Nodes == nil {
-				msgs := make([]string, len(gqlResp.Errors))
-				for i, e := range gqlResp.Errors {
-					msgs[i] = e.Message
-				}
-				return nil, fmt.Errorf("GraphQL errors: %s", strings.Join(msgs, "; "))
-			}
-			for _, e := range gqlResp.Errors {
-				log.Printf("Warning: skipping inaccessible org: %s", e.Message)
-			}
-		}
-
-		// If the enterprise field is null/empty, the token likely lacks read:enterprise scope.
-		if gqlResp.Data.Enterprise.Organizations.Nodes == nil && cursor == nil {
-			return nil, fmt.Errorf(
-				"enterprise '%s' returned no organizations — your token likely lacks the 'read:enterprise' scope.\n"+
-					"  Run: gh auth refresh -s read:enterprise",
-				enterprise,
-			)
-		}
-
-		nodes := gqlResp.Data.Enterprise.Organizations.Nodes
-		for _, n := range nodes {
-			// Orgs the token cannot access surface as null nodes.
-			if n.Login == "" {
-				continue
-			}
-			allOrgs = append(allOrgs, n.Login)
-		}
-
-		pi := gqlResp.Data.Enterprise.Organizations.PageInfo
-		if !pi.HasNextPage {
-			break
-		}
-		cursor = &pi.EndCursor
+		log.Printf("Project list written to %s", *listFile)
 	}
-
-	return allOrgs, nil
 }
 
-// getDotProjectCreatedAt checks if an org has a .project repository and returns its creation date.
-func getDotProjectCreatedAt(client *http.Client, token, org string) (time.Time, bool, error) {
-	url := fmt.Sprintf("%s/repos/%s/.project", projects.DefaultGitHubAPIURL, org)
+// listEnterpriseOrgs returns the logins of all organizations in the
+// enterprise; see projects.ListEnterpriseOrgs.
+func listEnterpriseOrgs(client *http.Client, apiURL, token, enterprise string) ([]string, error) {
+	return projects.ListEnterpriseOrgs(client, apiURL, token, enterprise)
+}
 
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return time.Time{}, false, err
+// writeProjectList writes a projectlist.yaml pointing at project.yaml in each
+// onboarded org's .project repository, in the same shape the validator's
+// enterprise:// source produces. Archived .project repositories are skipped,
+// as they are there.
+func writeProjectList(path string, onboarded []onboardedOrg) error {
+	var list projects.ProjectListConfig
+	for _, o := range onboarded {
+		if o.Archived {
+			continue
+		}
+		list.Projects = append(list.Projects, projects.ProjectListEntry{
+			URL: fmt.Sprintf("%s/%s/.project/%s/project.yaml", projects.DefaultGitHubRawURL, o.Login, o.DefaultBranch),
+			ID:  o.Login,
+		})
 	}
-	req.Header.Set("Authorization", "Bearer "+token)
-	req.Header.Set("Accept", "application/vnd.github+json")
-	req.Header.Set("User-Agent", userAgent)
-	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
-
-	resp, err := client.Do(req)
+	sort.Slice(list.Projects, func(i, j int) bool { return list.Projects[i].ID < list.Projects[j].ID })
+	data, err := yaml.Marshal(list)
 	if err != nil {
-		return time.Time{}, false, fmt.Errorf("request failed: %w", err)
-	}
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			log.Printf("Warning: failed to close response body: %v", err)
-		}
-	}()
-
-	switch resp.StatusCode {
-	case http.StatusOK:
-		var repoData struct {
-			CreatedAt time.Time `json:"created_at"`
-		}
-		if err := json.NewDecoder(resp.Body).Decode(&repoData); err != nil {
-			return time.Time{}, true, fmt.Errorf("decoding response: %w", err)
-		}
-		return repoData.CreatedAt, true, nil
-	case http.StatusNotFound, http.StatusMovedPermanently:
-		return time.Time{}, false, nil
-	case http.StatusForbidden, http.StatusTooManyRequests:
-		return time.Time{}, false, fmt.Errorf("rate limited or forbidden (status %d)", resp.StatusCode)
-	default:
-		return time.Time{}, false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+		return err
 	}
+	return os.WriteFile(path, data, 0644)
 }
 
 // generateMarkdown produces the onboarding report.
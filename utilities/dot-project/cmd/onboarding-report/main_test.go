@@ -3,9 +3,15 @@ package main
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"testing"
+
+	"gopkg.in/yaml.v3"
+
+	"projects"
 )
 
 // newGraphQLServer serves the given JSON responses in order, one per request.
@@ -94,3 +100,30 @@ func TestListEnterpriseOrgsPaginates(t *testing.T) {
 		t.Errorf("orgs = %v, want %v", orgs, want)
 	}
 }
+
+func TestWriteProjectList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "projectlist.yaml")
+	onboarded := []onboardedOrg{
+		{Login: "zeta", DefaultBranch: "main"},
+		{Login: "alpha", DefaultBranch: "trunk"},
+		{Login: "retired", DefaultBranch: "main", Archived: true},
+	}
+	if err := writeProjectList(path, onboarded); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var list projects.ProjectListConfig
+	if err := yaml.Unmarshal(data, &list); err != nil {
+		t.Fatal(err)
+	}
+	want := []projects.ProjectListEntry{
+		{URL: "https://raw.githubusercontent.com/alpha/.project/trunk/project.yaml", ID: "alpha"},
+		{URL: "https://raw.githubusercontent.com/zeta/.project/main/project.yaml", ID: "zeta"},
+	}
+	if !reflect.DeepEqual(list.Projects, want) {
+		t.Errorf("projects = %+v, want %+v", list.Projects, want)
+	}
+}
@@ -5,7 +5,10 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"projects"
@@ -15,15 +18,35 @@ import (
 
 func main() {
 	var (
-		projectFile   = flag.String("project", "", "Path to project.yaml file (required)")
-		thresholdDays = flag.Int("threshold", projects.DefaultStalenessThresholdDays, "Days before considering maintainers stale")
-		lastUpdate    = flag.String("last-update", "", "Override last update date (YYYY-MM-DD format)")
-		outputFormat  = flag.String("output", "text", "Output format: text, json, yaml")
+		projectFile     = flag.String("project", "", "Path to project.yaml file (required)")
+		maintainersFile = flag.String("maintainers", "", "Path to maintainers.yaml (default: maintainers.yaml next to -project)")
+		thresholdDays   = flag.Int("threshold", projects.DefaultStalenessThresholdDays, "Days before considering maintainers stale")
+		lastUpdate      = flag.String("last-update", "", "Override last update date (YYYY-MM-DD format)")
+		source          = flag.String("source", "auto", "Where the last update comes from: auto, git, github, mtime")
+		repo            = flag.String("repo", "", "GitHub org/repo holding maintainers.yaml for -source github (default: <org>/.project, with the org of the primary repository)")
+		githubToken     = flag.String("github-token", "", "GitHub token (or set GITHUB_TOKEN env)")
+		mode            = flag.String("mode", "maintainers", "What to check: maintainers (when maintainers.yaml last changed), activity (when each maintainer last committed to the project repositories) or contributions (commits, reviews and comments of each maintainer over -months)")
+		months          = flag.Int("months", 12, "With -mode contributions: how many months of activity to count")
+		minContrib      = flag.Int("min-contributions", 1, "With -mode contributions: maintainers with fewer contributions are emeritus candidates")
+		outputFormat    = flag.String("output", "text", "Output format: text, json, yaml; with -config: markdown, json, csv")
+		notify          = flag.Bool("notify", false, "Open, update or close the staleness tracking issue in the .project repository (see -repo)")
+		dryRun          = flag.Bool("dry-run", false, "With -notify: print the issue that would be filed instead of changing anything")
+		configFile      = flag.String("config", "", "Check every project in this project list (file, URL, or enterprise://SLUG) and print a fleet report instead of checking -project")
 	)
 	flag.Parse()
 
+	token := *githubToken
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	client := &http.Client{Timeout: projects.DefaultHTTPTimeout}
+
+	if *configFile != "" {
+		os.Exit(checkFleet(*configFile, token, client, *thresholdDays, *outputFormat))
+	}
+
 	if *projectFile == "" {
-		fmt.Fprintln(os.Stderr, "Error: -project flag is required")
+		fmt.Fprintln(os.Stderr, "Error: -project or -config flag is required")
 		flag.Usage()
 		os.Exit(1)
 	}
@@ -32,23 +55,49 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to load project: %v", err)
 	}
+	if *maintainersFile == "" {
+		*maintainersFile = filepath.Join(filepath.Dir(*projectFile), "maintainers.yaml")
+	}
+
+	switch *mode {
+	case "activity":
+		os.Exit(checkActivity(project, *maintainersFile, token, client, *thresholdDays, *outputFormat))
+	case "contributions":
+		os.Exit(checkContributions(project, *maintainersFile, token, client, *months, *minContrib, *outputFormat))
+	}
 
 	var updateTime time.Time
+	updateSource := "override"
 	if *lastUpdate != "" {
 		updateTime, err = time.Parse("2006-01-02", *lastUpdate)
 		if err != nil {
 			log.Fatalf("Invalid date format: %v", err)
 		}
 	} else {
-		// Default: check file modification time
-		info, err := os.Stat(*projectFile)
+		updateTime, updateSource, err = lastMaintainersChange(project, *maintainersFile, *source, *repo, token, client)
 		if err != nil {
-			log.Fatalf("Failed to stat file: %v", err)
+			log.Fatalf("Failed to determine when maintainers were last updated: %v", err)
 		}
-		updateTime = info.ModTime()
 	}
 
 	result := projects.CheckStaleness(project, updateTime, *thresholdDays)
+	result.UpdateSource = updateSource
+
+	if *notify {
+		org, name, err := dotProjectRepo(project, *repo)
+		if err != nil {
+			log.Fatalf("Failed to find the .project repository: %v", err)
+		}
+		notifier := projects.StalenessNotifier{Org: org, Repo: name, Token: token, Client: client, DryRun: *dryRun}
+		outcome, err := notifier.Notify(result, *thresholdDays)
+		if err != nil {
+			log.Fatalf("Failed to notify: %v", err)
+		}
+		fmt.Print(projects.FormatNotifyResult(outcome, org, name))
+		// The tracking issue is the reminder; a stale project does not fail
+		// the job that files it.
+		return
+	}
 
 	switch *outputFormat {
 	case "json":
@@ -66,3 +115,183 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// checkFleet checks every project in the project list and prints a ranked
+// report. It returns 1 when any project is stale.
+func checkFleet(projectList, token string, client *http.Client, thresholdDays int, outputFormat string) int {
+	if outputFormat == "text" {
+		outputFormat = "markdown"
+	}
+	validator := projects.NewValidator(".cache")
+	results, err := validator.CheckFleetStaleness(projectList, projects.DefaultStalenessHistory(token, client), thresholdDays)
+	if err != nil {
+		log.Fatalf("Failed to check staleness: %v", err)
+	}
+	report, err := projects.FormatFleetStaleness(results, outputFormat)
+	if err != nil {
+		log.Fatalf("Failed to format report: %v", err)
+	}
+	fmt.Print(report)
+
+	for _, r := range results {
+		if r.IsStale {
+			return 1
+		}
+	}
+	return 0
+}
+
+// lastMaintainersChange returns when the maintainers file last changed and
+// which source said so. In auto mode git history is tried first, then the
+// GitHub commits API; if neither knows, it fails rather than guess. The file
+// modification time is only used when asked for with -source mtime, since
+// in a fresh checkout it is the clone time and makes every project look
+// fresh.
+func lastMaintainersChange(project projects.Project, path, source, repo, token string, client *http.Client) (time.Time, string, error) {
+	sources := []string{source}
+	if source == "auto" {
+		sources = []string{"git", "github"}
+	}
+
+	var errs []string
+	for _, s := range sources {
+		var history projects.RepoHistory
+		repoPath := filepath.Base(path)
+		switch s {
+		case "git":
+			history = projects.LocalGitHistory{Dir: filepath.Dir(path)}
+		case "github":
+			org, name, err := dotProjectRepo(project, repo)
+			if err != nil {
+				errs = append(errs, err.Error())
+				continue
+			}
+			history = projects.GitHubCommitHistory{Org: org, Repo: name, Token: token, Client: client}
+		case "mtime":
+			info, err := os.Stat(path)
+			if err != nil {
+				return time.Time{}, "", err
+			}
+			return info.ModTime(), "mtime", nil
+		default:
+			return time.Time{}, "", fmt.Errorf("unknown source %q (want auto, git, github or mtime)", s)
+		}
+		t, err := history.LastCommit(repoPath)
+		if err == nil {
+			return t, s, nil
+		}
+		errs = append(errs, fmt.Sprintf("%s: %v", s, err))
+	}
+	return time.Time{}, "", fmt.Errorf("%s", strings.Join(errs, "; "))
+}
+
+// dotProjectRepo returns the repository named by -repo, or the .project
+// repository of the primary repository's org.
+func dotProjectRepo(project projects.Project, repo string) (string, string, error) {
+	if repo != "" {
+		org, name, ok := strings.Cut(repo, "/")
+		if !ok || org == "" || name == "" {
+			return "", "", fmt.Errorf("-repo must be org/repo, got %q", repo)
+		}
+		return org, name, nil
+	}
+	org, _, err := projects.ParseGitHubURL(projects.PrimaryRepositoryURL(project.Repositories))
+	if err != nil || org == "" {
+		return "", "", fmt.Errorf("cannot tell the GitHub org from the primary repository; use -repo")
+	}
+	return org, ".project", nil
+}
+
+// checkActivity reports when each project maintainer last committed to the
+// project repositories and returns the exit code.
+func checkActivity(project projects.Project, maintainersFile, token string, client *http.Client, thresholdDays int, outputFormat string) int {
+	handles := maintainerHandles(maintainersFile)
+	repos := repositoryURLs(project)
+	results := projects.CheckMaintainerActivity(handles, repos, projects.GitHubCommitActivity{Token: token, Client: client}, thresholdDays)
+
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(results, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(results)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatMaintainerActivity(results, thresholdDays))
+	}
+
+	for _, r := range results {
+		if r.Inactive {
+			return 1
+		}
+	}
+	return 0
+}
+
+// checkContributions counts the commits, reviews and comments of each project
+// maintainer over the last months and lists the emeritus candidates. It
+// returns 1 when there are any.
+func checkContributions(project projects.Project, maintainersFile, token string, client *http.Client, months, minContributions int, outputFormat string) int {
+	if token == "" {
+		log.Fatalf("-mode contributions needs a GitHub token (-github-token or GITHUB_TOKEN)")
+	}
+	since := time.Now().AddDate(0, -months, 0)
+	source := projects.GitHubContributions{Token: token, Client: client}
+	report := source.Report(maintainerHandles(maintainersFile), repositoryURLs(project), since, minContributions)
+
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	case "yaml":
+		data, _ := yaml.Marshal(report)
+		fmt.Print(string(data))
+	default:
+		fmt.Print(projects.FormatContributionReport(report))
+	}
+
+	if len(report.EmeritusCandidates) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// maintainerHandles returns the de-duplicated project-maintainers handles in
+// the maintainers file, without the @ prefix.
+func maintainerHandles(maintainersFile string) []string {
+	data, err := os.ReadFile(maintainersFile)
+	if err != nil {
+		log.Fatalf("Failed to read maintainers file: %v", err)
+	}
+	var config projects.MaintainersConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		log.Fatalf("Failed to parse maintainers file: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	var handles []string
+	for _, entry := range config.Maintainers {
+		for _, team := range entry.Teams {
+			if team.Name != "project-maintainers" {
+				continue
+			}
+			for _, h := range team.Members {
+				h = strings.TrimPrefix(strings.TrimSpace(h), "@")
+				if h != "" && !seen[strings.ToLower(h)] {
+					seen[strings.ToLower(h)] = true
+					handles = append(handles, h)
+				}
+			}
+		}
+	}
+	return handles
+}
+
+// repositoryURLs returns the URLs of the project repositories.
+func repositoryURLs(project projects.Project) []string {
+	var repos []string
+	for _, r := range project.Repositories {
+		repos = append(repos, r.URL)
+	}
+	return repos
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"projects"
+)
+
+func main() {
+	var (
+		configFile          = flag.String("config", "yaml/projectlist.yaml", "Path to project list configuration file, or enterprise://SLUG to discover .project repos (needs GITHUB_TOKEN)")
+		includeOrgs         = flag.String("include-orgs", "", "With -config enterprise://SLUG, only orgs matching these comma-separated glob patterns")
+		excludeOrgs         = flag.String("exclude-orgs", "", "With -config enterprise://SLUG, skip orgs matching these comma-separated glob patterns")
+		cacheDir            = flag.String("cache", ".cache", "Cache location: a directory, cas://DIR (content-addressed) or s3://BUCKET/PREFIX")
+		maintainersFile     = flag.String("maintainers", "yaml/maintainers.yaml", "Path to maintainers file (set empty to skip)")
+		baseMaintainersFile = flag.String("base-maintainers", "", "Path to base maintainers file for diff validation")
+		verifyMaintainers   = flag.Bool("verify-maintainers", false, "Verify maintainer handles (see -verify-with)")
+		verifyWith          = flag.String("verify-with", "", "Comma-separated handle verifiers: github, lfx, allowlist; a handle passes if any accepts it (default: LFX when LFX_AUTH_TOKEN is set)")
+		handleAllowlist     = flag.String("handle-allowlist", "", "File of handles to accept without a lookup, one per line; adds the allowlist verifier")
+		verifyConcurrency   = flag.Int("verify-concurrency", projects.DefaultVerifyConcurrency, "Maximum number of handle lookups in flight")
+		outputFormat        = flag.String("output", "text", "Output format: text, json, yaml, sarif, github")
+		schemaFile          = flag.String("schema", "", "JSON Schema to check projects against alongside the Go rules (default: bundled schema/project.schema.json)")
+		skipSchema          = flag.Bool("skip-schema", false, "Skip the JSON Schema check")
+		policyFile          = flag.String("policy", "", "Maturity policy YAML file (default: bundled policy/maturity.yaml)")
+		skipPolicy          = flag.Bool("skip-policy", false, "Skip maturity policy checks")
+		showDiff            = flag.Bool("diff", false, "Print a Markdown digest of field-level changes since the cached run instead of the project report")
+		bundleDir           = flag.String("bundle", "", "Validate a .project directory (project.yaml + maintainers.yaml) with cross-file checks; ignores -config and -maintainers")
+		auditMaxAgeYears    = flag.Int("audit-max-age-years", projects.DefaultAuditMaxAgeYears, "Warn when an incubating or graduated project's last security audit is missing or older than this many years; negative disables")
+		resolvePackages     = flag.Bool("resolve-packages", false, "Check that package_managers identifiers exist in their registries (Docker Hub, GHCR, Quay, npm, PyPI, crates.io, Go proxy, Artifact Hub, Maven Central, RubyGems)")
+		fix                 = flag.Bool("fix", false, "Fix common mistakes in the project.yaml files given as arguments (default: project.yaml) in place, then report what is left")
+	)
+	flag.Parse()
+
+	if *fix {
+		os.Exit(runFix(flag.Args(), *outputFormat))
+	}
+
+	if *configFile == "" {
+		// Create a temporary dummy config file if none provided
+		f, err := os.CreateTemp("", "dummy-projectlist-*.yaml")
+		if err != nil {
+			log.Fatal("failed to create temporary config file")
+		}
+		f.WriteString("projects: []")
+		f.Close()
+		defer os.Remove(f.Name())
+		*configFile = f.Name()
+	}
+
+	validator := projects.NewValidator(*cacheDir)
+	if strings.HasPrefix(*configFile, projects.EnterpriseListPrefix) {
+		enterprise := strings.TrimPrefix(*configFile, projects.EnterpriseListPrefix)
+		if enterprise == "" {
+			enterprise = projects.DefaultEnterprise
+		}
+		validator.SetProjectListSource(&projects.EnterpriseProjectSource{
+			Enterprise: enterprise,
+			Token:      os.Getenv("GITHUB_TOKEN"),
+			Include:    splitList(*includeOrgs),
+			Exclude:    splitList(*excludeOrgs),
+		})
+	}
+	if *skipSchema {
+		validator.SetSchema(nil)
+	} else if *schemaFile != "" {
+		data, err := os.ReadFile(*schemaFile)
+		if err != nil {
+			log.Fatalf("failed to read schema: %v", err)
+		}
+		schema, err := projects.LoadJSONSchema(data)
+		if err != nil {
+			log.Fatalf("failed to load schema: %v", err)
+		}
+		validator.SetSchema(schema)
+	}
+	validator.SetAuditFreshness(&projects.AuditFreshness{MaxAgeYears: *auditMaxAgeYears})
+	if *resolvePackages {
+		validator.SetPackageResolver(&projects.PackageResolver{})
+	}
+	if *verifyWith != "" || *handleAllowlist != "" {
+		verifier, err := buildHandleVerifier(splitList(*verifyWith), *handleAllowlist)
+		if err != nil {
+			log.Fatalf("failed to set up handle verification: %v", err)
+		}
+		validator.SetHandleVerifier(projects.NewCachedHandleVerifier(verifier, *verifyConcurrency))
+	}
+	if *skipPolicy {
+		validator.SetPolicy(nil)
+	} else if *policyFile != "" {
+		policy, err := projects.LoadPolicyFile(*policyFile)
+		if err != nil {
+			log.Fatalf("failed to load policy: %v", err)
+		}
+		validator.SetPolicy(policy)
+	}
+
+	var (
+		projectResults     []projects.ValidationResult
+		maintainerResults  []projects.MaintainerValidationResult
+		maintainersEnabled bool
+	)
+	if *bundleDir != "" {
+		var err error
+		projectResults, maintainerResults, err = validator.ValidateBundle(*bundleDir, *verifyMaintainers)
+		if err != nil {
+			log.Fatalf("bundle validation failed: %v", err)
+		}
+		maintainersEnabled = maintainerResults != nil
+	} else {
+		results, err := validator.ValidateAll(*configFile)
+		if err != nil {
+			log.Fatalf("validation failed: %v", err)
+		}
+		projectResults = results
+		maintainersEnabled = *maintainersFile != ""
+	}
+
+	if maintainersEnabled && *bundleDir == "" {
+		var excludedHandles map[string]bool
+		if *baseMaintainersFile != "" {
+			handles, err := validator.ExtractHandles(*baseMaintainersFile)
+			if err != nil {
+				log.Fatalf("failed to extract handles from base maintainers file: %v", err)
+			}
+			excludedHandles = handles
+		}
+
+		results, err := validator.ValidateMaintainersFileWithExclusion(*maintainersFile, *verifyMaintainers, excludedHandles)
+		if err != nil {
+			log.Fatalf("maintainers validation failed: %v", err)
+		}
+		maintainerResults = results
+	}
+
+	if *showDiff {
+		printChangeDigest(projectResults, *outputFormat)
+	} else if *outputFormat == "sarif" {
+		// SARIF consumers expect a single log document, so project and
+		// maintainer findings are emitted together.
+		output, err := projects.FormatSARIF(projectResults, maintainerResults)
+		if err != nil {
+			log.Fatalf("failed to format SARIF results: %v", err)
+		}
+		fmt.Print(output)
+	} else {
+		printResults(validator, projectResults, maintainerResults, maintainersEnabled, *outputFormat)
+	}
+
+	// Check if any validation failed
+	hasErrors := false
+	for _, result := range projectResults {
+		if !result.Valid {
+			hasErrors = true
+			break
+		}
+	}
+	if !hasErrors && maintainersEnabled {
+		for _, result := range maintainerResults {
+			if !result.Valid {
+				hasErrors = true
+				break
+			}
+		}
+	}
+
+	if hasErrors {
+		os.Exit(1)
+	}
+}
+
+// printResults writes the project and maintainer reports in a per-section format.
+func printResults(validator *projects.ProjectValidator, projectResults []projects.ValidationResult, maintainerResults []projects.MaintainerValidationResult, maintainersEnabled bool, format string) {
+	output, err := validator.FormatResults(projectResults, format)
+	if err != nil {
+		log.Fatalf("failed to format project results: %v", err)
+	}
+	fmt.Print(output)
+	if maintainersEnabled {
+		fmt.Println()
+		maintainersOutput, err := validator.FormatMaintainersResults(maintainerResults, format)
+		if err != nil {
+			log.Fatalf("failed to format maintainer results: %v", err)
+		}
+		fmt.Print(maintainersOutput)
+	}
+}
+
+// printChangeDigest writes the field-level change digest: JSON for -output
+// json, Markdown otherwise.
+func printChangeDigest(projectResults []projects.ValidationResult, format string) {
+	if format != "json" {
+		fmt.Print(projects.FormatChangeDigest(projectResults))
+		return
+	}
+	data, err := json.MarshalIndent(projects.CollectProjectChanges(projectResults), "", "  ")
+	if err != nil {
+		log.Fatalf("failed to format change digest: %v", err)
+	}
+	fmt.Println(string(data))
+}
+
+// runFix applies the lint fixes to each file and prints a summary. It
+// returns a non-zero exit code if any file could not be read or still has
+// errors after fixing.
+func runFix(files []string, format string) int {
+	if len(files) == 0 {
+		files = []string{"project.yaml"}
+	}
+	var results []projects.LintResult
+	exitCode := 0
+	for _, file := range files {
+		result, err := projects.LintProjectFile(file)
+		if err != nil {
+			log.Printf("failed to fix %s: %v", file, err)
+			exitCode = 1
+			continue
+		}
+		if len(result.Remaining) > 0 {
+			exitCode = 1
+		}
+		results = append(results, result)
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			log.Fatalf("failed to format fix results: %v", err)
+		}
+		fmt.Println(string(data))
+	} else {
+		fmt.Print(projects.FormatLintResults(results))
+	}
+	return exitCode
+}
+
+// buildHandleVerifier combines the named verifiers. The allowlist is asked
+// first so listed handles never need a remote lookup.
+func buildHandleVerifier(names []string, allowlistFile string) (projects.HandleVerifier, error) {
+	var verifiers projects.AnyHandleVerifier
+	if allowlistFile != "" {
+		allowlist, err := projects.LoadHandleAllowlist(allowlistFile)
+		if err != nil {
+			return nil, err
+		}
+		verifiers = append(verifiers, allowlist)
+	}
+	for _, name := range names {
+		switch name {
+		case "github":
+			verifiers = append(verifiers, &projects.GitHubUserVerifier{Token: os.Getenv("GITHUB_TOKEN")})
+		case "lfx":
+			token := os.Getenv("LFX_AUTH_TOKEN")
+			if token == "" {
+				return nil, fmt.Errorf("the lfx verifier needs LFX_AUTH_TOKEN")
+			}
+			verifiers = append(verifiers, &projects.LFXVerifier{Token: token, BaseURL: os.Getenv("MAINTAINER_API_ENDPOINT")})
+		case "allowlist":
+			if allowlistFile == "" {
+				return nil, fmt.Errorf("the allowlist verifier needs -handle-allowlist")
+			}
+		default:
+			return nil, fmt.Errorf("unknown handle verifier %q (want github, lfx or allowlist)", name)
+		}
+	}
+	return verifiers, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty items.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,309 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContributionActivity counts one maintainer's contributions to the
+// project's repositories since a date.
+type ContributionActivity struct {
+	Handle   string `json:"handle" yaml:"handle"`
+	Commits  int    `json:"commits" yaml:"commits"`   // Commits authored on default branches
+	Reviews  int    `json:"reviews" yaml:"reviews"`   // Pull requests reviewed
+	Comments int    `json:"comments" yaml:"comments"` // Issues and pull requests commented on
+	Error    string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// Total is the sum of all contribution counts.
+func (a ContributionActivity) Total() int {
+	return a.Commits + a.Reviews + a.Comments
+}
+
+// ContributionReport is the contribution activity of a project's
+// maintainers, with the maintainers who fell below the minimum listed as
+// emeritus candidates.
+type ContributionReport struct {
+	Since              time.Time              `json:"since" yaml:"since"`
+	Repositories       []string               `json:"repositories" yaml:"repositories"`
+	MinContributions   int                    `json:"min_contributions" yaml:"min_contributions"`
+	Maintainers        []ContributionActivity `json:"maintainers" yaml:"maintainers"`
+	EmeritusCandidates []string               `json:"emeritus_candidates" yaml:"emeritus_candidates"`
+}
+
+// GitHubContributions counts contributions through the GitHub GraphQL API.
+// Commits are counted on each repository's default branch; reviews and
+// comments come from issue search, which counts each pull request or issue
+// once however many reviews or comments it received. Search cannot date a
+// review or comment, so these count pull requests and issues updated since
+// the date that the maintainer reviewed or commented on at any time, which
+// can include older activity on threads that are still busy.
+type GitHubContributions struct {
+	Token       string
+	Client      *http.Client
+	GraphQLURL  string // "" means DefaultGitHubGraphQLURL
+	Concurrency int    // Maintainers looked up in parallel; 0 means DefaultFetchConcurrency
+}
+
+// searchQueryLimit is the longest query GitHub search accepts.
+const searchQueryLimit = 256
+
+// commitHistoryBatch is how many repositories one commit-count query covers.
+const commitHistoryBatch = 25
+
+// Report counts the contributions of each handle to the GitHub repositories
+// among repos since the given date. Maintainers with fewer than
+// minContributions in total become emeritus candidates; maintainers whose
+// lookups failed are reported with Error set and are not judged.
+func (g GitHubContributions) Report(handles, repos []string, since time.Time, minContributions int) ContributionReport {
+	report := ContributionReport{Since: since, MinContributions: minContributions, Maintainers: make([]ContributionActivity, len(handles))}
+	var refs [][2]string
+	for _, r := range repos {
+		if org, repo, err := ParseGitHubURL(r); err == nil && repo != "" {
+			refs = append(refs, [2]string{org, repo})
+			report.Repositories = append(report.Repositories, org+"/"+repo)
+		}
+	}
+
+	workers := g.Concurrency
+	if workers <= 0 {
+		workers = DefaultFetchConcurrency
+	}
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, handle := range handles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			a, err := g.contributions(handle, refs, since)
+			if err != nil {
+				a = ContributionActivity{Handle: handle, Error: err.Error()}
+			}
+			report.Maintainers[i] = a
+		}()
+	}
+	wg.Wait()
+
+	for _, a := range report.Maintainers {
+		if a.Error == "" && a.Total() < minContributions {
+			report.EmeritusCandidates = append(report.EmeritusCandidates, a.Handle)
+		}
+	}
+	sort.Strings(report.EmeritusCandidates)
+	return report
+}
+
+// contributions looks up one maintainer: the user ID and search counts in
+// one query, then commit counts in batches of repositories.
+func (g GitHubContributions) contributions(handle string, repos [][2]string, since time.Time) (ContributionActivity, error) {
+	a := ContributionActivity{Handle: handle}
+	if len(repos) == 0 {
+		return a, nil
+	}
+	day := since.UTC().Format(time.DateOnly)
+	reviewed := searchQueries("is:pr reviewed-by:"+handle+" -author:"+handle+" updated:>="+day, repos)
+	commented := searchQueries("commenter:"+handle+" updated:>="+day, repos)
+
+	var q strings.Builder
+	q.WriteString("query($login: String!) {\n  user(login: $login) { id }\n")
+	for i, s := range reviewed {
+		fmt.Fprintf(&q, "  r%d: search(query: %s, type: ISSUE, first: 0) { issueCount }\n", i, strconv.Quote(s))
+	}
+	for i, s := range commented {
+		fmt.Fprintf(&q, "  c%d: search(query: %s, type: ISSUE, first: 0) { issueCount }\n", i, strconv.Quote(s))
+	}
+	q.WriteString("}")
+
+	var data map[string]json.RawMessage
+	err := g.query(q.String(), map[string]any{"login": handle}, &data)
+	if data == nil {
+		return a, err
+	}
+	var user *struct {
+		ID string `json:"id"`
+	}
+	if json.Unmarshal(data["user"], &user) != nil || user == nil || user.ID == "" {
+		return a, fmt.Errorf("GitHub user %s not found", handle)
+	}
+	if err != nil {
+		return a, err
+	}
+	for key, raw := range data {
+		var s struct {
+			IssueCount int `json:"issueCount"`
+		}
+		if key == "user" || json.Unmarshal(raw, &s) != nil {
+			continue
+		}
+		if strings.HasPrefix(key, "r") {
+			a.Reviews += s.IssueCount
+		} else {
+			a.Comments += s.IssueCount
+		}
+	}
+
+	for start := 0; start < len(repos); start += commitHistoryBatch {
+		batch := repos[start:min(start+commitHistoryBatch, len(repos))]
+		q.Reset()
+		q.WriteString("query($author: ID!, $since: GitTimestamp!) {\n")
+		for i, r := range batch {
+			fmt.Fprintf(&q, "  r%d: repository(owner: %s, name: %s) { defaultBranchRef { target { ... on Commit { history(since: $since, author: {id: $author}) { totalCount } } } } }\n",
+				i, strconv.Quote(r[0]), strconv.Quote(r[1]))
+		}
+		q.WriteString("}")
+
+		var repoData map[string]*struct {
+			DefaultBranchRef *struct {
+				Target struct {
+					History struct {
+						TotalCount int `json:"totalCount"`
+					} `json:"history"`
+				} `json:"target"`
+			} `json:"defaultBranchRef"`
+		}
+		vars := map[string]any{"author": user.ID, "since": since.UTC().Format(time.RFC3339)}
+		if err := g.query(q.String(), vars, &repoData); err != nil {
+			return a, err
+		}
+		for _, r := range repoData {
+			if r != nil && r.DefaultBranchRef != nil {
+				a.Commits += r.DefaultBranchRef.Target.History.TotalCount
+			}
+		}
+	}
+	return a, nil
+}
+
+// searchQueries splits repos across as few search queries as fit in the
+// search length limit, each being base plus repo: qualifiers. Repository
+// qualifiers in one query are ORed.
+func searchQueries(base string, repos [][2]string) []string {
+	var queries []string
+	current := base
+	n := 0
+	for _, r := range repos {
+		qualifier := " repo:" + r[0] + "/" + r[1]
+		if n > 0 && len(current)+len(qualifier) > searchQueryLimit {
+			queries = append(queries, current)
+			current, n = base, 0
+		}
+		current += qualifier
+		n++
+	}
+	return append(queries, current)
+}
+
+// query posts a GraphQL query and decodes its data into out. Errors
+// reported alongside data still fail the query, after out is filled in:
+// counts missing an inaccessible repository or search would understate the
+// maintainer's activity.
+func (g GitHubContributions) query(query string, variables map[string]any, out any) error {
+	if g.Token == "" {
+		return errors.New("the GitHub GraphQL API requires a token (set GITHUB_TOKEN)")
+	}
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return fmt.Errorf("marshaling request: %w", err)
+	}
+	apiURL := g.GraphQLURL
+	if apiURL == "" {
+		apiURL = DefaultGitHubGraphQLURL
+	}
+	req, err := http.NewRequest("POST", apiURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+g.Token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+
+	client := g.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("GraphQL request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("reading response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GraphQL API returned HTTP %d%s", resp.StatusCode, rateLimitHint(resp))
+	}
+
+	var gqlResp struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+		return fmt.Errorf("parsing response: %w", err)
+	}
+	if len(gqlResp.Data) > 0 && string(gqlResp.Data) != "null" {
+		if err := json.Unmarshal(gqlResp.Data, out); err != nil {
+			return fmt.Errorf("parsing response data: %w", err)
+		}
+		if len(gqlResp.Errors) == 0 {
+			return nil
+		}
+	}
+	msgs := make([]string, len(gqlResp.Errors))
+	for i, e := range gqlResp.Errors {
+		msgs[i] = e.Message
+	}
+	return fmt.Errorf("GraphQL errors: %s", strings.Join(msgs, "; "))
+}
+
+// FormatContributionReport renders a report as a markdown activity table
+// followed by the emeritus candidates.
+func FormatContributionReport(report ContributionReport) string {
+	var b strings.Builder
+	b.WriteString("# Maintainer Contribution Activity\n\n")
+	b.WriteString(fmt.Sprintf("Contributions since %s across %d repositories: %s.\n\n",
+		report.Since.Format(time.DateOnly), len(report.Repositories), strings.Join(report.Repositories, ", ")))
+	b.WriteString(fmt.Sprintf("Reviews and comments count pull requests and issues updated since %s that the maintainer reviewed or commented on, "+
+		"possibly earlier; GitHub search cannot date the review or comment itself.\n\n", report.Since.Format(time.DateOnly)))
+	b.WriteString("| Maintainer | Commits | Reviews | Comments | Total |\n")
+	b.WriteString("|------------|---------|---------|----------|-------|\n")
+
+	rows := append([]ContributionActivity(nil), report.Maintainers...)
+	sort.SliceStable(rows, func(i, j int) bool {
+		if (rows[i].Error == "") != (rows[j].Error == "") {
+			return rows[i].Error == ""
+		}
+		return rows[i].Total() > rows[j].Total()
+	})
+	for _, a := range rows {
+		if a.Error != "" {
+			b.WriteString(fmt.Sprintf("| @%s | | | | could not check: %s |\n", a.Handle, markdownCell(a.Error)))
+			continue
+		}
+		b.WriteString(fmt.Sprintf("| @%s | %d | %d | %d | %d |\n", a.Handle, a.Commits, a.Reviews, a.Comments, a.Total()))
+	}
+
+	b.WriteString(fmt.Sprintf("\n## Emeritus candidates\n\nMaintainers with fewer than %d contributions since %s:\n\n",
+		report.MinContributions, report.Since.Format(time.DateOnly)))
+	if len(report.EmeritusCandidates) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, h := range report.EmeritusCandidates {
+		b.WriteString(fmt.Sprintf("- @%s\n", h))
+	}
+	return b.String()
+}
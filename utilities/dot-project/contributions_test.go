@@ -0,0 +1,130 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newContributionsServer serves the GraphQL queries of GitHubContributions
+// from per-handle counts. Unknown handles resolve to a null user, and a
+// handle whose counts carry an Error gets it reported alongside the commit
+// counts, as for an inaccessible repository.
+func newContributionsServer(t *testing.T, counts map[string]ContributionActivity) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req graphQLRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		data := make(map[string]any)
+		if login, ok := req.Variables["login"].(string); ok {
+			c, found := counts[login]
+			if !found {
+				data["user"] = nil
+				json.NewEncoder(w).Encode(map[string]any{"data": data, "errors": []map[string]string{{"type": "NOT_FOUND", "message": "Could not resolve to a User"}}})
+				return
+			}
+			data["user"] = map[string]string{"id": "ID_" + login}
+			for i := 0; strings.Contains(req.Query, fmt.Sprintf("r%d: search", i)); i++ {
+				data[fmt.Sprintf("r%d", i)] = map[string]int{"issueCount": c.Reviews}
+			}
+			for i := 0; strings.Contains(req.Query, fmt.Sprintf("c%d: search", i)); i++ {
+				data[fmt.Sprintf("c%d", i)] = map[string]int{"issueCount": c.Comments}
+			}
+		} else {
+			login := strings.TrimPrefix(req.Variables["author"].(string), "ID_")
+			if req.Variables["since"] != "2024-01-01T00:00:00Z" {
+				t.Errorf("since = %v", req.Variables["since"])
+			}
+			for i := 0; strings.Contains(req.Query, fmt.Sprintf("r%d: repository", i)); i++ {
+				data[fmt.Sprintf("r%d", i)] = map[string]any{"defaultBranchRef": map[string]any{"target": map[string]any{"history": map[string]int{"totalCount": counts[login].Commits}}}}
+			}
+			if msg := counts[login].Error; msg != "" {
+				data["r1"] = nil
+				json.NewEncoder(w).Encode(map[string]any{"data": data, "errors": []map[string]string{{"type": "FORBIDDEN", "message": msg}}})
+				return
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]any{"data": data})
+	}))
+}
+
+func TestGitHubContributionsReport(t *testing.T) {
+	server := newContributionsServer(t, map[string]ContributionActivity{
+		"alice": {Commits: 3, Reviews: 2, Comments: 1},
+		"bob":   {},
+		"carol": {Error: "Resource not accessible by integration"},
+	})
+	defer server.Close()
+
+	source := GitHubContributions{Token: "test-token", GraphQLURL: server.URL}
+	repos := []string{"https://github.com/test/one", "https://github.com/test/two", "https://gitlab.com/test/three", "https://github.com/test"}
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := source.Report([]string{"alice", "bob", "ghost", "carol"}, repos, since, 1)
+
+	if got := strings.Join(report.Repositories, ","); got != "test/one,test/two" {
+		t.Errorf("Repositories = %s, want only the GitHub repositories", got)
+	}
+	alice := report.Maintainers[0]
+	// Two repositories fit in one search query, and each repository adds its commits.
+	if alice.Commits != 6 || alice.Reviews != 2 || alice.Comments != 1 || alice.Error != "" {
+		t.Errorf("alice = %+v", alice)
+	}
+	if report.Maintainers[1].Total() != 0 || report.Maintainers[1].Error != "" {
+		t.Errorf("bob = %+v", report.Maintainers[1])
+	}
+	if !strings.Contains(report.Maintainers[2].Error, "not found") {
+		t.Errorf("ghost error = %q, want not found", report.Maintainers[2].Error)
+	}
+	// Errors returned alongside data leave carol's counts incomplete, so
+	// carol is not judged.
+	if !strings.Contains(report.Maintainers[3].Error, "not accessible") {
+		t.Errorf("carol error = %q, want the GraphQL error", report.Maintainers[3].Error)
+	}
+	if got := strings.Join(report.EmeritusCandidates, ","); got != "bob" {
+		t.Errorf("EmeritusCandidates = %s, want bob", got)
+	}
+
+	out := FormatContributionReport(report)
+	for _, want := range []string{"| @alice | 6 | 2 | 1 | 9 |", "| @bob | 0 | 0 | 0 | 0 |", "@ghost | | | | could not check", "## Emeritus candidates", "- @bob"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGitHubContributionsRequiresToken(t *testing.T) {
+	report := GitHubContributions{}.Report([]string{"alice"}, []string{"https://github.com/test/one"}, time.Now(), 1)
+	if !strings.Contains(report.Maintainers[0].Error, "token") || len(report.EmeritusCandidates) != 0 {
+		t.Errorf("report = %+v, want a token error and no candidates", report)
+	}
+}
+
+func TestSearchQueriesSplitsLongRepositoryLists(t *testing.T) {
+	var repos [][2]string
+	for i := 0; i < 20; i++ {
+		repos = append(repos, [2]string{"kubernetes-sigs", fmt.Sprintf("repository-%02d", i)})
+	}
+	queries := searchQueries("commenter:someone updated:>=2024-01-01", repos)
+	if len(queries) < 2 {
+		t.Fatalf("got %d queries, want the repositories split across several", len(queries))
+	}
+	n := 0
+	for _, q := range queries {
+		if len(q) > searchQueryLimit {
+			t.Errorf("query is %d characters long: %s", len(q), q)
+		}
+		n += strings.Count(q, " repo:")
+	}
+	if n != len(repos) {
+		t.Errorf("queries cover %d repositories, want %d", n, len(repos))
+	}
+}
@@ -9,10 +9,51 @@ const (
 	// clients (validator, bootstrap sources, etc.).
 	DefaultHTTPTimeout = 30 * time.Second
 
+	// DefaultFetchConcurrency is how many project files ValidateProjects
+	// fetches and validates in parallel.
+	DefaultFetchConcurrency = 8
+
+	// DefaultHostRequestsPerSecond caps the request rate to any single host
+	// while fetching project files.
+	DefaultHostRequestsPerSecond = 10
+
+	// DefaultFetchMaxRetries is how many times a project fetch is retried
+	// after a 429, a 5xx or a network error.
+	DefaultFetchMaxRetries = 3
+
+	// DefaultFetchRetryBackoff is the delay before the first retry; it
+	// doubles on each further attempt, up to DefaultFetchMaxBackoff. A
+	// longer Retry-After from the server takes precedence.
+	DefaultFetchRetryBackoff = 500 * time.Millisecond
+
+	// DefaultFetchMaxBackoff bounds the delay between fetch retries.
+	DefaultFetchMaxBackoff = 30 * time.Second
+
+	// DefaultCacheLockTimeout is how long a validator waits for another run
+	// to release the cache lock.
+	DefaultCacheLockTimeout = 30 * time.Second
+
+	// DefaultCacheLockStaleAfter is the age after which a cache lock file is
+	// assumed to be left over from a crashed run and removed.
+	DefaultCacheLockStaleAfter = 10 * time.Minute
+
+	// DefaultCacheSaveAttempts bounds the merge-and-retry loop when saving
+	// to an object store that another run wrote concurrently.
+	DefaultCacheSaveAttempts = 5
+
+	// DefaultVerifyConcurrency is how many maintainer handles are verified
+	// in parallel.
+	DefaultVerifyConcurrency = 4
+
 	// DefaultStalenessThresholdDays is the number of days after which a
 	// project's maintainer data is considered stale.
 	DefaultStalenessThresholdDays = 180
 
+	// DefaultAuditMaxAgeYears is the age in years after which the last
+	// security audit of an incubating or graduated project is reported as
+	// out of date.
+	DefaultAuditMaxAgeYears = 3
+
 	// DefaultDCOCommitSampleSize is how many recent commits we fetch when
 	// detecting whether a repo uses DCO (Signed-off-by).
 	DefaultDCOCommitSampleSize = 20
@@ -36,6 +77,13 @@ const (
 	// DefaultGitHubGraphQLURL is the GitHub GraphQL API endpoint.
 	DefaultGitHubGraphQLURL = "https://api.github.com/graphql"
 
+	// DefaultGitHubRawURL serves raw file contents from GitHub repositories.
+	DefaultGitHubRawURL = "https://raw.githubusercontent.com"
+
+	// DefaultLFXAPIURL is the LFX platform API gateway used to verify
+	// maintainer handles.
+	DefaultLFXAPIURL = "https://api-gw.platform.linuxfoundation.org"
+
 	// DefaultEnterprise is the GitHub Enterprise slug for CNCF.
 	DefaultEnterprise = "cncf"
 )
@@ -0,0 +1,285 @@
+package projects
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Severity classifies how serious a Diagnostic is. Only SeverityError makes a
+// result invalid; warnings and info are reported but never fail validation.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+// Rule IDs identify the check that produced a Diagnostic. They are stable so
+// bots and suppression lists can key on them instead of on message text.
+const (
+	RuleYAMLParse           = "yaml-parse"
+	RuleFetch               = "fetch"
+	RuleRequired            = "required"
+	RuleNonEmpty            = "non-empty"
+	RuleSlugFormat          = "slug-format"
+	RuleProjectLeadFormat   = "project-lead-format"
+	RuleSlackChannelName    = "slack-channel-name"
+	RuleSlackWorkspace      = "slack-workspace"
+	RuleSlackLink           = "slack-link"
+	RuleSocialPlatform      = "social-platform"
+	RuleURLFormat           = "url-format"
+	RuleSinglePrimary       = "single-primary"
+	RuleDuplicateRepository = "duplicate-repository"
+	RuleDuplicateSubproject = "duplicate-subproject"
+	RuleSchemaVersion       = "schema-version"
+	RuleMaturityPhase       = "maturity-phase"
+	RuleMaturityOrder       = "maturity-order"
+	RuleSecurityContact     = "security-contact"
+	RuleEmailFormat         = "email-format"
+	RuleAdvisoryURL         = "advisory-url"
+	RuleIdentityType        = "identity-type"
+	RuleRequiredTeam        = "required-team"
+	RuleDuplicateHandle     = "duplicate-handle"
+	RuleCompanyDiversity    = "company-diversity"
+	RuleHandleVerification  = "handle-verification"
+	RuleJSONSchema          = "json-schema"
+	RuleSchemaDisagreement  = "schema-disagreement"
+	RuleMaturityPolicy      = "maturity-policy"
+	RuleAuditFreshness      = "audit-freshness"
+	RuleThreatModel         = "threat-model"
+	RuleFutureDate          = "future-date"
+	RulePackageRegistry     = "package-registry"
+	RulePackageIdentifier   = "package-identifier"
+	RulePackageNotFound     = "package-not-found"
+	RuleBundleMissingFile   = "bundle-missing-file"
+	RuleBundleProjectID     = "bundle-project-id"
+	RuleBundleOrg           = "bundle-org"
+	RuleBundleProjectLead   = "bundle-project-lead"
+)
+
+// Diagnostic is a single structured validation finding. Path is the
+// field path inside the validated document (e.g. "maturity_log[1].date");
+// Line and Column are 1-based positions in the source YAML and are zero when
+// the position is unknown (e.g. when validating an in-memory Project).
+type Diagnostic struct {
+	Path     string   `json:"path,omitempty" yaml:"path,omitempty"`
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Message  string   `json:"message" yaml:"message"`
+	Line     int      `json:"line,omitempty" yaml:"line,omitempty"`
+	Column   int      `json:"column,omitempty" yaml:"column,omitempty"`
+	Fix      string   `json:"fix,omitempty" yaml:"fix,omitempty"` // Suggested fix, if one is known
+}
+
+// String returns the diagnostic message, matching the legacy []string errors.
+func (d Diagnostic) String() string {
+	return d.Message
+}
+
+// withFix returns a copy of d with the suggested fix set.
+func (d Diagnostic) withFix(format string, args ...interface{}) Diagnostic {
+	d.Fix = fmt.Sprintf(format, args...)
+	return d
+}
+
+// errorDiag builds an error-severity diagnostic.
+func errorDiag(path, rule, format string, args ...interface{}) Diagnostic {
+	return Diagnostic{Path: path, Rule: rule, Severity: SeverityError, Message: fmt.Sprintf(format, args...)}
+}
+
+// diagnosticMessages returns the messages of all diagnostics with the given severity.
+func diagnosticMessages(diags []Diagnostic, severity Severity) []string {
+	var msgs []string
+	for _, d := range diags {
+		if d.Severity == severity {
+			msgs = append(msgs, d.Message)
+		}
+	}
+	return msgs
+}
+
+// hasErrorDiagnostics reports whether any diagnostic has error severity.
+func hasErrorDiagnostics(diags []Diagnostic) bool {
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			return true
+		}
+	}
+	return false
+}
+
+// prefixDiagnostics prepends prefix to the path of every diagnostic, e.g. to
+// turn an entry-relative "teams[0]" into "maintainers[2].teams[0]".
+func prefixDiagnostics(diags []Diagnostic, prefix string) []Diagnostic {
+	out := make([]Diagnostic, len(diags))
+	for i, d := range diags {
+		switch {
+		case d.Path == "":
+			d.Path = prefix
+		case strings.HasPrefix(d.Path, "["):
+			d.Path = prefix + d.Path
+		default:
+			d.Path = prefix + "." + d.Path
+		}
+		out[i] = d
+	}
+	return out
+}
+
+// LocateDiagnostics fills in Line and Column for each diagnostic by resolving
+// its Path against the parsed YAML document. When the exact node does not
+// exist (typically a missing required field) the nearest existing ancestor is
+// used, so every diagnostic points somewhere useful.
+func LocateDiagnostics(diags []Diagnostic, root *yaml.Node) {
+	if root == nil {
+		return
+	}
+	for i := range diags {
+		if diags[i].Line != 0 {
+			continue
+		}
+		if n := findNode(root, diags[i].Path); n != nil {
+			diags[i].Line = n.Line
+			diags[i].Column = n.Column
+		}
+	}
+}
+
+// pathSegment is one step of a diagnostic path: a mapping key or a sequence index.
+type pathSegment struct {
+	key   string
+	index int // -1 for key segments
+}
+
+// parsePath splits "a.b[1].c" into its key and index segments.
+func parsePath(path string) []pathSegment {
+	var segs []pathSegment
+	for _, part := range strings.Split(path, ".") {
+		for part != "" {
+			open := strings.IndexByte(part, '[')
+			if open < 0 {
+				segs = append(segs, pathSegment{key: part, index: -1})
+				break
+			}
+			if open > 0 {
+				segs = append(segs, pathSegment{key: part[:open], index: -1})
+			}
+			end := strings.IndexByte(part[open:], ']')
+			if end < 0 {
+				break
+			}
+			if idx, err := strconv.Atoi(part[open+1 : open+end]); err == nil {
+				segs = append(segs, pathSegment{index: idx})
+			}
+			part = part[open+end+1:]
+		}
+	}
+	return segs
+}
+
+// findNode walks the YAML tree along path and returns the deepest node it
+// could reach.
+func findNode(root *yaml.Node, path string) *yaml.Node {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	for _, seg := range parsePath(path) {
+		next := childNode(node, seg)
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return node
+}
+
+// childNode returns the child of node addressed by seg, or nil.
+func childNode(node *yaml.Node, seg pathSegment) *yaml.Node {
+	if node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+	switch {
+	case seg.index >= 0 && node.Kind == yaml.SequenceNode:
+		if seg.index < len(node.Content) {
+			return node.Content[seg.index]
+		}
+	case seg.index < 0 && node.Kind == yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == seg.key {
+				return node.Content[i+1]
+			}
+		}
+	}
+	return nil
+}
+
+// yamlErrorLinePattern extracts the line number yaml.v3 embeds in its errors.
+var yamlErrorLinePattern = regexp.MustCompile(`line (\d+): (.*)`)
+
+// yamlErrorDiagnostics converts a YAML decode error into diagnostics, one per
+// underlying error, each carrying the line yaml.v3 reported.
+func yamlErrorDiagnostics(err error) []Diagnostic {
+	var lines []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		lines = typeErr.Errors
+	} else {
+		lines = []string{err.Error()}
+	}
+
+	diags := make([]Diagnostic, 0, len(lines))
+	for _, l := range lines {
+		d := errorDiag("", RuleYAMLParse, "YAML parsing error: %s", strings.TrimPrefix(l, "yaml: "))
+		if m := yamlErrorLinePattern.FindStringSubmatch(l); m != nil {
+			d.Line, _ = strconv.Atoi(m[1])
+			if strings.Contains(m[2], "not found in type") {
+				d = d.withFix("remove the field or check its spelling against the schema")
+			}
+		}
+		diags = append(diags, d)
+	}
+	return diags
+}
+
+// reportDiagnostics returns the diagnostics to render for a result. Results
+// built without diagnostics (e.g. by older callers) fall back to their plain
+// error strings so nothing is dropped from the report.
+func reportDiagnostics(errs []string, diags []Diagnostic) []Diagnostic {
+	if len(diags) > 0 {
+		return diags
+	}
+	out := make([]Diagnostic, 0, len(errs))
+	for _, e := range errs {
+		out = append(out, Diagnostic{Severity: SeverityError, Message: e})
+	}
+	return out
+}
+
+// formatDiagnosticLine renders a diagnostic for the human-readable reports.
+func formatDiagnosticLine(d Diagnostic) string {
+	var b strings.Builder
+	b.WriteString("  - ")
+	if d.Severity != "" && d.Severity != SeverityError {
+		b.WriteString(string(d.Severity) + ": ")
+	}
+	b.WriteString(d.Message)
+	if d.Line > 0 {
+		b.WriteString(fmt.Sprintf(" (line %d", d.Line))
+		if d.Column > 0 {
+			b.WriteString(fmt.Sprintf(", column %d", d.Column))
+		}
+		b.WriteString(")")
+	}
+	b.WriteString("\n")
+	if d.Fix != "" {
+		b.WriteString(fmt.Sprintf("    fix: %s\n", d.Fix))
+	}
+	return b.String()
+}
@@ -0,0 +1,203 @@
+package projects
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func findDiagnostic(diags []Diagnostic, path string) (Diagnostic, bool) {
+	for _, d := range diags {
+		if d.Path == path {
+			return d, true
+		}
+	}
+	return Diagnostic{}, false
+}
+
+func TestValidateProjectContentPositions(t *testing.T) {
+	content := `schema_version: "1.0.0"
+slug: Test_Project
+name: Test Project
+description: A test project
+maturity_log:
+  - phase: sandbox
+    date: 2024-01-15
+    issue: https://github.com/cncf/toc/issues/1
+  - phase: incubating
+    date: 2023-01-15
+    issue: https://github.com/cncf/toc/issues/2
+repositories:
+  - https://github.com/test/repo
+slack_channels:
+  - name: test-project
+`
+	project, diags, parsed := validateProjectContent(content)
+	if !parsed {
+		t.Fatalf("expected content to parse, got %v", diags)
+	}
+	if project.Name != "Test Project" {
+		t.Errorf("Name = %q", project.Name)
+	}
+
+	tests := []struct {
+		path string
+		rule string
+		line int
+		col  int
+		fix  string
+	}{
+		{"slug", RuleSlugFormat, 2, 7, `use slug "test-project"`},
+		{"maturity_log[1].date", RuleMaturityOrder, 10, 11, "sort maturity_log entries by date, oldest first"},
+		{"slack_channels[0].name", RuleSlackChannelName, 15, 11, `use "#test-project"`},
+	}
+	for _, tt := range tests {
+		d, ok := findDiagnostic(diags, tt.path)
+		if !ok {
+			t.Errorf("no diagnostic for %s in %v", tt.path, diags)
+			continue
+		}
+		if d.Rule != tt.rule || d.Severity != SeverityError {
+			t.Errorf("%s: rule/severity = %s/%s, want %s/error", tt.path, d.Rule, d.Severity, tt.rule)
+		}
+		if d.Line != tt.line || d.Column != tt.col {
+			t.Errorf("%s: position = %d:%d, want %d:%d", tt.path, d.Line, d.Column, tt.line, tt.col)
+		}
+		if d.Fix != tt.fix {
+			t.Errorf("%s: fix = %q, want %q", tt.path, d.Fix, tt.fix)
+		}
+	}
+}
+
+func TestLocateDiagnosticsFallsBackToAncestor(t *testing.T) {
+	content := `schema_version: "1.0.0"
+slug: test-project
+name: Test Project
+description: A test project
+maturity_log:
+  - phase: sandbox
+    issue: https://github.com/cncf/toc/issues/1
+repositories:
+  - https://github.com/test/repo
+`
+	_, diags, _ := validateProjectContent(content)
+	d, ok := findDiagnostic(diags, "maturity_log[0].date")
+	if !ok {
+		t.Fatalf("expected missing date diagnostic, got %v", diags)
+	}
+	// The date key is absent, so the entry itself (line 6) is reported.
+	if d.Line != 6 {
+		t.Errorf("line = %d, want 6", d.Line)
+	}
+}
+
+func TestYAMLErrorDiagnostics(t *testing.T) {
+	content := `schema_version: "1.0.0"
+slug: test-project
+unknown_field: true
+`
+	_, diags, parsed := validateProjectContent(content)
+	if parsed {
+		t.Fatal("expected strict decoding to fail on unknown field")
+	}
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	d := diags[0]
+	if d.Rule != RuleYAMLParse || d.Line != 3 {
+		t.Errorf("got rule %s line %d, want %s line 3", d.Rule, d.Line, RuleYAMLParse)
+	}
+	if !strings.HasPrefix(d.Message, "YAML parsing error") {
+		t.Errorf("message = %q", d.Message)
+	}
+	if d.Fix == "" {
+		t.Error("expected a suggested fix for unknown field")
+	}
+}
+
+func TestParsePath(t *testing.T) {
+	segs := parsePath("maintainers[2].teams[0].members[3]")
+	want := []pathSegment{
+		{key: "maintainers", index: -1}, {index: 2},
+		{key: "teams", index: -1}, {index: 0},
+		{key: "members", index: -1}, {index: 3},
+	}
+	if len(segs) != len(want) {
+		t.Fatalf("got %v, want %v", segs, want)
+	}
+	for i := range want {
+		if segs[i] != want[i] {
+			t.Errorf("segment %d = %+v, want %+v", i, segs[i], want[i])
+		}
+	}
+}
+
+func TestMaintainerDiagnosticsPositions(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "maintainers.yaml")
+	writeFile(t, path, `maintainers:
+  - project_id: ok
+    teams:
+      - name: project-maintainers
+        members:
+          - alice
+  - project_id: dup
+    teams:
+      - name: project-maintainers
+        members:
+          - bob
+          - Bob
+`)
+	pv := newTestValidator(t)
+	results, err := pv.ValidateMaintainersFile(path, false)
+	if err != nil {
+		t.Fatalf("ValidateMaintainersFile: %v", err)
+	}
+	if len(results) != 2 || results[1].Valid {
+		t.Fatalf("expected second entry to be invalid, got %+v", results)
+	}
+	d, ok := findDiagnostic(results[1].Diagnostics, "maintainers[1].teams[0].members[1]")
+	if !ok {
+		t.Fatalf("expected duplicate handle diagnostic, got %v", results[1].Diagnostics)
+	}
+	if d.Rule != RuleDuplicateHandle || d.Line != 12 {
+		t.Errorf("got rule %s line %d, want %s line 12", d.Rule, d.Line, RuleDuplicateHandle)
+	}
+	if len(results[1].Errors) != 1 || results[1].Errors[0] != d.Message {
+		t.Errorf("Errors should mirror error diagnostics, got %v", results[1].Errors)
+	}
+}
+
+func TestDiagnosticsInOutputFormats(t *testing.T) {
+	pv := newTestValidator(t)
+	results := []ValidationResult{{
+		URL:         "file:///p.yaml",
+		ProjectName: "p",
+		Errors:      []string{"name is required"},
+		Diagnostics: []Diagnostic{{
+			Path: "name", Rule: RuleRequired, Severity: SeverityError,
+			Message: "name is required", Line: 3, Column: 1, Fix: "add a name",
+		}},
+	}}
+
+	text, err := pv.FormatResults(results, "text")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(text, "- name is required (line 3, column 1)") || !strings.Contains(text, "fix: add a name") {
+		t.Errorf("text output missing position or fix:\n%s", text)
+	}
+
+	out, err := pv.FormatResults(results, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parsed []ValidationResult
+	if err := json.Unmarshal([]byte(out), &parsed); err != nil {
+		t.Fatal(err)
+	}
+	if got := parsed[0].Diagnostics; len(got) != 1 || got[0].Line != 3 || got[0].Rule != RuleRequired {
+		t.Errorf("json diagnostics = %+v", got)
+	}
+}
@@ -0,0 +1,383 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EnterpriseListPrefix marks a project list location that is discovered from
+// a GitHub enterprise instead of read from a file: enterprise://cncf.
+const EnterpriseListPrefix = "enterprise://"
+
+// ProjectListSource supplies the projects to validate. The default source is
+// the projectlist.yaml file named by Config.ProjectListURL.
+type ProjectListSource interface {
+	ProjectList() ([]ProjectListEntry, error)
+}
+
+// SetProjectListSource replaces the project list file with src. nil restores
+// the default of reading Config.ProjectListURL.
+func (pv *ProjectValidator) SetProjectListSource(src ProjectListSource) {
+	pv.source = src
+}
+
+// DotProjectRepo describes an org's .project repository.
+type DotProjectRepo struct {
+	Org           string
+	CreatedAt     time.Time
+	DefaultBranch string
+	Archived      bool
+}
+
+// EnterpriseProjectSource lists every organization in a GitHub enterprise
+// and keeps those that have a .project repository, so the validator covers
+// every onboarded org without a hand-maintained list.
+//
+// Include and Exclude are case-insensitive glob patterns (path.Match) on the
+// org login. An empty Include keeps all orgs; Exclude wins over Include.
+// Archived .project repositories are skipped.
+type EnterpriseProjectSource struct {
+	Enterprise  string
+	Token       string
+	Include     []string
+	Exclude     []string
+	Client      *http.Client
+	GraphQLURL  string // "" means DefaultGitHubGraphQLURL
+	APIURL      string // "" means DefaultGitHubAPIURL
+	RawURL      string // "" means DefaultGitHubRawURL
+	Concurrency int    // Parallel .project lookups; 0 means DefaultFetchConcurrency
+}
+
+// ProjectList returns one entry per onboarded org, sorted by org, pointing at
+// project.yaml on the default branch of its .project repository. Orgs whose
+// .project repository cannot be checked are skipped with a warning, as in
+// the onboarding report.
+func (s *EnterpriseProjectSource) ProjectList() ([]ProjectListEntry, error) {
+	if s.Token == "" {
+		return nil, fmt.Errorf("listing enterprise %q requires a GitHub token with read:enterprise scope (set GITHUB_TOKEN)", s.Enterprise)
+	}
+	for _, p := range append(append([]string{}, s.Include...), s.Exclude...) {
+		if _, err := path.Match(p, ""); err != nil {
+			return nil, fmt.Errorf("invalid org filter %q: %w", p, err)
+		}
+	}
+
+	client := s.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	graphQLURL := s.GraphQLURL
+	if graphQLURL == "" {
+		graphQLURL = DefaultGitHubGraphQLURL
+	}
+	rawURL := s.RawURL
+	if rawURL == "" {
+		rawURL = DefaultGitHubRawURL
+	}
+
+	orgs, err := ListEnterpriseOrgs(client, graphQLURL, s.Token, s.Enterprise)
+	if err != nil {
+		return nil, fmt.Errorf("listing enterprise %q: %w", s.Enterprise, err)
+	}
+	orgs = FilterOrgs(orgs, s.Include, s.Exclude)
+
+	repos := FindDotProjectRepos(client, s.APIURL, s.Token, orgs, s.Concurrency)
+	entries := make([]ProjectListEntry, 0, len(repos))
+	for _, repo := range repos {
+		if repo.Archived {
+			continue
+		}
+		entries = append(entries, ProjectListEntry{
+			URL: fmt.Sprintf("%s/%s/.project/%s/project.yaml", strings.TrimRight(rawURL, "/"), repo.Org, repo.DefaultBranch),
+			ID:  repo.Org,
+		})
+	}
+	return entries, nil
+}
+
+// newEnterpriseSource builds the source for an enterprise://SLUG project
+// list location, taking the token from GITHUB_TOKEN and the org filters from
+// the config.
+func newEnterpriseSource(location string, config *Config) *EnterpriseProjectSource {
+	src := &EnterpriseProjectSource{
+		Enterprise: strings.TrimPrefix(location, EnterpriseListPrefix),
+		Token:      os.Getenv("GITHUB_TOKEN"),
+	}
+	if src.Enterprise == "" {
+		src.Enterprise = DefaultEnterprise
+	}
+	if config != nil {
+		src.Include = config.IncludeOrgs
+		src.Exclude = config.ExcludeOrgs
+		src.Concurrency = config.Concurrency
+	}
+	return src
+}
+
+// FilterOrgs returns the orgs matching any include pattern (all orgs when
+// include is empty) and no exclude pattern. Matching is case-insensitive;
+// malformed patterns match nothing.
+func FilterOrgs(orgs, include, exclude []string) []string {
+	matchAny := func(patterns []string, org string) bool {
+		for _, p := range patterns {
+			if ok, _ := path.Match(strings.ToLower(p), strings.ToLower(org)); ok {
+				return true
+			}
+		}
+		return false
+	}
+	var kept []string
+	for _, org := range orgs {
+		if len(include) > 0 && !matchAny(include, org) {
+			continue
+		}
+		if matchAny(exclude, org) {
+			continue
+		}
+		kept = append(kept, org)
+	}
+	return kept
+}
+
+// FindDotProjectRepos looks up the .project repository of each org with up
+// to concurrency requests in flight, returning the orgs that have one sorted
+// by org. Lookup failures are logged and the org skipped.
+func FindDotProjectRepos(client *http.Client, baseURL, token string, orgs []string, concurrency int) []DotProjectRepo {
+	if concurrency <= 0 {
+		concurrency = DefaultFetchConcurrency
+	}
+
+	var (
+		repos []DotProjectRepo
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+	)
+	sem := make(chan struct{}, concurrency)
+	for _, org := range orgs {
+		wg.Add(1)
+		go func(org string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			repo, found, err := GetDotProjectRepo(client, baseURL, token, org)
+			if err != nil {
+				log.Printf("Warning: error checking %s/.project: %v", org, err)
+				return
+			}
+			if found {
+				mu.Lock()
+				repos = append(repos, repo)
+				mu.Unlock()
+			}
+		}(org)
+	}
+	wg.Wait()
+
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Org < repos[j].Org })
+	return repos
+}
+
+// GetDotProjectRepo reports whether org has a .project repository. A 404 (or
+// a redirect to a renamed repository) means not found; rate limiting and
+// other statuses are errors.
+func GetDotProjectRepo(client *http.Client, baseURL, token, org string) (DotProjectRepo, bool, error) {
+	repo := DotProjectRepo{Org: org}
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/.project", strings.TrimRight(baseURL, "/"), org), nil)
+	if err != nil {
+		return repo, false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return repo, false, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var data struct {
+			CreatedAt     time.Time `json:"created_at"`
+			DefaultBranch string    `json:"default_branch"`
+			Archived      bool      `json:"archived"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+			return repo, true, fmt.Errorf("decoding response: %w", err)
+		}
+		repo.CreatedAt = data.CreatedAt
+		repo.DefaultBranch = data.DefaultBranch
+		repo.Archived = data.Archived
+		if repo.DefaultBranch == "" {
+			repo.DefaultBranch = "main"
+		}
+		return repo, true, nil
+	case http.StatusNotFound, http.StatusMovedPermanently:
+		return repo, false, nil
+	case http.StatusForbidden, http.StatusTooManyRequests:
+		return repo, false, fmt.Errorf("rate limited or forbidden (status %d)%s", resp.StatusCode, rateLimitHint(resp))
+	default:
+		return repo, false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+}
+
+// GraphQL types for ListEnterpriseOrgs.
+
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+type enterpriseOrgsResponse struct {
+	Data struct {
+		Enterprise struct {
+			Organizations struct {
+				Nodes []struct {
+					Login string `json:"login"`
+				} `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"organizations"`
+		} `json:"enterprise"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// ListEnterpriseOrgs uses the GitHub GraphQL API to paginate through all
+// organizations in the enterprise. Organizations that block the token (e.g.
+// orgs that forbid classic PATs) are skipped with a warning rather than
+// failing the whole listing.
+func ListEnterpriseOrgs(client *http.Client, apiURL, token, enterprise string) ([]string, error) {
+	var allOrgs []string
+	var cursor *string
+
+	query := `
+query($enterprise: String!, $first: Int!, $after: String) {
+  enterprise(slug: $enterprise) {
+    organizations(first: $first, after: $after) {
+      nodes {
+        login
+      }
+      pageInfo {
+        hasNextPage
+        endCursor
+      }
+    }
+  }
+}`
+
+	for {
+		variables := map[string]any{
+			"enterprise": enterprise,
+			"first":      100,
+		}
+		if cursor != nil {
+			variables["after"] = *cursor
+		}
+
+		reqBody := graphQLRequest{
+			Query:     query,
+			Variables: variables,
+		}
+
+		bodyBytes, err := json.Marshal(reqBody)
+		if err != nil {
+			return nil, fmt.Errorf("marshaling request: %w", err)
+		}
+
+		req, err := http.NewRequest("POST", apiURL, bytes.NewReader(bodyBytes))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("User-Agent", bootstrapUserAgent)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request failed: %w", err)
+		}
+
+		respBody, err := io.ReadAll(resp.Body)
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.Printf("Warning: failed to close response body: %v", closeErr)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading response: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("GraphQL API returned %d: %s", resp.StatusCode, string(respBody))
+		}
+
+		var gqlResp enterpriseOrgsResponse
+		if err := json.Unmarshal(respBody, &gqlResp); err != nil {
+			return nil, fmt.Errorf("parsing response: %w", err)
+		}
+
+		if len(gqlResp.Errors) > 0 {
+			// Errors without any data mean the request itself failed (bad
+			// credentials, missing scope). Errors alongside data are per-org
+			// access blocks; keep the orgs that did come back.
+			if gqlResp.Data.Enterprise.Organizations.Nodes == nil {
+				msgs := make([]string, len(gqlResp.Errors))
+				for i, e := range gqlResp.Errors {
+					msgs[i] = e.Message
+				}
+				return nil, fmt.Errorf("GraphQL errors: %s", strings.Join(msgs, "; "))
+			}
+			for _, e := range gqlResp.Errors {
+				log.Printf("Warning: skipping inaccessible org: %s", e.Message)
+			}
+		}
+
+		// If the enterprise field is null/empty, the token likely lacks read:enterprise scope.
+		if gqlResp.Data.Enterprise.Organizations.Nodes == nil && cursor == nil {
+			return nil, fmt.Errorf(
+				"enterprise '%s' returned no organizations — your token likely lacks the 'read:enterprise' scope.\n"+
+					"  Run: gh auth refresh -s read:enterprise",
+				enterprise,
+			)
+		}
+
+		nodes := gqlResp.Data.Enterprise.Organizations.Nodes
+		for _, n := range nodes {
+			// Orgs the token cannot access surface as null nodes.
+			if n.Login == "" {
+				continue
+			}
+			allOrgs = append(allOrgs, n.Login)
+		}
+
+		pi := gqlResp.Data.Enterprise.Organizations.PageInfo
+		if !pi.HasNextPage {
+			break
+		}
+		cursor = &pi.EndCursor
+	}
+
+	return allOrgs, nil
+}
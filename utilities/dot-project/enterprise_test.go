@@ -0,0 +1,148 @@
+package projects
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// newEnterpriseServer serves a one-page enterprise org listing at /graphql,
+// the repos API at /repos/{org}/.project for the orgs in dotProject, and raw
+// project.yaml files at /{org}/.project/{branch}/project.yaml.
+func newEnterpriseServer(t *testing.T, orgs []string, dotProject map[string]string) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/graphql":
+			if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+				t.Errorf("GraphQL Authorization = %q", got)
+			}
+			var nodes []string
+			for _, org := range orgs {
+				nodes = append(nodes, `{"login":"`+org+`"}`)
+			}
+			w.Write([]byte(`{"data":{"enterprise":{"organizations":{"nodes":[` + strings.Join(nodes, ",") +
+				`],"pageInfo":{"hasNextPage":false,"endCursor":""}}}}}`))
+		case strings.HasPrefix(r.URL.Path, "/repos/"):
+			if got := r.Header.Get("Authorization"); got != "Bearer test-token" {
+				t.Errorf("REST Authorization = %q", got)
+			}
+			if got := r.Header.Get("X-GitHub-Api-Version"); got != "2022-11-28" {
+				t.Errorf("X-GitHub-Api-Version = %q", got)
+			}
+			org := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/"), "/.project")
+			body, ok := dotProject[org]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(body))
+		case strings.HasSuffix(r.URL.Path, "/project.yaml"):
+			w.Write([]byte(validProjectYAML()))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func newTestEnterpriseSource(srv *httptest.Server) *EnterpriseProjectSource {
+	return &EnterpriseProjectSource{
+		Enterprise: "cncf",
+		Token:      "test-token",
+		Client:     srv.Client(),
+		GraphQLURL: srv.URL + "/graphql",
+		APIURL:     srv.URL,
+		RawURL:     srv.URL,
+	}
+}
+
+func TestEnterpriseProjectSource(t *testing.T) {
+	srv := newEnterpriseServer(t, []string{"zeta", "alpha", "no-dot-project", "archived", "sandbox-x"}, map[string]string{
+		"zeta":      `{"default_branch":"main"}`,
+		"alpha":     `{"default_branch":"trunk"}`,
+		"archived":  `{"default_branch":"main","archived":true}`,
+		"sandbox-x": `{"default_branch":"main"}`,
+	})
+	defer srv.Close()
+
+	src := newTestEnterpriseSource(srv)
+	src.Exclude = []string{"Sandbox-*"}
+	entries, err := src.ProjectList()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []ProjectListEntry{
+		{URL: srv.URL + "/alpha/.project/trunk/project.yaml", ID: "alpha"},
+		{URL: srv.URL + "/zeta/.project/main/project.yaml", ID: "zeta"},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("entries = %+v\nwant %+v", entries, want)
+	}
+}
+
+func TestEnterpriseProjectSourceErrors(t *testing.T) {
+	if _, err := (&EnterpriseProjectSource{Enterprise: "cncf"}).ProjectList(); err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+		t.Errorf("missing token: err = %v", err)
+	}
+	src := &EnterpriseProjectSource{Enterprise: "cncf", Token: "t", Include: []string{"["}}
+	if _, err := src.ProjectList(); err == nil || !strings.Contains(err.Error(), "invalid org filter") {
+		t.Errorf("bad pattern: err = %v", err)
+	}
+}
+
+func TestFilterOrgs(t *testing.T) {
+	orgs := []string{"kubernetes", "kubeflow", "prometheus", "cncf-sandbox"}
+	tests := []struct {
+		include, exclude []string
+		want             []string
+	}{
+		{nil, nil, orgs},
+		{[]string{"kube*"}, nil, []string{"kubernetes", "kubeflow"}},
+		{[]string{"KUBE*", "prometheus"}, []string{"kubeflow"}, []string{"kubernetes", "prometheus"}},
+		{nil, []string{"cncf-*"}, []string{"kubernetes", "kubeflow", "prometheus"}},
+	}
+	for _, tt := range tests {
+		if got := FilterOrgs(orgs, tt.include, tt.exclude); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FilterOrgs(%v, %v) = %v, want %v", tt.include, tt.exclude, got, tt.want)
+		}
+	}
+}
+
+func TestGetDotProjectRepoRateLimited(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	if _, found, err := GetDotProjectRepo(srv.Client(), srv.URL, "t", "org"); err == nil || found {
+		t.Errorf("found = %v, err = %v; want a rate-limit error", found, err)
+	}
+}
+
+func TestValidateProjectsFromEnterprise(t *testing.T) {
+	srv := newEnterpriseServer(t, []string{"test"}, map[string]string{"test": `{"default_branch":"main"}`})
+	defer srv.Close()
+
+	pv := NewValidator(filepath.Join(t.TempDir(), "cache"))
+	pv.SetProjectListSource(newTestEnterpriseSource(srv))
+	results, err := pv.ValidateProjects()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || !results[0].Valid || results[0].URL != srv.URL+"/test/.project/main/project.yaml" {
+		t.Errorf("results = %+v", results)
+	}
+}
+
+func TestLoadProjectListEnterpriseScheme(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "")
+	pv := NewValidator(t.TempDir())
+	pv.config.ProjectListURL = "enterprise://cncf"
+	if _, err := pv.loadProjectList(); err == nil || !strings.Contains(err.Error(), "GITHUB_TOKEN") {
+		t.Errorf("err = %v, want the enterprise source's missing-token error", err)
+	}
+}
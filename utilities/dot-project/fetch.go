@@ -0,0 +1,202 @@
+package projects
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// fetchResponse is the outcome of a conditional project fetch.
+type fetchResponse struct {
+	Content     string
+	ETag        string
+	NotModified bool // server answered 304; Content is empty
+}
+
+// fetcher downloads project files with per-host rate limiting, retries with
+// exponential backoff on 429/5xx and network errors, and conditional
+// requests. It is safe for concurrent use.
+type fetcher struct {
+	client       *http.Client
+	interval     time.Duration // minimum spacing between requests to one host
+	maxRetries   int
+	retryBackoff time.Duration
+	sleep        func(time.Duration) // replaced in tests
+
+	mu    sync.Mutex
+	hosts map[string]*hostLimiter
+}
+
+// hostLimiter spaces out requests to a single host.
+type hostLimiter struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// newFetcher builds a fetcher from the validator config, falling back to
+// the Default* settings for unset values. A negative MaxRetries disables
+// retries.
+func newFetcher(client *http.Client, config *Config) *fetcher {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	rps := float64(DefaultHostRequestsPerSecond)
+	retries := DefaultFetchMaxRetries
+	if config != nil {
+		if config.HostRequestsPerSecond > 0 {
+			rps = config.HostRequestsPerSecond
+		}
+		if config.MaxRetries > 0 {
+			retries = config.MaxRetries
+		} else if config.MaxRetries < 0 {
+			retries = 0
+		}
+	}
+	return &fetcher{
+		client:       client,
+		interval:     time.Duration(float64(time.Second) / rps),
+		maxRetries:   retries,
+		retryBackoff: DefaultFetchRetryBackoff,
+		sleep:        time.Sleep,
+		hosts:        make(map[string]*hostLimiter),
+	}
+}
+
+// fetch retrieves rawURL, which may also be a file:// URL or a local path.
+// For HTTP URLs a non-empty etag is sent as If-None-Match.
+func (f *fetcher) fetch(rawURL, etag string) (fetchResponse, error) {
+	if strings.HasPrefix(rawURL, "file://") || (!strings.HasPrefix(rawURL, "http://") && !strings.HasPrefix(rawURL, "https://")) {
+		data, err := os.ReadFile(strings.TrimPrefix(rawURL, "file://"))
+		if err != nil {
+			return fetchResponse{}, err
+		}
+		return fetchResponse{Content: string(data)}, nil
+	}
+
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		host = u.Host
+	}
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		f.wait(host)
+		resp, retryAfter, err := f.do(rawURL, etag)
+		if err == nil {
+			return resp, nil
+		}
+		lastErr = err
+		if !isRetryable(err) || attempt >= f.maxRetries {
+			break
+		}
+		delay := f.retryBackoff << attempt
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+		if delay > DefaultFetchMaxBackoff {
+			delay = DefaultFetchMaxBackoff
+		}
+		f.sleep(delay)
+	}
+	return fetchResponse{}, lastErr
+}
+
+// fetchStatusError is a non-200, non-304 HTTP response.
+type fetchStatusError struct {
+	StatusCode int
+	Status     string
+}
+
+func (e *fetchStatusError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+}
+
+// isRetryable reports whether a failed request is worth repeating: rate
+// limiting, server errors and transport failures are; other statuses are not.
+func isRetryable(err error) bool {
+	if se, ok := err.(*fetchStatusError); ok {
+		return se.StatusCode == http.StatusTooManyRequests || se.StatusCode >= 500
+	}
+	return true
+}
+
+// do performs one request. On failure it also returns the server's
+// Retry-After delay, if any.
+func (f *fetcher) do(rawURL, etag string) (fetchResponse, time.Duration, error) {
+	req, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return fetchResponse{}, 0, err
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return fetchResponse{}, 0, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		content, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fetchResponse{}, 0, err
+		}
+		return fetchResponse{Content: string(content), ETag: resp.Header.Get("ETag")}, 0, nil
+	case http.StatusNotModified:
+		newETag := resp.Header.Get("ETag")
+		if newETag == "" {
+			newETag = etag
+		}
+		return fetchResponse{ETag: newETag, NotModified: true}, 0, nil
+	default:
+		return fetchResponse{}, parseRetryAfter(resp.Header.Get("Retry-After")), &fetchStatusError{StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP
+// date. Unparseable values yield zero.
+func parseRetryAfter(v string) time.Duration {
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
+
+// wait blocks until the next request to host is allowed.
+func (f *fetcher) wait(host string) {
+	f.mu.Lock()
+	l, ok := f.hosts[host]
+	if !ok {
+		l = &hostLimiter{}
+		f.hosts[host] = l
+	}
+	f.mu.Unlock()
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next = slot.Add(f.interval)
+	l.mu.Unlock()
+
+	if d := slot.Sub(now); d > 0 {
+		f.sleep(d)
+	}
+}
@@ -0,0 +1,190 @@
+package projects
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestFetcher returns a fetcher that records sleeps instead of sleeping.
+func newTestFetcher(client *http.Client, config *Config) (*fetcher, *[]time.Duration) {
+	f := newFetcher(client, config)
+	var mu sync.Mutex
+	var sleeps []time.Duration
+	f.sleep = func(d time.Duration) {
+		mu.Lock()
+		sleeps = append(sleeps, d)
+		mu.Unlock()
+	}
+	return f, &sleeps
+}
+
+func TestFetcherRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch atomic.AddInt32(&calls, 1) {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte("ok"))
+		}
+	}))
+	defer srv.Close()
+
+	f, sleeps := newTestFetcher(srv.Client(), &Config{HostRequestsPerSecond: 1e9})
+	resp, err := f.fetch(srv.URL+"/project.yaml", "")
+	if err != nil {
+		t.Fatalf("fetch: %v", err)
+	}
+	if resp.Content != "ok" || calls != 3 {
+		t.Errorf("content = %q after %d calls, want ok after 3", resp.Content, calls)
+	}
+
+	var backoffs []time.Duration
+	for _, d := range *sleeps {
+		if d >= DefaultFetchRetryBackoff {
+			backoffs = append(backoffs, d)
+		}
+	}
+	want := []time.Duration{2 * time.Second, 2 * DefaultFetchRetryBackoff}
+	if fmt.Sprint(backoffs) != fmt.Sprint(want) {
+		t.Errorf("backoffs = %v, want %v (Retry-After, then doubled backoff)", backoffs, want)
+	}
+}
+
+func TestFetcherDoesNotRetryClientErrors(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	f, _ := newTestFetcher(srv.Client(), nil)
+	if _, err := f.fetch(srv.URL+"/missing.yaml", ""); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("err = %v, want HTTP 404", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
+
+func TestFetcherGivesUpAfterMaxRetries(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	f, _ := newTestFetcher(srv.Client(), &Config{MaxRetries: 2})
+	if _, err := f.fetch(srv.URL+"/project.yaml", ""); err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3 (1 + 2 retries)", calls)
+	}
+}
+
+func TestFetcherRateLimitsPerHost(t *testing.T) {
+	f, sleeps := newTestFetcher(nil, &Config{HostRequestsPerSecond: 2})
+	for i := 0; i < 3; i++ {
+		f.wait("a.example")
+	}
+	f.wait("b.example")
+
+	// The 2nd and 3rd requests to a.example wait ~0.5s and ~1s; b.example
+	// has its own budget and does not wait.
+	if len(*sleeps) != 2 {
+		t.Fatalf("sleeps = %v, want 2", *sleeps)
+	}
+	if d := (*sleeps)[1]; d < 900*time.Millisecond || d > time.Second {
+		t.Errorf("third request waited %v, want ~1s", d)
+	}
+}
+
+func TestValidateProjectsConcurrentOrderAndETag(t *testing.T) {
+	const n = 12
+	var inFlight, maxInFlight, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cur := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			m := atomic.LoadInt32(&maxInFlight)
+			if cur <= m || atomic.CompareAndSwapInt32(&maxInFlight, m, cur) {
+				break
+			}
+		}
+
+		var i int
+		fmt.Sscanf(r.URL.Path, "/p%d.yaml", &i)
+		// Later projects answer faster so completion order differs from
+		// list order.
+		time.Sleep(time.Duration(n-i) * 2 * time.Millisecond)
+
+		etag := fmt.Sprintf(`"v%d"`, i)
+		if r.Header.Get("If-None-Match") == etag {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(strings.Replace(validProjectYAML(), "name: Test Project", fmt.Sprintf("name: Project %d", i), 1)))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	var list strings.Builder
+	list.WriteString("projects:\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&list, "  - url: %q\n", fmt.Sprintf("%s/p%d.yaml", srv.URL, i))
+	}
+	writeFile(t, listPath, list.String())
+
+	cacheDir := filepath.Join(dir, "cache")
+	pv := NewValidator(cacheDir)
+	pv.config.Concurrency = 4
+	pv.config.HostRequestsPerSecond = 1e9
+	results, err := pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("ValidateAll: %v", err)
+	}
+	for i, r := range results {
+		if want := fmt.Sprintf("Project %d", i); r.ProjectName != want || !r.Changed || !r.Valid {
+			t.Errorf("results[%d] = %q changed=%v valid=%v, want %q changed valid", i, r.ProjectName, r.Changed, r.Valid, want)
+		}
+	}
+	if maxInFlight < 2 || maxInFlight > 4 {
+		t.Errorf("max in-flight requests = %d, want between 2 and 4", maxInFlight)
+	}
+
+	// A second run sends the stored ETags; unchanged files come back 304
+	// and are still validated from the cached content.
+	pv = NewValidator(cacheDir)
+	pv.config.HostRequestsPerSecond = 1e9
+	results, err = pv.ValidateAll(listPath)
+	if err != nil {
+		t.Fatalf("second ValidateAll: %v", err)
+	}
+	if notModified != n {
+		t.Errorf("304 responses = %d, want %d", notModified, n)
+	}
+	for i, r := range results {
+		if r.Changed || !r.Valid || r.ProjectName != fmt.Sprintf("Project %d", i) {
+			t.Errorf("second run results[%d] = %+v, want unchanged and valid", i, r)
+		}
+	}
+	if diff := pv.GenerateDiff(results); !strings.Contains(diff, fmt.Sprintf("%d projects validated, 0 changed", n)) {
+		t.Errorf("GenerateDiff summary wrong:\n%s", diff)
+	}
+}
@@ -0,0 +1,278 @@
+package projects
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+)
+
+// HandleVerifier checks that a maintainer handle belongs to a real account.
+// An error means the answer could not be obtained (outage, rate limiting,
+// rejected credentials), which is different from the handle being unknown.
+type HandleVerifier interface {
+	VerifyHandle(handle string) (bool, error)
+}
+
+// SetHandleVerifier sets the verifier used when maintainer verification is
+// requested. nil restores the default of HandleVerifierFromEnv. Wrap remote
+// verifiers with NewCachedHandleVerifier.
+func (pv *ProjectValidator) SetHandleVerifier(v HandleVerifier) {
+	pv.verifier = v
+}
+
+// handleVerifier returns the configured verifier, or the one described by
+// the environment. The environment is read on every call so that a change
+// takes effect, but the cached verifier built from it is reused while it
+// stays the same.
+func (pv *ProjectValidator) handleVerifier() HandleVerifier {
+	if pv.verifier != nil {
+		return pv.verifier
+	}
+	key := strings.Join([]string{os.Getenv("LFX_AUTH_TOKEN"), os.Getenv("MAINTAINER_API_ENDPOINT"), os.Getenv("MAINTAINER_API_STUB")}, "\x00")
+	pv.envMu.Lock()
+	defer pv.envMu.Unlock()
+	if !pv.envResolved || pv.envKey != key {
+		pv.envResolved, pv.envKey, pv.envVerifier = true, key, nil
+		if v := HandleVerifierFromEnv(); v != nil {
+			pv.envVerifier = NewCachedHandleVerifier(v, DefaultVerifyConcurrency)
+		}
+	}
+	return pv.envVerifier
+}
+
+// HandleVerifierFromEnv returns the verifier described by the environment:
+// LFX when LFX_AUTH_TOKEN is set (MAINTAINER_API_ENDPOINT overrides the API
+// gateway URL), a stub that accepts every handle when only
+// MAINTAINER_API_ENDPOINT is set (MAINTAINER_API_STUB=fail rejects every
+// handle instead), or nil.
+func HandleVerifierFromEnv() HandleVerifier {
+	endpoint := os.Getenv("MAINTAINER_API_ENDPOINT")
+	if token := os.Getenv("LFX_AUTH_TOKEN"); token != "" {
+		return &LFXVerifier{Token: token, BaseURL: endpoint}
+	}
+	if endpoint != "" {
+		return stubHandleVerifier{endpoint: endpoint, fail: strings.EqualFold(os.Getenv("MAINTAINER_API_STUB"), "fail")}
+	}
+	return nil
+}
+
+// stubHandleVerifier stands in for a verification service in CI.
+type stubHandleVerifier struct {
+	endpoint string
+	fail     bool
+}
+
+func (v stubHandleVerifier) VerifyHandle(handle string) (bool, error) {
+	log.Printf("[maintainers] Stub verifying handle %s via %s", handle, v.endpoint)
+	return !v.fail, nil
+}
+
+// GitHubUserVerifier checks that a GitHub account exists.
+type GitHubUserVerifier struct {
+	Token   string
+	Client  *http.Client
+	BaseURL string // GitHub API base; "" for DefaultGitHubAPIURL
+}
+
+// VerifyHandle reports whether handle is a GitHub user or organization. A
+// 404 means unknown; any other non-200 status is an error.
+func (v *GitHubUserVerifier) VerifyHandle(handle string) (bool, error) {
+	resp, err := githubGet(v.Client, v.BaseURL, v.Token, "/users/"+url.PathEscape(handle))
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("GitHub users API returned HTTP %d for %s%s", resp.StatusCode, handle, rateLimitHint(resp))
+	}
+}
+
+// LFXVerifier checks that a GitHub handle is linked to an LFX profile.
+type LFXVerifier struct {
+	Token   string
+	Client  *http.Client
+	BaseURL string // LFX API gateway; "" for DefaultLFXAPIURL
+}
+
+// VerifyHandle searches LFX users by GitHub ID. An empty result means
+// unknown; a non-200 status, including a rejected token, is an error.
+func (v *LFXVerifier) VerifyHandle(handle string) (bool, error) {
+	base := v.BaseURL
+	if base == "" {
+		base = DefaultLFXAPIURL
+	}
+	req, err := http.NewRequest("GET", strings.TrimRight(base, "/")+"/user-service/v1/users/search?githubID="+url.QueryEscape(handle), nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+v.Token)
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+
+	client := v.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("querying LFX: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("LFX user search returned HTTP %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Data []json.RawMessage `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("parsing LFX response: %w", err)
+	}
+	return len(result.Data) > 0, nil
+}
+
+// AllowlistVerifier accepts exactly the handles it lists, e.g. bots or
+// accounts that cannot be looked up elsewhere.
+type AllowlistVerifier struct {
+	Handles map[string]bool // lowercase, without '@'
+}
+
+// LoadHandleAllowlist reads an allowlist file with one handle per line.
+// Blank lines and lines starting with '#' are ignored.
+func LoadHandleAllowlist(path string) (*AllowlistVerifier, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening handle allowlist: %w", err)
+	}
+	defer f.Close()
+
+	v := &AllowlistVerifier{Handles: make(map[string]bool)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		v.Handles[normalizeHandle(line)] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading handle allowlist %s: %w", path, err)
+	}
+	return v, nil
+}
+
+// VerifyHandle reports whether handle is on the list.
+func (v *AllowlistVerifier) VerifyHandle(handle string) (bool, error) {
+	return v.Handles[normalizeHandle(handle)], nil
+}
+
+// AnyHandleVerifier accepts a handle that any of its verifiers accepts. A
+// handle is only reported unknown when every verifier answered; if one
+// failed, its error is returned instead.
+type AnyHandleVerifier []HandleVerifier
+
+// VerifyHandle asks each verifier in order until one accepts handle.
+func (vs AnyHandleVerifier) VerifyHandle(handle string) (bool, error) {
+	var errs []error
+	for _, v := range vs {
+		found, err := v.VerifyHandle(handle)
+		if found {
+			return true, nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return false, errors.Join(errs...)
+}
+
+// CachedHandleVerifier remembers each handle's result for the lifetime of
+// the verifier and bounds how many lookups run at once. Concurrent requests
+// for the same handle share one lookup. Errors are cached too, so an outage
+// is not retried for every team that lists the handle.
+type CachedHandleVerifier struct {
+	verifier HandleVerifier
+	sem      chan struct{}
+
+	mu      sync.Mutex
+	results map[string]*handleLookup
+}
+
+type handleLookup struct {
+	done  chan struct{}
+	found bool
+	err   error
+}
+
+// NewCachedHandleVerifier wraps v. concurrency bounds parallel lookups; 0
+// means DefaultVerifyConcurrency.
+func NewCachedHandleVerifier(v HandleVerifier, concurrency int) *CachedHandleVerifier {
+	if concurrency <= 0 {
+		concurrency = DefaultVerifyConcurrency
+	}
+	return &CachedHandleVerifier{
+		verifier: v,
+		sem:      make(chan struct{}, concurrency),
+		results:  make(map[string]*handleLookup),
+	}
+}
+
+// VerifyHandle returns the cached result for handle, looking it up first if
+// needed. Handles are compared case-insensitively.
+func (c *CachedHandleVerifier) VerifyHandle(handle string) (bool, error) {
+	key := normalizeHandle(handle)
+	c.mu.Lock()
+	l, ok := c.results[key]
+	if !ok {
+		l = &handleLookup{done: make(chan struct{})}
+		c.results[key] = l
+	}
+	c.mu.Unlock()
+
+	if !ok {
+		c.sem <- struct{}{}
+		l.found, l.err = c.verifier.VerifyHandle(handle)
+		<-c.sem
+		close(l.done)
+	}
+	<-l.done
+	return l.found, l.err
+}
+
+// handleCheck is the verification outcome for one handle.
+type handleCheck struct {
+	handle string
+	found  bool
+	err    error
+}
+
+// verifyHandles checks handles in parallel; the verifier bounds concurrency.
+// Results are in the order of handles.
+func verifyHandles(v HandleVerifier, handles []string) []handleCheck {
+	checks := make([]handleCheck, len(handles))
+	var wg sync.WaitGroup
+	for i, h := range handles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			found, err := v.VerifyHandle(h)
+			checks[i] = handleCheck{handle: h, found: found, err: err}
+		}()
+	}
+	wg.Wait()
+	return checks
+}
@@ -0,0 +1,141 @@
+package projects
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGitHubUserVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/users/alice":
+			w.Write([]byte(`{"login": "alice"}`))
+		case "/users/limited":
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	v := &GitHubUserVerifier{BaseURL: server.URL}
+
+	if found, err := v.VerifyHandle("alice"); !found || err != nil {
+		t.Errorf("alice = %v, %v; want found", found, err)
+	}
+	if found, err := v.VerifyHandle("ghost"); found || err != nil {
+		t.Errorf("ghost = %v, %v; want not found", found, err)
+	}
+	if _, err := v.VerifyHandle("limited"); err == nil {
+		t.Error("expected an error when rate limited")
+	}
+}
+
+func TestLFXVerifier(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user-service/v1/users/search" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("githubID") {
+		case "alice":
+			w.Write([]byte(`{"data": [{"Username": "alice"}]}`))
+		case "broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.Write([]byte(`{"data": []}`))
+		}
+	}))
+	defer server.Close()
+	v := &LFXVerifier{Token: "t", BaseURL: server.URL}
+
+	if found, err := v.VerifyHandle("alice"); !found || err != nil {
+		t.Errorf("alice = %v, %v; want found", found, err)
+	}
+	if found, err := v.VerifyHandle("ghost"); found || err != nil {
+		t.Errorf("ghost = %v, %v; want not found", found, err)
+	}
+	if _, err := v.VerifyHandle("broken"); err == nil {
+		t.Error("expected an error for a 502")
+	}
+}
+
+func TestLoadHandleAllowlist(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "allowlist.txt")
+	writeFile(t, path, "# bots\n@k8s-ci-robot\n\nAlice\n")
+	v, err := LoadHandleAllowlist(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for handle, want := range map[string]bool{"k8s-ci-robot": true, "@alice": true, "bob": false} {
+		if found, err := v.VerifyHandle(handle); found != want || err != nil {
+			t.Errorf("%s = %v, %v; want %v", handle, found, err, want)
+		}
+	}
+}
+
+type funcVerifier func(string) (bool, error)
+
+func (f funcVerifier) VerifyHandle(h string) (bool, error) { return f(h) }
+
+func TestAnyHandleVerifier(t *testing.T) {
+	down := funcVerifier(func(string) (bool, error) { return false, errors.New("down") })
+	allow := &AllowlistVerifier{Handles: map[string]bool{"bot": true}}
+
+	v := AnyHandleVerifier{allow, down}
+	if found, err := v.VerifyHandle("bot"); !found || err != nil {
+		t.Errorf("bot = %v, %v; want found", found, err)
+	}
+	if found, err := v.VerifyHandle("alice"); found || err == nil {
+		t.Errorf("alice = %v, %v; want an error while a verifier is down", found, err)
+	}
+	if found, err := (AnyHandleVerifier{allow}).VerifyHandle("alice"); found || err != nil {
+		t.Errorf("alice = %v, %v; want not found", found, err)
+	}
+}
+
+func TestCachedHandleVerifier(t *testing.T) {
+	var calls, running, peak atomic.Int32
+	slow := funcVerifier(func(h string) (bool, error) {
+		calls.Add(1)
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		running.Add(-1)
+		return h != "ghost", nil
+	})
+	v := NewCachedHandleVerifier(slow, 2)
+
+	handles := []string{"a", "b", "c", "d", "A", "@b", "ghost"}
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, c := range verifyHandles(v, handles) {
+				if c.found == (c.handle == "ghost") || c.err != nil {
+					t.Errorf("%s = %v, %v", c.handle, c.found, c.err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 5 {
+		t.Errorf("lookups = %d, want 5 (one per distinct handle)", got)
+	}
+	if got := peak.Load(); got > 2 {
+		t.Errorf("peak concurrency = %d, want at most 2", got)
+	}
+}
@@ -0,0 +1,325 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaIDBase  = "https://github.com/cncf/automation/utilities/dot-project/schema"
+)
+
+// JSONSchema is a JSON Schema (draft 2020-12) node. Only the keywords the
+// project schema needs are modelled.
+type JSONSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	ID                   string                 `json:"$id,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Type                 string                 `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	MinLength            *int                   `json:"minLength,omitempty"`
+	MinItems             *int                   `json:"minItems,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+	AdditionalProperties interface{}            `json:"additionalProperties,omitempty"` // false, or a *JSONSchema for map values
+	OneOf                []*JSONSchema          `json:"oneOf,omitempty"`
+	AnyOf                []*JSONSchema          `json:"anyOf,omitempty"`
+	Defs                 map[string]*JSONSchema `json:"$defs,omitempty"`
+}
+
+// schemaGenerator builds a JSON Schema by walking Go types with reflection.
+// Named struct types become entries in $defs and are referenced with $ref.
+type schemaGenerator struct {
+	annotations map[string]SchemaAnnotation
+	used        map[string]bool
+	defs        map[string]*JSONSchema
+}
+
+// GenerateProjectSchema builds the JSON Schema for project.yaml from the
+// Project type's json struct tags and the annotations in
+// projectSchemaAnnotations. It fails if an annotation names a type or field
+// that no longer exists, so renames in types.go cannot silently drop
+// documentation.
+func GenerateProjectSchema() (*JSONSchema, error) {
+	g := &schemaGenerator{
+		annotations: projectSchemaAnnotations(),
+		used:        make(map[string]bool),
+		defs:        make(map[string]*JSONSchema),
+	}
+
+	root := g.structSchema(reflect.TypeOf(Project{}))
+	root.Schema = jsonSchemaDialect
+	root.ID = fmt.Sprintf("%s/v%s/project.json", jsonSchemaIDBase, LatestSchemaVersion())
+	root.Title = "CNCF Project Metadata"
+	root.Description = "Schema for CNCF .project repository project.yaml files"
+	root.Defs = g.defs
+
+	var unused []string
+	for key := range g.annotations {
+		if !g.used[key] {
+			unused = append(unused, key)
+		}
+	}
+	if len(unused) > 0 {
+		sort.Strings(unused)
+		return nil, fmt.Errorf("schema annotations do not match any type or field: %s", strings.Join(unused, ", "))
+	}
+	return root, nil
+}
+
+// MarshalProjectSchema renders GenerateProjectSchema as indented JSON, in the
+// exact form checked in at schema/project.schema.json.
+func MarshalProjectSchema() ([]byte, error) {
+	schema, err := GenerateProjectSchema()
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(schema); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// typeSchema returns the schema for a Go type, referencing struct types
+// through $defs.
+func (g *schemaGenerator) typeSchema(t reflect.Type) *JSONSchema {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if s, ok := g.overrideSchema(t); ok {
+		return s
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return &JSONSchema{Type: "string"}
+	case reflect.Bool:
+		return &JSONSchema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &JSONSchema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &JSONSchema{Type: "number"}
+	case reflect.Slice, reflect.Array:
+		return &JSONSchema{Type: "array", Items: g.typeSchema(t.Elem())}
+	case reflect.Map:
+		return &JSONSchema{Type: "object", AdditionalProperties: g.typeSchema(t.Elem())}
+	case reflect.Struct:
+		return g.structRef(t)
+	default:
+		return &JSONSchema{}
+	}
+}
+
+// structRef registers t in $defs (once) and returns a reference to it.
+func (g *schemaGenerator) structRef(t reflect.Type) *JSONSchema {
+	name := t.Name()
+	if _, ok := g.defs[name]; !ok {
+		g.defs[name] = nil // reserve the name so recursive types terminate
+		g.defs[name] = g.structSchema(t)
+	}
+	return &JSONSchema{Ref: "#/$defs/" + name}
+}
+
+// structSchema builds an object schema from a struct's exported fields.
+// A field is required unless its json tag has omitempty or its annotation
+// marks it Optional.
+func (g *schemaGenerator) structSchema(t reflect.Type) *JSONSchema {
+	s := &JSONSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*JSONSchema),
+		AdditionalProperties: false,
+	}
+	if a, ok := g.annotation(t.Name()); ok {
+		s.Description = a.Description
+		for _, group := range a.AnyOfRequired {
+			s.AnyOf = append(s.AnyOf, &JSONSchema{Required: group})
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, omitempty := jsonFieldName(f)
+		if name == "" {
+			continue
+		}
+
+		prop := g.typeSchema(f.Type)
+		a, annotated := g.annotation(t.Name() + "." + name)
+		if annotated {
+			a.apply(prop)
+		}
+		s.Properties[name] = prop
+		if !omitempty && !a.Optional {
+			s.Required = append(s.Required, name)
+		}
+	}
+	return s
+}
+
+// annotation looks up an annotation and records that it was used.
+func (g *schemaGenerator) annotation(key string) (SchemaAnnotation, bool) {
+	a, ok := g.annotations[key]
+	if ok {
+		g.used[key] = true
+	}
+	return a, ok
+}
+
+// jsonFieldName returns the JSON property name for a struct field and
+// whether it is tagged omitempty. Fields tagged "-" yield an empty name.
+func jsonFieldName(f reflect.StructField) (string, bool) {
+	tag := f.Tag.Get("json")
+	if tag == "-" {
+		return "", false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = f.Name
+	}
+	omitempty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+	return name, omitempty
+}
+
+// SchemaAnnotation carries schema details that struct tags cannot express.
+// Annotations are keyed by Go type name ("SecurityContact") for the type
+// itself, or by type name and JSON field name ("Project.slug") for a field.
+type SchemaAnnotation struct {
+	Description string
+	Pattern     string   // Applied to the innermost string schema (array items, map values)
+	Format      string   // Applied like Pattern
+	Enum        []string // Applied like Pattern
+	Optional    bool     // Field is optional even though its json tag lacks omitempty
+	NonEmpty    bool     // Arrays need at least one item; strings at least one character
+
+	// AnyOfRequired, on a type annotation, requires at least one of the
+	// listed property groups to be present.
+	AnyOfRequired [][]string
+}
+
+// apply copies the annotation onto a generated property schema. The
+// description stays on the property itself; string constraints are pushed
+// down to the element schema of arrays and maps.
+func (a SchemaAnnotation) apply(s *JSONSchema) {
+	if a.Description != "" {
+		s.Description = a.Description
+	}
+	leaf := s
+	for {
+		if leaf.Items != nil {
+			leaf = leaf.Items
+			continue
+		}
+		if m, ok := leaf.AdditionalProperties.(*JSONSchema); ok {
+			leaf = m
+			continue
+		}
+		break
+	}
+	if a.NonEmpty {
+		one := 1
+		if s.Type == "array" {
+			s.MinItems = &one
+		} else {
+			leaf.MinLength = &one
+		}
+	}
+	if a.Pattern != "" {
+		leaf.Pattern = a.Pattern
+	}
+	if a.Format != "" {
+		leaf.Format = a.Format
+	}
+	if len(a.Enum) > 0 {
+		leaf.Enum = a.Enum
+	}
+}
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	stringOrSliceType   = reflect.TypeOf(StringOrSlice{})
+	repositoryEntryType = reflect.TypeOf(RepositoryEntry{})
+)
+
+// overrideSchema supplies schemas for types whose YAML/JSON form is not what
+// reflection over their fields would produce: types with custom
+// (un)marshalers and time values.
+func (g *schemaGenerator) overrideSchema(t reflect.Type) (*JSONSchema, bool) {
+	switch t {
+	case timeType:
+		return &JSONSchema{Type: "string", Format: "date-time"}, true
+	case stringOrSliceType:
+		// A plain string or a list of strings.
+		return &JSONSchema{OneOf: []*JSONSchema{
+			{Type: "string"},
+			{Type: "array", Items: &JSONSchema{Type: "string"}},
+		}}, true
+	case repositoryEntryType:
+		// A plain URL string or the expanded object form.
+		return &JSONSchema{OneOf: []*JSONSchema{
+			{Type: "string", Format: "uri"},
+			g.structRef(repositoryEntryType),
+		}}, true
+	}
+	return nil, false
+}
+
+// UnmarshalJSON decodes a schema, turning additionalProperties into either a
+// bool or a *JSONSchema so that loaded schemas look like generated ones.
+func (s *JSONSchema) UnmarshalJSON(data []byte) error {
+	type plain JSONSchema
+	aux := struct {
+		*plain
+		AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	s.AdditionalProperties = nil
+	if len(aux.AdditionalProperties) == 0 {
+		return nil
+	}
+	var b bool
+	if err := json.Unmarshal(aux.AdditionalProperties, &b); err == nil {
+		s.AdditionalProperties = b
+		return nil
+	}
+	var sub JSONSchema
+	if err := json.Unmarshal(aux.AdditionalProperties, &sub); err != nil {
+		return fmt.Errorf("additionalProperties: %w", err)
+	}
+	s.AdditionalProperties = &sub
+	return nil
+}
+
+// LoadJSONSchema parses a JSON Schema document such as
+// schema/project.schema.json.
+func LoadJSONSchema(data []byte) (*JSONSchema, error) {
+	var s JSONSchema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing JSON Schema: %w", err)
+	}
+	return &s, nil
+}
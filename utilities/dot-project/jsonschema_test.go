@@ -0,0 +1,77 @@
+package projects
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestProjectSchemaUpToDate(t *testing.T) {
+	want, err := MarshalProjectSchema()
+	if err != nil {
+		t.Fatalf("MarshalProjectSchema: %v", err)
+	}
+	got, err := os.ReadFile("schema/project.schema.json")
+	if err != nil {
+		t.Fatalf("reading checked-in schema: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Error("schema/project.schema.json is out of date with types.go; regenerate it with:\n\tgo run ./cmd/generate-schema > schema/project.schema.json")
+	}
+}
+
+func TestGenerateProjectSchema(t *testing.T) {
+	schema, err := GenerateProjectSchema()
+	if err != nil {
+		t.Fatalf("GenerateProjectSchema: %v", err)
+	}
+
+	wantRequired := []string{"name", "description", "maturity_log", "repositories", "schema_version", "slug"}
+	if len(schema.Required) != len(wantRequired) {
+		t.Fatalf("required = %v, want %v", schema.Required, wantRequired)
+	}
+	for i := range wantRequired {
+		if schema.Required[i] != wantRequired[i] {
+			t.Errorf("required = %v, want %v", schema.Required, wantRequired)
+			break
+		}
+	}
+	if schema.AdditionalProperties != false {
+		t.Error("top-level additionalProperties should be false")
+	}
+
+	// RepositoryEntry accepts a URL string or an object.
+	repos := schema.Properties["repositories"]
+	if repos.Items == nil || len(repos.Items.OneOf) != 2 {
+		t.Fatalf("repositories items = %+v, want oneOf string/object", repos.Items)
+	}
+	if repos.Items.OneOf[0].Type != "string" || repos.Items.OneOf[1].Ref != "#/$defs/RepositoryEntry" {
+		t.Errorf("repositories oneOf = %+v, %+v", repos.Items.OneOf[0], repos.Items.OneOf[1])
+	}
+	if def := schema.Defs["RepositoryEntry"]; def == nil || def.Properties["url"].Format != "uri" {
+		t.Errorf("RepositoryEntry def = %+v", def)
+	}
+
+	// StringOrSlice fields accept a string or a list of strings.
+	if lead := schema.Properties["project_lead"]; len(lead.OneOf) != 2 || lead.Description == "" {
+		t.Errorf("project_lead = %+v", lead)
+	}
+	pm, ok := schema.Properties["package_managers"].AdditionalProperties.(*JSONSchema)
+	if !ok || len(pm.OneOf) != 2 {
+		t.Errorf("package_managers values = %+v", schema.Properties["package_managers"].AdditionalProperties)
+	}
+
+	// Annotations reach nested element schemas.
+	if social, ok := schema.Properties["social"].AdditionalProperties.(*JSONSchema); !ok || social.Format != "uri" {
+		t.Errorf("social values should have uri format, got %+v", schema.Properties["social"].AdditionalProperties)
+	}
+	if got := schema.Properties["schema_version"].Enum; len(got) != len(SupportedSchemaVersions) {
+		t.Errorf("schema_version enum = %v, want %v", got, SupportedSchemaVersions)
+	}
+	if contact := schema.Defs["SecurityContact"]; contact == nil || len(contact.AnyOf) != 2 {
+		t.Errorf("SecurityContact should require email or advisory_url: %+v", contact)
+	}
+	if date := schema.Defs["MaturityEntry"].Properties["date"]; date.Format != "date-time" {
+		t.Errorf("MaturityEntry.date = %+v", date)
+	}
+}
@@ -49,15 +49,17 @@ func ProjectToLandscapeEntry(project Project) LandscapeEntry {
 		entry.RepoURL = repoURL
 	}
 
-	// Get twitter URL from social
-	if twitter, ok := project.Social["twitter"]; ok {
-		entry.Twitter = twitter
+	// Social links: twitter is a top-level field, the rest go under extra
+	for key, link := range LandscapeSocialLinks(project) {
+		if key == "twitter" {
+			entry.Twitter = link
+		} else {
+			entry.Extra[key] = link
+		}
 	}
 
 	// Get current maturity (last entry in log)
-	if len(project.MaturityLog) > 0 {
-		entry.Project = project.MaturityLog[len(project.MaturityLog)-1].Phase
-	}
+	entry.Project = CurrentMaturityPhase(project)
 
 	// Set slug in extra
 	if project.Slug != "" {
@@ -0,0 +1,301 @@
+package projects
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LintFix records one mechanical fix applied to project.yaml. Rule is the ID
+// of the diagnostic the fix resolves.
+type LintFix struct {
+	Path        string `json:"path"`
+	Rule        string `json:"rule"`
+	Description string `json:"description"`
+}
+
+// LintResult is the outcome of linting one file: the fixes applied and the
+// error diagnostics that still need a human.
+type LintResult struct {
+	File      string       `json:"file"`
+	Fixes     []LintFix    `json:"fixes,omitempty"`
+	Remaining []Diagnostic `json:"remaining,omitempty"`
+}
+
+// FixProjectYAML applies the mechanical fixes for common project.yaml
+// mistakes. Fixes edit only the affected values or list items in the
+// source text, so a one-value fix is a one-line diff:
+//
+//   - slack_channels[].name without a leading '#'
+//   - project_lead entries with a leading '@'
+//   - maturity_log entries out of chronological order
+//   - schema_version with a leading 'v'
+//   - repositories listed more than once
+//   - social links given as a bare handle, e.g. twitter: "@project"
+//
+// Content that needs no fixes is returned byte-for-byte unchanged, so the
+// fixer is idempotent and safe to run from a pre-commit hook. A mistake in
+// a value that spans several lines or sits in a flow-style list is left
+// for the validator to report.
+func FixProjectYAML(content []byte) ([]byte, []LintFix, error) {
+	doc, err := parseYAMLDocument(content)
+	if err != nil {
+		return nil, nil, fmt.Errorf("parsing project YAML: %w", err)
+	}
+	root := documentRoot(doc)
+	ed := newYAMLEditor(content)
+
+	var fixes []LintFix
+	fixes = append(fixes, fixSchemaVersion(root, ed)...)
+	fixes = append(fixes, fixProjectLeads(root, ed)...)
+	fixes = append(fixes, fixSlackChannelNames(root, ed)...)
+	fixes = append(fixes, fixMaturityLogOrder(root, ed)...)
+	fixes = append(fixes, fixDuplicateRepositories(root, ed)...)
+	fixes = append(fixes, fixSocialHandles(root, ed)...)
+	if len(fixes) == 0 {
+		return content, nil, nil
+	}
+	return ed.bytes(), fixes, nil
+}
+
+// LintProjectFile fixes the project.yaml at path in place, writing only when
+// something changed, and validates the result.
+func LintProjectFile(path string) (LintResult, error) {
+	result := LintResult{File: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return result, err
+	}
+	fixed, fixes, err := FixProjectYAML(content)
+	if err != nil {
+		return result, fmt.Errorf("%s: %w", path, err)
+	}
+	result.Fixes = fixes
+	if len(fixes) > 0 {
+		if err := writeFileAtomic(path, fixed); err != nil {
+			return result, err
+		}
+	}
+
+	_, diags, _ := validateProjectContent(string(fixed))
+	for _, d := range diags {
+		if d.Severity == SeverityError {
+			result.Remaining = append(result.Remaining, d)
+		}
+	}
+	return result, nil
+}
+
+func fixSchemaVersion(root *yaml.Node, ed *yamlEditor) []LintFix {
+	v := mappingValue(root, "schema_version")
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return nil
+	}
+	trimmed := strings.TrimPrefix(strings.TrimPrefix(v.Value, "v"), "V")
+	if trimmed == v.Value {
+		return nil
+	}
+	if _, err := parseSemver(trimmed); err != nil {
+		return nil
+	}
+	old := v.Value
+	if !ed.setScalar(v, trimmed) {
+		return nil
+	}
+	return []LintFix{{Path: "schema_version", Rule: RuleSchemaVersion, Description: fmt.Sprintf("schema_version: %q -> %q", old, trimmed)}}
+}
+
+func fixProjectLeads(root *yaml.Node, ed *yamlEditor) []LintFix {
+	v := mappingValue(root, "project_lead")
+	if v == nil {
+		return nil
+	}
+	var fixes []LintFix
+	fix := func(n *yaml.Node, path string) {
+		if n.Kind != yaml.ScalarNode {
+			return
+		}
+		lead := strings.TrimPrefix(strings.TrimSpace(n.Value), "@")
+		if lead == "" || lead == n.Value {
+			return
+		}
+		old := n.Value
+		if ed.setScalar(n, lead) {
+			fixes = append(fixes, LintFix{Path: path, Rule: RuleProjectLeadFormat, Description: fmt.Sprintf("%s: %q -> %q", path, old, lead)})
+		}
+	}
+	switch v.Kind {
+	case yaml.ScalarNode:
+		fix(v, "project_lead")
+	case yaml.SequenceNode:
+		for i, item := range v.Content {
+			fix(item, fmt.Sprintf("project_lead[%d]", i))
+		}
+	}
+	return fixes
+}
+
+func fixSlackChannelNames(root *yaml.Node, ed *yamlEditor) []LintFix {
+	channels := mappingValue(root, "slack_channels")
+	if channels == nil || channels.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var fixes []LintFix
+	for i, ch := range channels.Content {
+		name := mappingValue(ch, "name")
+		if name == nil || name.Kind != yaml.ScalarNode {
+			continue
+		}
+		trimmed := strings.TrimSpace(name.Value)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		path := fmt.Sprintf("slack_channels[%d].name", i)
+		old := name.Value
+		// A bare # starts a comment, so setScalar quotes the new value.
+		if ed.setScalar(name, "#"+trimmed) {
+			fixes = append(fixes, LintFix{Path: path, Rule: RuleSlackChannelName, Description: fmt.Sprintf("%s: %q -> %q", path, old, "#"+trimmed)})
+		}
+	}
+	return fixes
+}
+
+// fixSocialHandles expands handle-only social values into the platform's
+// canonical URL.
+func fixSocialHandles(root *yaml.Node, ed *yamlEditor) []LintFix {
+	social := mappingValue(root, "social")
+	if social == nil || social.Kind != yaml.MappingNode {
+		return nil
+	}
+	var fixes []LintFix
+	for i := 0; i+1 < len(social.Content); i += 2 {
+		key, v := social.Content[i].Value, social.Content[i+1]
+		if v.Kind != yaml.ScalarNode || isValidURL(v.Value) {
+			continue
+		}
+		link, ok := NormalizeSocialLink(key, v.Value)
+		if !ok {
+			continue
+		}
+		path := "social." + key
+		old := v.Value
+		if ed.setScalar(v, link) {
+			fixes = append(fixes, LintFix{Path: path, Rule: RuleURLFormat, Description: fmt.Sprintf("%s: %q -> %q", path, old, link)})
+		}
+	}
+	return fixes
+}
+
+// fixMaturityLogOrder sorts maturity_log entries by date, oldest first. It
+// leaves the list alone if any date is missing or unparseable.
+func fixMaturityLogOrder(root *yaml.Node, ed *yamlEditor) []LintFix {
+	log := mappingValue(root, "maturity_log")
+	if log == nil || log.Kind != yaml.SequenceNode || len(log.Content) < 2 {
+		return nil
+	}
+	dates := make(map[*yaml.Node]time.Time, len(log.Content))
+	for _, entry := range log.Content {
+		d := mappingValue(entry, "date")
+		if d == nil {
+			return nil
+		}
+		var t time.Time
+		if err := d.Decode(&t); err != nil {
+			return nil
+		}
+		dates[entry] = t
+	}
+	if sort.SliceIsSorted(log.Content, func(i, j int) bool {
+		return dates[log.Content[i]].Before(dates[log.Content[j]])
+	}) {
+		return nil
+	}
+	sorted := append([]*yaml.Node(nil), log.Content...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return dates[sorted[i]].Before(dates[sorted[j]])
+	})
+	if !ed.reorderItems(log.Content, sorted) {
+		return nil
+	}
+	log.Content = sorted
+	return []LintFix{{Path: "maturity_log", Rule: RuleMaturityOrder, Description: "maturity_log: sorted entries by date, oldest first"}}
+}
+
+// fixDuplicateRepositories drops later entries whose URL repeats an earlier
+// one. A duplicate that carries tags or primary: true is kept, since
+// dropping it would lose data; the validator still reports it.
+func fixDuplicateRepositories(root *yaml.Node, ed *yamlEditor) []LintFix {
+	repos := mappingValue(root, "repositories")
+	if repos == nil || repos.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var fixes []LintFix
+	seen := make(map[string]bool)
+	kept := repos.Content[:0]
+	for i, item := range repos.Content {
+		url := item.Value
+		plain := item.Kind == yaml.ScalarNode
+		if item.Kind == yaml.MappingNode {
+			if u := mappingValue(item, "url"); u != nil {
+				url = u.Value
+			}
+			plain = mappingValue(item, "tags") == nil && mappingValue(item, "primary") == nil
+		}
+		key := normalizeRepoURL(url)
+		if key != "" && seen[key] && plain && ed.deleteItem(item) {
+			fixes = append(fixes, LintFix{
+				Path:        fmt.Sprintf("repositories[%d]", i),
+				Rule:        RuleDuplicateRepository,
+				Description: fmt.Sprintf("repositories[%d]: removed duplicate %s", i, url),
+			})
+			continue
+		}
+		seen[key] = true
+		kept = append(kept, item)
+	}
+	repos.Content = kept
+	return fixes
+}
+
+// normalizeRepoURL returns the comparison key for a repository URL: trimmed,
+// lowercased, without a trailing slash or .git suffix.
+func normalizeRepoURL(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	u = strings.TrimSuffix(u, "/")
+	return strings.TrimSuffix(u, ".git")
+}
+
+// FormatLintResults renders a plain-text summary of lint runs: the fixes
+// applied to each file and the errors left to fix by hand.
+func FormatLintResults(results []LintResult) string {
+	var sb strings.Builder
+	totalFixes, totalRemaining := 0, 0
+	for _, r := range results {
+		totalFixes += len(r.Fixes)
+		totalRemaining += len(r.Remaining)
+		switch {
+		case len(r.Fixes) == 0 && len(r.Remaining) == 0:
+			fmt.Fprintf(&sb, "%s: ok\n", r.File)
+			continue
+		case len(r.Fixes) == 0:
+			fmt.Fprintf(&sb, "%s: nothing to fix automatically\n", r.File)
+		default:
+			fmt.Fprintf(&sb, "%s: applied %d fix(es)\n", r.File, len(r.Fixes))
+		}
+		for _, f := range r.Fixes {
+			fmt.Fprintf(&sb, "  - fixed %s\n", f.Description)
+		}
+		if len(r.Remaining) > 0 {
+			fmt.Fprintf(&sb, "%s: %d error(s) to fix by hand\n", r.File, len(r.Remaining))
+			for _, d := range r.Remaining {
+				sb.WriteString(formatDiagnosticLine(d))
+			}
+		}
+	}
+	fmt.Fprintf(&sb, "\n%d fix(es) applied, %d error(s) remaining\n", totalFixes, totalRemaining)
+	return sb.String()
+}
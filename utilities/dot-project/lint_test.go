@@ -0,0 +1,225 @@
+package projects
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const messyProjectYAML = `# Project metadata for Test Project
+schema_version: v1.0.0
+slug: test-project
+name: Test Project
+description: A valid test project
+project_lead: "@alice" # current lead
+maturity_log:
+  - phase: incubating
+    date: 2025-03-01
+    issue: https://github.com/cncf/toc/issues/2
+  # accepted into the sandbox
+  - phase: sandbox
+    date: 2024-01-15
+    issue: https://github.com/cncf/toc/issues/1
+repositories:
+  - https://github.com/test/repo
+  - url: https://github.com/test/other
+    primary: true
+  - https://github.com/test/repo.git
+slack_channels:
+  - name: test-project
+  - name: "#test-dev"
+`
+
+func TestFixProjectYAML(t *testing.T) {
+	out, fixes, err := FixProjectYAML([]byte(messyProjectYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rules := map[string]bool{}
+	for _, f := range fixes {
+		rules[f.Rule] = true
+	}
+	for _, r := range []string{RuleSchemaVersion, RuleProjectLeadFormat, RuleSlackChannelName, RuleMaturityOrder, RuleDuplicateRepository} {
+		if !rules[r] {
+			t.Errorf("no %s fix in %+v", r, fixes)
+		}
+	}
+	if len(fixes) != 5 {
+		t.Errorf("got %d fixes, want 5: %+v", len(fixes), fixes)
+	}
+
+	got := string(out)
+	for _, want := range []string{
+		"# Project metadata for Test Project",
+		"project_lead: \"alice\" # current lead",
+		"# accepted into the sandbox",
+		`name: "#test-project"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("fixed YAML missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "test/repo") != 1 {
+		t.Errorf("duplicate repository not removed:\n%s", got)
+	}
+	if strings.Index(got, "phase: sandbox") > strings.Index(got, "phase: incubating") {
+		t.Errorf("maturity_log not sorted:\n%s", got)
+	}
+
+	_, diags, parsed := validateProjectContent(got)
+	if !parsed || hasErrorDiagnostics(diags) {
+		t.Errorf("fixed YAML still invalid: %v", diagnosticMessages(diags, SeverityError))
+	}
+}
+
+func TestFixProjectYAMLIdempotent(t *testing.T) {
+	once, _, err := FixProjectYAML([]byte(messyProjectYAML))
+	if err != nil {
+		t.Fatal(err)
+	}
+	twice, fixes, err := FixProjectYAML(once)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 0 || string(twice) != string(once) {
+		t.Errorf("second run changed the file: fixes %+v\n%s", fixes, twice)
+	}
+
+	clean := validProjectYAML()
+	out, fixes, err := FixProjectYAML([]byte(clean))
+	if err != nil || len(fixes) != 0 || string(out) != clean {
+		t.Errorf("clean file was rewritten: fixes %+v, err %v", fixes, err)
+	}
+}
+
+func TestFixProjectYAMLChangesOnlyFixedLines(t *testing.T) {
+	template, err := os.ReadFile(filepath.Join("template", "project.yaml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := `  - name: "#my-project"         # REQUIRED per entry: must start with "#"`
+	if !strings.Contains(string(template), before) {
+		t.Fatalf("template no longer contains %q", before)
+	}
+	content := strings.Replace(string(template), before, `  - name: my-project            # REQUIRED per entry: must start with "#"`, 1)
+
+	out, fixes, err := FixProjectYAML([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 1 {
+		t.Fatalf("got fixes %+v, want one", fixes)
+	}
+	if string(out) != string(template) {
+		t.Errorf("fix changed more than the channel name:\n%s", UnifiedDiff("template", "fixed", template, out))
+	}
+}
+
+func TestFixProjectYAMLKeepsLayoutAroundListEdits(t *testing.T) {
+	content := `slug: test-project
+
+maturity_log:
+  # incubation
+  - phase: incubating
+    date: 2025-03-01
+
+  - phase: sandbox
+    date: 2024-01-15
+
+repositories:
+  - https://github.com/test/repo    # main
+  - https://github.com/test/repo/   # again
+
+name: Test Project
+`
+	want := `slug: test-project
+
+maturity_log:
+  - phase: sandbox
+    date: 2024-01-15
+
+  # incubation
+  - phase: incubating
+    date: 2025-03-01
+
+repositories:
+  - https://github.com/test/repo    # main
+
+name: Test Project
+`
+	out, fixes, err := FixProjectYAML([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 2 || string(out) != want {
+		t.Errorf("fixes %+v, got:\n%s", fixes, out)
+	}
+}
+
+func TestFixDuplicateRepositoryKeepsTaggedEntry(t *testing.T) {
+	content := validProjectYAML() + "  - url: https://github.com/test/repo/\n    tags: [core]\n"
+	_, fixes, err := FixProjectYAML([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 0 {
+		t.Errorf("a duplicate with tags should be left for a human, got %+v", fixes)
+	}
+
+	_, diags, _ := validateProjectContent(content)
+	if d, ok := findDiagnostic(diags, "repositories[1]"); !ok || d.Rule != RuleDuplicateRepository {
+		t.Errorf("expected a duplicate-repository diagnostic, got %+v", diags)
+	}
+}
+
+func TestLintProjectFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	writeFile(t, path, strings.Replace(messyProjectYAML, "description: A valid test project\n", "", 1))
+
+	result, err := LintProjectFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Fixes) != 5 {
+		t.Errorf("fixes = %+v", result.Fixes)
+	}
+	if len(result.Remaining) != 1 || result.Remaining[0].Path != "description" {
+		t.Errorf("remaining = %+v, want the missing description", result.Remaining)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `schema_version: 1.0.0`) && !strings.Contains(string(data), `schema_version: "1.0.0"`) {
+		t.Errorf("file not rewritten:\n%s", data)
+	}
+
+	summary := FormatLintResults([]LintResult{result})
+	for _, want := range []string{"applied 5 fix(es)", "1 error(s) to fix by hand", "description is required", "5 fix(es) applied, 1 error(s) remaining"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+}
+
+func TestSchemaVersionPrefixFixHint(t *testing.T) {
+	p := validBaseProject()
+	p.SchemaVersion = "v1.0.0"
+	d, ok := findDiagnostic(ValidateProjectDiagnostics(p), "schema_version")
+	if !ok || !strings.Contains(d.Fix, `"1.0.0"`) {
+		t.Errorf("diagnostic = %+v, want a fix suggesting 1.0.0", d)
+	}
+}
+
+func TestFixSocialHandles(t *testing.T) {
+	content := validProjectYAML() + "social:\n  twitter: \"@testproject\"\n  discord: not a handle!\n  slack: https://test.slack.com\n"
+	out, fixes, err := FixProjectYAML([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fixes) != 1 || fixes[0].Path != "social.twitter" {
+		t.Fatalf("fixes = %+v, want only social.twitter", fixes)
+	}
+	if !strings.Contains(string(out), "https://twitter.com/testproject") || !strings.Contains(string(out), "discord: not a handle!") {
+		t.Errorf("fixed YAML:\n%s", out)
+	}
+}
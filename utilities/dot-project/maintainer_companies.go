@@ -0,0 +1,96 @@
+package projects
+
+import (
+	"fmt"
+	"net/mail"
+	"sort"
+	"strings"
+)
+
+// CompanyShare is the number of project maintainers employed by one company.
+type CompanyShare struct {
+	Company string `json:"company" yaml:"company"`
+	Count   int    `json:"count" yaml:"count"`
+}
+
+// MaintainerCompanies counts the companies of the project-maintainers team,
+// largest first. Members are counted once per handle; members without a
+// company are left out. Company names are grouped case-insensitively.
+func MaintainerCompanies(entry MaintainerEntry) []CompanyShare {
+	index := make(map[string]int)
+	seen := make(map[string]bool)
+	var shares []CompanyShare
+	for _, team := range entry.Teams {
+		if team.Name != "project-maintainers" {
+			continue
+		}
+		for _, m := range team.TeamMembers() {
+			handle := strings.ToLower(normalizeHandle(m.Handle))
+			company := strings.TrimSpace(m.Company)
+			if handle == "" || company == "" || seen[handle] {
+				continue
+			}
+			seen[handle] = true
+			key := strings.ToLower(company)
+			if i, ok := index[key]; ok {
+				shares[i].Count++
+				continue
+			}
+			index[key] = len(shares)
+			shares = append(shares, CompanyShare{Company: company, Count: 1})
+		}
+	}
+	sort.SliceStable(shares, func(i, j int) bool {
+		if shares[i].Count != shares[j].Count {
+			return shares[i].Count > shares[j].Count
+		}
+		return strings.ToLower(shares[i].Company) < strings.ToLower(shares[j].Company)
+	})
+	return shares
+}
+
+// companyDiversityDiagnostics reports, as info on the team at path, a
+// project-maintainers team whose maintainers with a listed company all work
+// for the same one. Graduation expects maintainers from at least two
+// organizations.
+func companyDiversityDiagnostics(path string, shares []CompanyShare) []Diagnostic {
+	if len(shares) != 1 || shares[0].Count < 2 {
+		return nil
+	}
+	return []Diagnostic{{
+		Path:     path,
+		Rule:     RuleCompanyDiversity,
+		Severity: SeverityInfo,
+		Message: fmt.Sprintf("all %d project-maintainers with a listed company work for %s; graduation expects maintainers from at least two organizations",
+			shares[0].Count, shares[0].Company),
+	}}
+}
+
+// memberEmailDiagnostics checks the email of each rich team member. Paths are
+// relative to the team's members list.
+func memberEmailDiagnostics(team Team) []Diagnostic {
+	var diags []Diagnostic
+	for i, m := range team.TeamMembers() {
+		if m.Email == "" {
+			continue
+		}
+		path := fmt.Sprintf("[%d].email", i)
+		addr, err := mail.ParseAddress(m.Email)
+		if err != nil {
+			diags = append(diags, errorDiag(path, RuleEmailFormat, "team '%s': %s email is not a valid email: %s", team.Name, m.Handle, m.Email))
+		} else if addr.Address != m.Email {
+			diags = append(diags, errorDiag(path, RuleEmailFormat, "team '%s': %s email must be a bare address, got: %s", team.Name, m.Handle, m.Email).
+				withFix("use %q", addr.Address))
+		}
+	}
+	return diags
+}
+
+// formatCompanyShares renders shares as "Google 3, Microsoft 1".
+func formatCompanyShares(shares []CompanyShare) string {
+	parts := make([]string, len(shares))
+	for i, s := range shares {
+		parts[i] = fmt.Sprintf("%s %d", s.Company, s.Count)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,112 @@
+package projects
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const richMaintainersYAML = `maintainers:
+  - project_id: test-project
+    teams:
+      - name: project-maintainers
+        members:
+          - alice
+          - handle: bob
+            name: Bob Smith
+            email: bob@example.com
+            company: Example Corp
+            role: lead
+`
+
+func TestTeamMemberYAMLForms(t *testing.T) {
+	var config MaintainersConfig
+	if err := yaml.Unmarshal([]byte(richMaintainersYAML), &config); err != nil {
+		t.Fatal(err)
+	}
+	team := config.Maintainers[0].Teams[0]
+	if !reflect.DeepEqual(team.Members, []string{"alice", "bob"}) {
+		t.Fatalf("members = %v, want the handles of both forms", team.Members)
+	}
+	got := team.TeamMembers()
+	want := []TeamMember{
+		{Handle: "alice"},
+		{Handle: "bob", Name: "Bob Smith", Email: "bob@example.com", Company: "Example Corp", Role: "lead"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("members = %+v, want %+v", got, want)
+	}
+
+	out, err := yaml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(out), "- alice\n") || !strings.Contains(string(out), "handle: bob") {
+		t.Errorf("handle-only members should marshal as plain strings:\n%s", out)
+	}
+
+	data, err := json.Marshal(team)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Team
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(back, team) || !strings.Contains(string(data), `"members":["alice",`) {
+		t.Errorf("JSON round trip = %s -> %+v", data, back)
+	}
+}
+
+func TestMaintainerCompanies(t *testing.T) {
+	entry := MaintainerEntry{Teams: []Team{
+		newTeam("project-maintainers",
+			TeamMember{Handle: "alice", Company: "Google"},
+			TeamMember{Handle: "bob", Company: "google "},
+			TeamMember{Handle: "@Alice", Company: "Google"},
+			TeamMember{Handle: "carol", Company: "Microsoft"},
+			TeamMember{Handle: "dave"},
+		),
+		newTeam("reviewers", TeamMember{Handle: "erin", Company: "Acme"}),
+	}}
+	want := []CompanyShare{{Company: "Google", Count: 2}, {Company: "Microsoft", Count: 1}}
+	if got := MaintainerCompanies(entry); !reflect.DeepEqual(got, want) {
+		t.Errorf("MaintainerCompanies = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidateMaintainerEntryRichMembers(t *testing.T) {
+	t.Setenv("LFX_AUTH_TOKEN", "")
+	t.Setenv("MAINTAINER_API_ENDPOINT", "")
+	pv := newTestValidator(t)
+
+	entry := MaintainerEntry{ProjectID: "proj", Teams: []Team{newTeam("project-maintainers",
+		TeamMember{Handle: "alice", Email: "not-an-email", Company: "Acme"},
+		TeamMember{Handle: "bob", Email: "Bob <bob@example.com>", Company: "ACME"},
+		TeamMember{Handle: "carol", Email: "carol@example.com"},
+	)}}
+	result := pv.validateMaintainerEntry(entry, false, nil)
+	if result.Valid {
+		t.Error("invalid emails should make the entry invalid")
+	}
+	if d, ok := findDiagnostic(result.Diagnostics, "teams[0].members[0].email"); !ok || d.Rule != RuleEmailFormat {
+		t.Errorf("bad email diagnostic = %+v", d)
+	}
+	if d, ok := findDiagnostic(result.Diagnostics, "teams[0].members[1].email"); !ok || !strings.Contains(d.Fix, `"bob@example.com"`) {
+		t.Errorf("display-name email diagnostic = %+v", d)
+	}
+	if _, ok := findDiagnostic(result.Diagnostics, "teams[0].members[2].email"); ok {
+		t.Error("a bare address should be accepted")
+	}
+	if d, ok := findDiagnostic(result.Diagnostics, "teams[0]"); !ok || d.Rule != RuleCompanyDiversity || d.Severity != SeverityInfo {
+		t.Errorf("company diversity diagnostic = %+v", d)
+	}
+
+	out := formatMaintainersText([]MaintainerValidationResult{result})
+	if !strings.Contains(out, "Company diversity (project-maintainers):\n  proj: Acme 2\n") {
+		t.Errorf("report missing company diversity:\n%s", out)
+	}
+}
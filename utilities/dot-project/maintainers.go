@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
-	"net/http"
 	"os"
 	"sort"
 	"strings"
@@ -33,9 +32,19 @@ func (pv *ProjectValidator) ValidateMaintainersFileWithExclusion(path string, ve
 		return nil, fmt.Errorf("maintainers file %s does not contain any entries", path)
 	}
 
+	// Parse a node tree alongside the typed config so diagnostics can be
+	// positioned; a failure here only costs line numbers.
+	var root yaml.Node
+	hasRoot := yaml.Unmarshal(data, &root) == nil
+
 	var results []MaintainerValidationResult
-	for _, entry := range config.Maintainers {
+	for i, entry := range config.Maintainers {
 		result := pv.validateMaintainerEntry(entry, verify, excludedHandles)
+		result.File = path
+		result.Diagnostics = prefixDiagnostics(result.Diagnostics, fmt.Sprintf("maintainers[%d]", i))
+		if hasRoot {
+			LocateDiagnostics(result.Diagnostics, &root)
+		}
 		results = append(results, result)
 	}
 
@@ -75,55 +84,92 @@ func (pv *ProjectValidator) validateMaintainerEntry(entry MaintainerEntry, verif
 		Org:       entry.Org,
 	}
 
+	var diags []Diagnostic
 	if entry.ProjectID == "" {
-		result.Errors = append(result.Errors, "project_id is required")
+		diags = append(diags, errorDiag("project_id", RuleRequired, "project_id is required"))
 	}
 
 	if len(entry.Teams) == 0 {
-		result.Errors = append(result.Errors, "teams list cannot be empty")
+		diags = append(diags, errorDiag("teams", RuleRequired, "teams list cannot be empty"))
 	}
 
 	hasProjectMaintainers := false
+	maintainersPath := "teams"
 	var allVerifiedHandles []string
 	allPassed := true
+	skippedVerification := false
 
-	for _, team := range entry.Teams {
+	for i, team := range entry.Teams {
+		teamPath := fmt.Sprintf("teams[%d]", i)
 		if team.Name == "project-maintainers" {
 			hasProjectMaintainers = true
+			maintainersPath = teamPath
 			if len(team.Members) == 0 {
-				result.Errors = append(result.Errors, "team 'project-maintainers' cannot be empty")
+				diags = append(diags, errorDiag(teamPath+".members", RuleRequired, "team 'project-maintainers' cannot be empty"))
 			}
 		}
 
-		cleanHandles, duplicateErrors := normalizeHandles(team.Members)
-		if len(duplicateErrors) > 0 {
-			for _, err := range duplicateErrors {
-				result.Errors = append(result.Errors, fmt.Sprintf("team '%s': %s", team.Name, err))
-			}
+		cleanHandles, handleDiags := normalizeHandleDiagnostics(team.Members)
+		for _, d := range prefixDiagnostics(handleDiags, teamPath+".members") {
+			d.Message = fmt.Sprintf("team '%s': %s", team.Name, d.Message)
+			diags = append(diags, d)
 		}
+		diags = append(diags, prefixDiagnostics(memberEmailDiagnostics(team), teamPath+".members")...)
 
 		if verify && len(cleanHandles) > 0 {
+			verifier := pv.handleVerifier()
+			if verifier == nil {
+				skippedVerification = true
+				continue
+			}
 			result.VerificationAttempted = true
+			var toVerify []string
 			for _, handle := range cleanHandles {
-				if excludedHandles != nil && excludedHandles[strings.ToLower(handle)] {
-					continue
+				if !excludedHandles[strings.ToLower(handle)] {
+					toVerify = append(toVerify, handle)
 				}
-				if err := pv.verifyHandleWithExternalService(entry.ProjectID, handle); err != nil {
-					result.Errors = append(result.Errors, fmt.Sprintf("verification failed for %s (team %s): %v", handle, team.Name, err))
+			}
+			for _, c := range verifyHandles(verifier, toVerify) {
+				switch {
+				case c.err != nil:
+					// An unavailable service must not fail every PR.
+					diags = append(diags, Diagnostic{
+						Path:     teamPath + ".members",
+						Rule:     RuleHandleVerification,
+						Severity: SeverityWarning,
+						Message:  fmt.Sprintf("could not verify %s (team %s): %v", c.handle, team.Name, c.err),
+					})
+					result.UnverifiedHandles = append(result.UnverifiedHandles, c.handle)
+				case !c.found:
+					diags = append(diags, errorDiag(teamPath+".members", RuleHandleVerification, "verification failed for %s (team %s): handle not found", c.handle, team.Name).
+						withFix("check the spelling of the GitHub handle"))
 					allPassed = false
-				} else {
-					allVerifiedHandles = append(allVerifiedHandles, handle)
+				default:
+					allVerifiedHandles = append(allVerifiedHandles, c.handle)
 				}
 			}
 		}
 	}
 
+	if skippedVerification {
+		log.Printf("[maintainers] Skipping handle verification for %s (set LFX_AUTH_TOKEN or configure a verifier)", entry.ProjectID)
+	}
+
 	if !hasProjectMaintainers {
-		result.Errors = append(result.Errors, "team 'project-maintainers' is required")
+		diags = append(diags, errorDiag("teams", RuleRequiredTeam, "team 'project-maintainers' is required").
+			withFix("add a team named project-maintainers listing the maintainers' GitHub handles"))
 	}
 
+	result.Companies = MaintainerCompanies(entry)
+	diags = append(diags, companyDiversityDiagnostics(maintainersPath, result.Companies)...)
+
+	result.Diagnostics = diags
+	result.Errors = diagnosticMessages(diags, SeverityError)
+
 	if result.VerificationAttempted {
-		result.VerificationPassed = allPassed && len(result.Errors) == 0
+		// Handles the verifier could not check do not fail the entry, but
+		// they are not verified either.
+		result.VerificationPassed = allPassed && len(result.Errors) == 0 && len(result.UnverifiedHandles) == 0
 		result.VerifiedHandles = allVerifiedHandles
 	}
 
@@ -132,50 +178,36 @@ func (pv *ProjectValidator) validateMaintainerEntry(entry MaintainerEntry, verif
 }
 
 func normalizeHandles(handles []string) ([]string, []string) {
+	cleaned, diags := normalizeHandleDiagnostics(handles)
+	return cleaned, diagnosticMessages(diags, SeverityError)
+}
+
+// normalizeHandleDiagnostics trims, de-prefixes and de-duplicates handles,
+// reporting problems as diagnostics with index paths ("[2]") relative to the
+// handle list.
+func normalizeHandleDiagnostics(handles []string) ([]string, []Diagnostic) {
 	seen := make(map[string]bool)
 	var cleaned []string
-	var errors []string
+	var diags []Diagnostic
 	for i, h := range handles {
+		path := fmt.Sprintf("[%d]", i)
 		trimmed := strings.TrimSpace(h)
 		if trimmed == "" {
-			errors = append(errors, fmt.Sprintf("handles[%d] cannot be empty", i))
+			diags = append(diags, errorDiag(path, RuleNonEmpty, "handles[%d] cannot be empty", i))
 			continue
 		}
 		trimmed = strings.TrimPrefix(trimmed, "@")
 		key := strings.ToLower(trimmed)
 		if seen[key] {
-			errors = append(errors, fmt.Sprintf("duplicate handle detected: %s", trimmed))
+			diags = append(diags, errorDiag(path, RuleDuplicateHandle, "duplicate handle detected: %s", trimmed).
+				withFix("remove the duplicate entry"))
 			continue
 		}
 		seen[key] = true
 		cleaned = append(cleaned, trimmed)
 	}
 	sort.Strings(cleaned)
-	return cleaned, errors
-}
-
-func (pv *ProjectValidator) verifyHandleWithExternalService(projectID, handle string) error {
-	// If LFX_AUTH_TOKEN is set, perform LFX validation
-	if os.Getenv("LFX_AUTH_TOKEN") != "" {
-		if !checkMaintainerInLFX(handle) {
-			return fmt.Errorf("handle %s not found in LFX", handle)
-		}
-		return nil
-	}
-
-	endpoint := os.Getenv("MAINTAINER_API_ENDPOINT")
-	if endpoint == "" {
-		log.Printf("[maintainers] Skipping external verification for %s (no MAINTAINER_API_ENDPOINT set)", handle)
-		return nil
-	}
-
-	log.Printf("[maintainers] Stub verifying handle %s for project %s via %s", handle, projectID, endpoint)
-
-	if strings.EqualFold(os.Getenv("MAINTAINER_API_STUB"), "fail") {
-		return fmt.Errorf("stubbed failure for handle %s", handle)
-	}
-
-	return nil
+	return cleaned, diags
 }
 
 // FormatMaintainersResults formats maintainer validation results
@@ -193,6 +225,10 @@ func (pv *ProjectValidator) FormatMaintainersResults(results []MaintainerValidat
 			return "", err
 		}
 		return string(data), nil
+	case "sarif":
+		return FormatSARIF(nil, results)
+	case "github":
+		return formatGitHubAnnotations(maintainerAnnotatedFiles(results), "maintainer entries"), nil
 	default:
 		return formatMaintainersText(results), nil
 	}
@@ -205,62 +241,41 @@ func formatMaintainersText(results []MaintainerValidationResult) string {
 
 	var invalidCount int
 	for _, result := range results {
+		diags := reportDiagnostics(result.Errors, result.Diagnostics)
+		if result.Valid && len(diags) == 0 {
+			continue
+		}
 		if !result.Valid {
 			invalidCount++
-			b.WriteString(fmt.Sprintf("INVALID: %s", result.ProjectID))
-			b.WriteString("\n")
-			for _, err := range result.Errors {
-				b.WriteString(fmt.Sprintf("  - %s\n", err))
+			b.WriteString(fmt.Sprintf("INVALID: %s\n", result.ProjectID))
+		} else {
+			label := "NOTES"
+			for _, d := range diags {
+				if d.Severity == SeverityWarning {
+					label = "WARNINGS"
+					break
+				}
 			}
-			b.WriteString("\n")
+			b.WriteString(fmt.Sprintf("%s: %s\n", label, result.ProjectID))
 		}
+		for _, d := range diags {
+			b.WriteString(formatDiagnosticLine(d))
+		}
+		b.WriteString("\n")
 	}
 
-	b.WriteString(fmt.Sprintf("Summary: %d maintainer entries validated, %d with issues\n", len(results), invalidCount))
-	return b.String()
-}
-
-func checkMaintainerInLFX(handle string) bool {
-	token := os.Getenv("LFX_AUTH_TOKEN")
-	if token == "" {
-		log.Printf("LFX_AUTH_TOKEN environment variable is not set")
-		return false
-	}
-	apiURL := "https://api-gw.platform.linuxfoundation.org/user-service/v1/users/search"
-
-	req, err := http.NewRequest("GET", apiURL, nil)
-	if err != nil {
-		log.Printf("Error creating request: %v", err)
-		return false
-	}
-
-	q := req.URL.Query()
-	q.Add("githubID", handle)
-	req.URL.RawQuery = q.Encode()
-
-	req.Header.Add("Authorization", "Bearer "+token)
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		log.Printf("Error making request to LFX: %v", err)
-		return false
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		log.Printf("LFX API returned status: %d", resp.StatusCode)
-		return false
-	}
-
-	var result struct {
-		Data []interface{} `json:"data"`
+	var diversity strings.Builder
+	for _, result := range results {
+		if len(result.Companies) > 0 {
+			diversity.WriteString(fmt.Sprintf("  %s: %s\n", result.ProjectID, formatCompanyShares(result.Companies)))
+		}
 	}
-
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		log.Printf("Error decoding response: %v", err)
-		return false
+	if diversity.Len() > 0 {
+		b.WriteString("Company diversity (project-maintainers):\n")
+		b.WriteString(diversity.String())
+		b.WriteString("\n")
 	}
 
-	return len(result.Data) > 0
+	b.WriteString(fmt.Sprintf("Summary: %d maintainer entries validated, %d with issues\n", len(results), invalidCount))
+	return b.String()
 }
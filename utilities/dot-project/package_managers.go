@@ -0,0 +1,552 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// package_managers keys are normalized to the registry names bootstrap
+// already emits (the values of packageManagerManifests and
+// packageManagerURLPatterns): docker, npm, pypi, cargo, go, helm, maven,
+// gradle and rubygems. Other keys are allowed (e.g. fedora) but get neither
+// syntax checks nor live lookups.
+
+// packageRegistryAlias maps a non-canonical key to its canonical registry.
+// Host is the container registry the key implies, prefixed to identifiers
+// that do not name one (ghcr: org/image -> ghcr.io/org/image).
+type packageRegistryAlias struct {
+	Registry string
+	Host     string
+}
+
+var packageRegistryAliases = map[string]packageRegistryAlias{
+	"dockerhub":       {Registry: "docker"},
+	"docker-hub":      {Registry: "docker"},
+	"oci":             {Registry: "docker"},
+	"container":       {Registry: "docker"},
+	"containers":      {Registry: "docker"},
+	"ghcr":            {Registry: "docker", Host: "ghcr.io"},
+	"ghcr.io":         {Registry: "docker", Host: "ghcr.io"},
+	"quay":            {Registry: "docker", Host: "quay.io"},
+	"quay.io":         {Registry: "docker", Host: "quay.io"},
+	"ecr":             {Registry: "docker", Host: "public.ecr.aws"},
+	"gallery.ecr.aws": {Registry: "docker", Host: "public.ecr.aws"},
+	"npmjs":           {Registry: "npm"},
+	"node":            {Registry: "npm"},
+	"pip":             {Registry: "pypi"},
+	"python":          {Registry: "pypi"},
+	"crates":          {Registry: "cargo"},
+	"crates.io":       {Registry: "cargo"},
+	"rust":            {Registry: "cargo"},
+	"golang":          {Registry: "go"},
+	"gomod":           {Registry: "go"},
+	"artifacthub":     {Registry: "helm"},
+	"helm-chart":      {Registry: "helm"},
+	"mvn":             {Registry: "maven"},
+	"maven-central":   {Registry: "maven"},
+	"gem":             {Registry: "rubygems"},
+	"ruby":            {Registry: "rubygems"},
+}
+
+// knownPackageRegistries returns the canonical registry names: every
+// registry bootstrap can detect from a manifest or a package URL.
+func knownPackageRegistries() map[string]bool {
+	known := make(map[string]bool)
+	for _, registry := range packageManagerManifests {
+		known[registry] = true
+	}
+	for _, p := range packageManagerURLPatterns {
+		known[p.registry] = true
+	}
+	return known
+}
+
+// CanonicalPackageRegistry returns the canonical name for a package_managers
+// key and whether the registry is known. Matching is case-insensitive.
+func CanonicalPackageRegistry(key string) (string, bool) {
+	k := strings.ToLower(strings.TrimSpace(key))
+	if alias, ok := packageRegistryAliases[k]; ok {
+		return alias.Registry, true
+	}
+	return k, knownPackageRegistries()[k]
+}
+
+// canonicalPackageIdentifier rewrites an identifier given under key to its
+// form under the canonical registry.
+func canonicalPackageIdentifier(key, id string) string {
+	id = strings.TrimSpace(id)
+	alias, ok := packageRegistryAliases[strings.ToLower(strings.TrimSpace(key))]
+	if !ok || alias.Host == "" {
+		return id
+	}
+	if domain, _ := splitImageDomain(id); domain == "" {
+		return alias.Host + "/" + id
+	}
+	return id
+}
+
+// NormalizePackageManagers returns package_managers with every key mapped to
+// its canonical registry and identifiers rewritten accordingly. Identifiers
+// from keys that share a registry are merged, without duplicates.
+func NormalizePackageManagers(pm map[string]StringOrSlice) map[string]StringOrSlice {
+	if pm == nil {
+		return nil
+	}
+	out := make(map[string]StringOrSlice, len(pm))
+	for _, key := range sortedKeys(pm) {
+		registry, _ := CanonicalPackageRegistry(key)
+		for _, id := range pm[key] {
+			id = canonicalPackageIdentifier(key, id)
+			if id == "" || containsString(out[registry], id) {
+				continue
+			}
+			out[registry] = append(out[registry], id)
+		}
+	}
+	return out
+}
+
+var (
+	// Docker/OCI image references: [domain/]path[:tag][@digest].
+	imagePathComponentPattern = regexp.MustCompile(`^[a-z0-9]+(?:(?:[._]|__|-+)[a-z0-9]+)*$`)
+	imageTagPattern           = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9_.-]{0,127}$`)
+	imageDigestPattern        = regexp.MustCompile(`^[a-z0-9]+(?:[.+_-][a-z0-9]+)*:[a-fA-F0-9]{32,}$`)
+	imageDomainPattern        = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)(?:\.[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?)*(?::[0-9]+)?$`)
+
+	npmNamePattern      = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+	pypiNamePattern     = regexp.MustCompile(`^(?:[A-Za-z0-9]|[A-Za-z0-9][A-Za-z0-9._-]*[A-Za-z0-9])$`)
+	cargoNamePattern    = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]{0,63}$`)
+	goPathElemPattern   = regexp.MustCompile(`^[A-Za-z0-9._~-]+$`)
+	helmNamePattern     = regexp.MustCompile(`^[a-z0-9](?:[a-z0-9._-]*[a-z0-9])?$`)
+	mavenPartPattern    = regexp.MustCompile(`^[A-Za-z0-9_.-]+$`)
+	rubygemsNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+)
+
+// packageIdentifierCheckers validates identifier syntax per canonical
+// registry.
+var packageIdentifierCheckers = map[string]func(id string) error{
+	"docker":   checkImageReference,
+	"npm":      checkNPMName,
+	"pypi":     checkPyPIName,
+	"cargo":    checkCargoName,
+	"go":       checkGoModulePath,
+	"helm":     checkHelmChart,
+	"maven":    checkMavenCoordinates,
+	"gradle":   checkMavenCoordinates,
+	"rubygems": checkRubyGemName,
+}
+
+// ValidatePackageIdentifier checks id against the identifier syntax of the
+// registry named by key (canonical or alias). Unknown registries are not
+// checked.
+func ValidatePackageIdentifier(key, id string) error {
+	registry, _ := CanonicalPackageRegistry(key)
+	check, ok := packageIdentifierCheckers[registry]
+	if !ok {
+		return nil
+	}
+	return check(canonicalPackageIdentifier(key, id))
+}
+
+// splitImageDomain splits an image reference into its registry domain (""
+// for Docker Hub) and the remainder. As in the Docker CLI, the first path
+// element is a domain when it contains '.' or ':' or is localhost.
+func splitImageDomain(ref string) (domain, rest string) {
+	first, remainder, found := strings.Cut(ref, "/")
+	if found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return first, remainder
+	}
+	return "", ref
+}
+
+// parseImageReference splits ref into domain, repository path, tag and
+// digest.
+func parseImageReference(ref string) (domain, path, tag, digest string) {
+	domain, rest := splitImageDomain(ref)
+	if at := strings.Index(rest, "@"); at >= 0 {
+		rest, digest = rest[:at], rest[at+1:]
+	}
+	if colon := strings.LastIndex(rest, ":"); colon >= 0 && !strings.Contains(rest[colon:], "/") {
+		rest, tag = rest[:colon], rest[colon+1:]
+	}
+	return domain, rest, tag, digest
+}
+
+func checkImageReference(id string) error {
+	domain, path, tag, digest := parseImageReference(id)
+	if domain != "" && !imageDomainPattern.MatchString(domain) {
+		return fmt.Errorf("invalid registry host %q", domain)
+	}
+	if path == "" {
+		return fmt.Errorf("image name is missing")
+	}
+	for _, c := range strings.Split(path, "/") {
+		if !imagePathComponentPattern.MatchString(c) {
+			return fmt.Errorf("invalid image path component %q (lowercase letters, digits and . _ - separators)", c)
+		}
+	}
+	if tag != "" && !imageTagPattern.MatchString(tag) {
+		return fmt.Errorf("invalid tag %q", tag)
+	}
+	if digest != "" && !imageDigestPattern.MatchString(digest) {
+		return fmt.Errorf("invalid digest %q", digest)
+	}
+	return nil
+}
+
+func checkNPMName(id string) error {
+	if len(id) > 214 {
+		return fmt.Errorf("npm package names are at most 214 characters")
+	}
+	if !npmNamePattern.MatchString(id) {
+		return fmt.Errorf("not a valid npm package name (lowercase, optionally @scope/name)")
+	}
+	return nil
+}
+
+func checkPyPIName(id string) error {
+	if !pypiNamePattern.MatchString(id) {
+		return fmt.Errorf("not a valid PyPI project name (letters, digits, '.', '_' and '-', starting and ending alphanumeric)")
+	}
+	return nil
+}
+
+func checkCargoName(id string) error {
+	if !cargoNamePattern.MatchString(id) {
+		return fmt.Errorf("not a valid crate name (starts with a letter; letters, digits, '_' and '-'; at most 64 characters)")
+	}
+	return nil
+}
+
+func checkGoModulePath(id string) error {
+	path, _, _ := strings.Cut(id, "@")
+	if path == "" || strings.HasPrefix(path, "/") || strings.HasSuffix(path, "/") {
+		return fmt.Errorf("not a valid Go module path")
+	}
+	elems := strings.Split(path, "/")
+	for _, e := range elems {
+		if e == "" || e == "." || e == ".." || !goPathElemPattern.MatchString(e) {
+			return fmt.Errorf("invalid Go module path element %q", e)
+		}
+	}
+	if !strings.Contains(elems[0], ".") {
+		return fmt.Errorf("Go module paths start with a domain name (e.g. github.com/org/repo), got %q", elems[0])
+	}
+	return nil
+}
+
+// checkHelmChart accepts an Artifact Hub repo/chart pair, a bare chart name,
+// or an oci:// chart reference.
+func checkHelmChart(id string) error {
+	if ref, ok := strings.CutPrefix(id, "oci://"); ok {
+		return checkImageReference(ref)
+	}
+	parts := strings.Split(id, "/")
+	if len(parts) > 2 {
+		return fmt.Errorf("expected an Artifact Hub repo/chart, a chart name or an oci:// reference")
+	}
+	for _, p := range parts {
+		if !helmNamePattern.MatchString(p) {
+			return fmt.Errorf("invalid chart or repository name %q", p)
+		}
+	}
+	return nil
+}
+
+// checkMavenCoordinates accepts groupId:artifactId[:version] and the
+// groupId/artifactId form bootstrap extracts from search.maven.org URLs.
+func checkMavenCoordinates(id string) error {
+	group, artifact, _ := splitMavenCoordinates(id)
+	if group == "" || artifact == "" {
+		return fmt.Errorf("expected groupId:artifactId")
+	}
+	for _, p := range []string{group, artifact} {
+		if !mavenPartPattern.MatchString(p) {
+			return fmt.Errorf("invalid Maven coordinate part %q", p)
+		}
+	}
+	return nil
+}
+
+func splitMavenCoordinates(id string) (group, artifact, version string) {
+	sep := ":"
+	if !strings.Contains(id, ":") {
+		sep = "/"
+	}
+	parts := strings.Split(id, sep)
+	if len(parts) < 2 || len(parts) > 3 {
+		return "", "", ""
+	}
+	if len(parts) == 3 {
+		version = parts[2]
+	}
+	return parts[0], parts[1], version
+}
+
+func checkRubyGemName(id string) error {
+	if !rubygemsNamePattern.MatchString(id) {
+		return fmt.Errorf("not a valid gem name")
+	}
+	return nil
+}
+
+// packageManagerDiagnostics checks package_managers keys and identifier
+// syntax. Unknown registries (homebrew, fedora, ...) are noted as info and
+// alias keys are warnings; malformed identifiers for a known registry are
+// errors.
+func packageManagerDiagnostics(pm map[string]StringOrSlice) []Diagnostic {
+	var diags []Diagnostic
+	for _, key := range sortedKeys(pm) {
+		registry, known := CanonicalPackageRegistry(key)
+		switch {
+		case !known:
+			diags = append(diags, Diagnostic{
+				Path:     "package_managers." + key,
+				Rule:     RulePackageRegistry,
+				Severity: SeverityInfo,
+				Message:  fmt.Sprintf("package_managers.%s is not a registry the validator knows; its identifiers are not checked", key),
+				Fix:      fmt.Sprintf("known registries: %s", strings.Join(sortedKeys(knownPackageRegistries()), ", ")),
+			})
+		case registry != key:
+			d := Diagnostic{
+				Path:     "package_managers." + key,
+				Rule:     RulePackageRegistry,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("package_managers.%s should use the canonical registry key %q", key, registry),
+			}
+			var ids []string
+			for _, id := range pm[key] {
+				ids = append(ids, fmt.Sprintf("%q", canonicalPackageIdentifier(key, id)))
+			}
+			diags = append(diags, d.withFix("use %s: %s", registry, strings.Join(ids, ", ")))
+		}
+
+		for j, id := range pm[key] {
+			if strings.TrimSpace(id) == "" {
+				continue // reported by the non-empty check
+			}
+			if err := ValidatePackageIdentifier(key, id); err != nil {
+				diags = append(diags, errorDiag(fmt.Sprintf("package_managers.%s[%d]", key, j), RulePackageIdentifier,
+					"package_managers.%s[%d] %q is not a valid %s identifier: %v", key, j, id, registry, err))
+			}
+		}
+	}
+	return diags
+}
+
+// PackageLookup is the outcome of resolving an identifier in its registry.
+type PackageLookup int
+
+const (
+	PackageFound     PackageLookup = iota
+	PackageNotFound                // The registry answered that the package does not exist
+	PackageUnchecked               // No live lookup for this registry or identifier form
+)
+
+// defaultPackageRegistryURLs are the registry endpoints PackageResolver
+// queries, keyed by the names accepted in PackageResolver.BaseURLs.
+var defaultPackageRegistryURLs = map[string]string{
+	"dockerhub": "https://hub.docker.com",
+	"ghcr":      "https://ghcr.io",
+	"quay":      "https://quay.io",
+	"npm":       "https://registry.npmjs.org",
+	"pypi":      "https://pypi.org",
+	"cargo":     "https://crates.io",
+	"go":        "https://proxy.golang.org",
+	"helm":      "https://artifacthub.io",
+	"maven":     "https://repo1.maven.org/maven2",
+	"rubygems":  "https://rubygems.org",
+}
+
+// PackageResolver checks that package_managers identifiers exist in their
+// registries. BaseURLs overrides registry endpoints by name (dockerhub,
+// ghcr, quay, npm, pypi, cargo, go, helm, maven, rubygems), e.g. to point
+// them at an httptest server.
+type PackageResolver struct {
+	Client   *http.Client
+	BaseURLs map[string]string
+}
+
+// SetPackageResolver enables live registry lookups for package_managers
+// identifiers. Lookups are off by default; nil disables them again.
+func (pv *ProjectValidator) SetPackageResolver(r *PackageResolver) {
+	pv.packages = r
+}
+
+func (r *PackageResolver) baseURL(name string) string {
+	if u := r.BaseURLs[name]; u != "" {
+		return strings.TrimRight(u, "/")
+	}
+	return defaultPackageRegistryURLs[name]
+}
+
+// Resolve looks up id in the registry named by key. An error means the
+// registry could not be asked (network failure, rate limiting, unexpected
+// status), not that the package is missing.
+func (r *PackageResolver) Resolve(key, id string) (PackageLookup, error) {
+	registry, _ := CanonicalPackageRegistry(key)
+	id = canonicalPackageIdentifier(key, id)
+	if ValidatePackageIdentifier(registry, id) != nil {
+		return PackageUnchecked, nil
+	}
+
+	switch registry {
+	case "docker":
+		return r.resolveImage(id)
+	case "npm":
+		return r.lookup(r.baseURL("npm") + "/" + url.PathEscape(id))
+	case "pypi":
+		return r.lookup(r.baseURL("pypi") + "/pypi/" + url.PathEscape(id) + "/json")
+	case "cargo":
+		return r.lookup(r.baseURL("cargo") + "/api/v1/crates/" + url.PathEscape(id))
+	case "go":
+		path, _, _ := strings.Cut(id, "@")
+		return r.lookup(r.baseURL("go") + "/" + escapeGoModulePath(path) + "/@latest")
+	case "helm":
+		parts := strings.Split(id, "/")
+		if len(parts) != 2 {
+			return PackageUnchecked, nil // Artifact Hub needs repo/chart
+		}
+		return r.lookup(r.baseURL("helm") + "/api/v1/packages/helm/" + parts[0] + "/" + parts[1])
+	case "maven", "gradle":
+		group, artifact, _ := splitMavenCoordinates(id)
+		return r.lookup(r.baseURL("maven") + "/" + strings.ReplaceAll(group, ".", "/") + "/" + artifact + "/maven-metadata.xml")
+	case "rubygems":
+		return r.lookup(r.baseURL("rubygems") + "/api/v1/gems/" + url.PathEscape(id) + ".json")
+	}
+	return PackageUnchecked, nil
+}
+
+// resolveImage checks images on Docker Hub, GHCR and Quay. Other registries
+// need per-registry credentials and are not checked.
+func (r *PackageResolver) resolveImage(ref string) (PackageLookup, error) {
+	domain, path, _, _ := parseImageReference(ref)
+	switch domain {
+	case "", "docker.io", "index.docker.io", "registry-1.docker.io":
+		if !strings.Contains(path, "/") {
+			path = "library/" + path
+		}
+		return r.lookup(r.baseURL("dockerhub") + "/v2/repositories/" + path + "/")
+	case "quay.io":
+		return r.lookup(r.baseURL("quay") + "/api/v1/repository/" + path)
+	case "ghcr.io":
+		return r.resolveGHCR(path)
+	}
+	return PackageUnchecked, nil
+}
+
+// resolveGHCR asks GHCR for an anonymous pull token, then lists the image's
+// tags. Private and missing packages look the same to an anonymous client
+// and are both reported as not found.
+func (r *PackageResolver) resolveGHCR(path string) (PackageLookup, error) {
+	base := r.baseURL("ghcr")
+	resp, err := r.get(base+"/token?scope="+url.QueryEscape("repository:"+path+":pull"), "")
+	if err != nil {
+		return PackageUnchecked, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return PackageNotFound, nil
+	default:
+		return PackageUnchecked, fmt.Errorf("GHCR token request returned HTTP %d", resp.StatusCode)
+	}
+	var token struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return PackageUnchecked, fmt.Errorf("parsing GHCR token response: %w", err)
+	}
+
+	tags, err := r.get(base+"/v2/"+path+"/tags/list", token.Token)
+	if err != nil {
+		return PackageUnchecked, err
+	}
+	defer tags.Body.Close()
+	return lookupFromStatus(tags)
+}
+
+// lookup fetches u and maps the status to a lookup result. Registries
+// differ in HEAD support, so this uses GET and discards the body.
+func (r *PackageResolver) lookup(u string) (PackageLookup, error) {
+	resp, err := r.get(u, "")
+	if err != nil {
+		return PackageUnchecked, err
+	}
+	defer resp.Body.Close()
+	return lookupFromStatus(resp)
+}
+
+func (r *PackageResolver) get(u, bearer string) (*http.Response, error) {
+	client := r.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", bootstrapUserAgent) // crates.io rejects requests without one
+	if bearer != "" {
+		req.Header.Set("Authorization", "Bearer "+bearer)
+	}
+	return client.Do(req)
+}
+
+func lookupFromStatus(resp *http.Response) (PackageLookup, error) {
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return PackageFound, nil
+	case http.StatusNotFound, http.StatusGone:
+		return PackageNotFound, nil
+	default:
+		return PackageUnchecked, fmt.Errorf("%s returned HTTP %d%s", resp.Request.URL.Host, resp.StatusCode, rateLimitHint(resp))
+	}
+}
+
+// escapeGoModulePath applies the module proxy's case encoding: each
+// uppercase letter becomes '!' followed by its lowercase form.
+func escapeGoModulePath(path string) string {
+	var b strings.Builder
+	for _, c := range path {
+		if 'A' <= c && c <= 'Z' {
+			b.WriteByte('!')
+			c += 'a' - 'A'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// Diagnostics resolves every well-formed identifier in the project's
+// package_managers. Missing packages are errors; registries that could not
+// be asked produce warnings so an outage never fails validation.
+func (r *PackageResolver) Diagnostics(project Project) []Diagnostic {
+	var diags []Diagnostic
+	for _, key := range sortedKeys(project.PackageManagers) {
+		for j, id := range project.PackageManagers[key] {
+			path := fmt.Sprintf("package_managers.%s[%d]", key, j)
+			lookup, err := r.Resolve(key, id)
+			switch {
+			case err != nil:
+				diags = append(diags, Diagnostic{
+					Path:     path,
+					Rule:     RulePackageNotFound,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("could not check %s in the %s registry: %v", id, key, err),
+				})
+			case lookup == PackageNotFound:
+				diags = append(diags, errorDiag(path, RulePackageNotFound, "%s %q was not found in the registry", key, id).
+					withFix("check the identifier, or remove it if the package is no longer published"))
+			}
+		}
+	}
+	return diags
+}
@@ -0,0 +1,243 @@
+package projects
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCanonicalPackageRegistry(t *testing.T) {
+	tests := []struct {
+		key   string
+		want  string
+		known bool
+	}{
+		{"docker", "docker", true},
+		{"GHCR", "docker", true},
+		{"quay", "docker", true},
+		{"crates", "cargo", true},
+		{"artifacthub", "helm", true},
+		{"pip", "pypi", true},
+		{"gradle", "gradle", true},
+		{"fedora", "fedora", false},
+	}
+	for _, tt := range tests {
+		got, known := CanonicalPackageRegistry(tt.key)
+		if got != tt.want || known != tt.known {
+			t.Errorf("CanonicalPackageRegistry(%q) = %q, %v; want %q, %v", tt.key, got, known, tt.want, tt.known)
+		}
+	}
+}
+
+// Every registry bootstrap can emit must have a syntax checker, so scaffolded
+// files are always checked.
+func TestKnownPackageRegistriesHaveCheckers(t *testing.T) {
+	for registry := range knownPackageRegistries() {
+		if _, ok := packageIdentifierCheckers[registry]; !ok {
+			t.Errorf("no identifier checker for registry %q", registry)
+		}
+	}
+}
+
+func TestValidatePackageIdentifier(t *testing.T) {
+	tests := []struct {
+		key, id string
+		valid   bool
+	}{
+		{"docker", "nginx", true},
+		{"docker", "prom/prometheus:v2.50.0", true},
+		{"docker", "registry.k8s.io/kube-apiserver", true},
+		{"docker", "localhost:5000/team/app@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef", true},
+		{"docker", "Prom/Prometheus", false},
+		{"docker", "ghcr.io/", false},
+		{"ghcr", "fluxcd/flux-cli", true},
+		{"npm", "@backstage/core", true},
+		{"npm", "Express", false},
+		{"pypi", "kubernetes_asyncio", true},
+		{"pypi", "-bad", false},
+		{"crates", "kube-rs", true},
+		{"cargo", "1password", false},
+		{"go", "github.com/prometheus/client_golang", true},
+		{"go", "k8s.io/client-go@v0.30.0", true},
+		{"go", "client_golang", false},
+		{"helm", "prometheus-community/prometheus", true},
+		{"helm", "argo-cd", true},
+		{"helm", "oci://ghcr.io/argoproj/argo-helm/argo-cd", true},
+		{"artifacthub", "a/b/c", false},
+		{"maven", "io.grpc:grpc-core", true},
+		{"maven", "io.grpc/grpc-core", true},
+		{"maven", "grpc-core", false},
+		{"homebrew", "anything at all", true},
+	}
+	for _, tt := range tests {
+		err := ValidatePackageIdentifier(tt.key, tt.id)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidatePackageIdentifier(%q, %q) = %v, want valid=%v", tt.key, tt.id, err, tt.valid)
+		}
+	}
+}
+
+func TestNormalizePackageManagers(t *testing.T) {
+	got := NormalizePackageManagers(map[string]StringOrSlice{
+		"docker":   {"ghcr.io/fluxcd/flux"},
+		"ghcr":     {"fluxcd/flux", "fluxcd/helm-controller"},
+		"crates":   {"flux"},
+		"homebrew": {"flux"},
+	})
+	want := map[string]StringOrSlice{
+		"cargo":    {"flux"},
+		"docker":   {"ghcr.io/fluxcd/flux", "ghcr.io/fluxcd/helm-controller"},
+		"homebrew": {"flux"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizePackageManagers = %v, want %v", got, want)
+	}
+}
+
+func TestPackageManagerDiagnostics(t *testing.T) {
+	p := validBaseProject()
+	p.PackageManagers = map[string]StringOrSlice{
+		"ghcr":     {"fluxcd/flux"},
+		"npm":      {"Bad Name"},
+		"homebrew": {"flux"},
+	}
+	diags := ValidateProjectDiagnostics(p)
+
+	if d, ok := findDiagnostic(diags, "package_managers.ghcr"); !ok || d.Severity != SeverityWarning || !strings.Contains(d.Fix, `use docker: "ghcr.io/fluxcd/flux"`) {
+		t.Errorf("alias diagnostic = %+v", d)
+	}
+	if d, ok := findDiagnostic(diags, "package_managers.npm[0]"); !ok || d.Rule != RulePackageIdentifier || d.Severity != SeverityError {
+		t.Errorf("identifier diagnostic = %+v", d)
+	}
+	if d, ok := findDiagnostic(diags, "package_managers.homebrew"); !ok || d.Severity != SeverityInfo {
+		t.Errorf("unknown registry diagnostic = %+v", d)
+	}
+}
+
+// newFakeRegistries serves the endpoints PackageResolver queries. Paths in
+// exists answer 200, paths containing "unavailable" 503, and the rest 404.
+func newFakeRegistries(t *testing.T, exists ...string) *httptest.Server {
+	t.Helper()
+	found := make(map[string]bool)
+	for _, p := range exists {
+		found[p] = true
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			w.Write([]byte(`{"token":"anon"}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v2/") && strings.HasSuffix(r.URL.Path, "/tags/list") && r.Header.Get("Authorization") != "Bearer anon" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if strings.Contains(r.URL.Path, "unavailable") {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if found[r.URL.EscapedPath()] {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+}
+
+func newTestPackageResolver(srv *httptest.Server) *PackageResolver {
+	bases := make(map[string]string)
+	for name := range defaultPackageRegistryURLs {
+		bases[name] = srv.URL
+	}
+	return &PackageResolver{Client: srv.Client(), BaseURLs: bases}
+}
+
+func TestPackageResolverResolve(t *testing.T) {
+	srv := newFakeRegistries(t,
+		"/v2/repositories/library/nginx/",
+		"/v2/fluxcd/flux/tags/list",
+		"/api/v1/repository/prometheus/node-exporter",
+		"/@backstage%2Fcore",
+		"/pypi/kubernetes/json",
+		"/api/v1/crates/kube",
+		"/github.com/!burnt!sushi/toml/@latest",
+		"/api/v1/packages/helm/argo/argo-cd",
+		"/io/grpc/grpc-core/maven-metadata.xml",
+		"/api/v1/gems/fluentd.json",
+	)
+	defer srv.Close()
+	r := newTestPackageResolver(srv)
+
+	tests := []struct {
+		key, id string
+		want    PackageLookup
+	}{
+		{"docker", "nginx", PackageFound},
+		{"docker", "nginx-missing", PackageNotFound},
+		{"ghcr", "fluxcd/flux", PackageFound},
+		{"quay", "prometheus/node-exporter", PackageFound},
+		{"npm", "@backstage/core", PackageFound},
+		{"pypi", "kubernetes", PackageFound},
+		{"crates", "kube", PackageFound},
+		{"go", "github.com/BurntSushi/toml", PackageFound},
+		{"helm", "argo/argo-cd", PackageFound},
+		{"helm", "argo-cd", PackageUnchecked},
+		{"maven", "io.grpc:grpc-core", PackageFound},
+		{"rubygems", "fluentd", PackageFound},
+		{"docker", "registry.k8s.io/pause", PackageUnchecked},
+		{"homebrew", "flux", PackageUnchecked},
+		{"npm", "Not Valid", PackageUnchecked},
+	}
+	for _, tt := range tests {
+		got, err := r.Resolve(tt.key, tt.id)
+		if err != nil {
+			t.Errorf("Resolve(%q, %q) error: %v", tt.key, tt.id, err)
+		}
+		if got != tt.want {
+			t.Errorf("Resolve(%q, %q) = %v, want %v", tt.key, tt.id, got, tt.want)
+		}
+	}
+}
+
+func TestPackageResolverDiagnostics(t *testing.T) {
+	srv := newFakeRegistries(t, "/pypi/kubernetes/json")
+	defer srv.Close()
+
+	p := validBaseProject()
+	p.PackageManagers = map[string]StringOrSlice{
+		"pypi": {"kubernetes", "kubernetes-missing", "unavailable"},
+	}
+	diags := newTestPackageResolver(srv).Diagnostics(p)
+	if len(diags) != 2 {
+		t.Fatalf("diags = %+v, want 2", diags)
+	}
+	if diags[0].Path != "package_managers.pypi[1]" || diags[0].Severity != SeverityError {
+		t.Errorf("missing package diagnostic = %+v", diags[0])
+	}
+	if diags[1].Path != "package_managers.pypi[2]" || diags[1].Severity != SeverityWarning || !strings.Contains(diags[1].Message, "503") {
+		t.Errorf("unavailable registry diagnostic = %+v", diags[1])
+	}
+}
+
+func TestValidateProjectWithPackageResolver(t *testing.T) {
+	srv := newFakeRegistries(t)
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	writeFile(t, path, validProjectYAML()+"package_managers:\n  npm: missing-pkg\n")
+	pv := newTestValidator(t)
+	pv.SetPackageResolver(newTestPackageResolver(srv))
+	result, err := pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, ok := findDiagnostic(result.Diagnostics, "package_managers.npm[0]")
+	if !ok || d.Rule != RulePackageNotFound || d.Line == 0 {
+		t.Errorf("diagnostic = %+v, want a located package-not-found error", d)
+	}
+	if result.Valid {
+		t.Error("a missing package should make the result invalid")
+	}
+}
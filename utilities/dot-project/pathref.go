@@ -0,0 +1,242 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PathRefTarget is a PathRef field resolved against the project's primary
+// repository.
+type PathRefTarget struct {
+	Field    string `json:"field"`               // project.yaml path, e.g. governance.contributing.path
+	Path     string `json:"path"`                // Value as written in project.yaml
+	RepoPath string `json:"repo_path,omitempty"` // Slash-separated path inside the repository; empty for absolute URLs
+	URL      string `json:"url"`                 // Browsable URL: the value itself, or a blob URL for relative paths
+	Error    string `json:"error,omitempty"`     // Why the path could not be resolved
+}
+
+// ResolvePathRefs resolves every PathRef in a project. Absolute http(s)
+// values are kept as-is; relative paths are interpreted from the root of the
+// primary repository (see PrimaryRepositoryURL) and turned into blob URLs on
+// branch. Targets that cannot be resolved carry an Error.
+func ResolvePathRefs(project Project, branch string) []PathRefTarget {
+	repoURL := strings.TrimSuffix(strings.TrimRight(PrimaryRepositoryURL(project.Repositories), "/"), ".git")
+
+	var targets []PathRefTarget
+	for _, c := range projectPathRefs(project) {
+		if c.ref == nil || c.ref.Path == "" {
+			continue
+		}
+		t := PathRefTarget{Field: c.label + ".path", Path: c.ref.Path}
+		switch {
+		case isHTTPURL(t.Path):
+			t.URL = t.Path
+		case repoURL == "":
+			t.Error = "relative path but project.yaml has no repositories"
+		default:
+			repoPath, err := cleanRepoPath(t.Path)
+			if err != nil {
+				t.Error = err.Error()
+				break
+			}
+			t.RepoPath = repoPath
+			t.URL = fmt.Sprintf("%s/blob/%s/%s", repoURL, branch, repoPath)
+		}
+		targets = append(targets, t)
+	}
+	return targets
+}
+
+// cleanRepoPath normalizes a relative PathRef ("./docs/GOVERNANCE.md",
+// "/GOVERNANCE.md") to a slash-separated path from the repository root.
+func cleanRepoPath(p string) (string, error) {
+	rel := path.Clean(strings.TrimPrefix(strings.ReplaceAll(p, "\\", "/"), "/"))
+	if rel == ".." || strings.HasPrefix(rel, "../") {
+		return "", fmt.Errorf("path %q points outside the repository", p)
+	}
+	if rel == "." {
+		return "", fmt.Errorf("path %q does not name a file", p)
+	}
+	return rel, nil
+}
+
+// RepoFileChecker reports whether a path exists in the project repository.
+// An error means existence could not be determined (e.g. the API was
+// unavailable), which is different from the file being absent.
+type RepoFileChecker interface {
+	FileExists(repoPath string) (bool, error)
+}
+
+// LocalRepoChecker checks paths against a local checkout of the repository.
+type LocalRepoChecker struct {
+	Dir string
+}
+
+// FileExists reports whether repoPath exists (as a file or directory) under
+// the checkout.
+func (c LocalRepoChecker) FileExists(repoPath string) (bool, error) {
+	_, err := os.Stat(filepath.Join(c.Dir, filepath.FromSlash(repoPath)))
+	if err == nil {
+		return true, nil
+	}
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// GitHubContentsChecker checks paths through the GitHub contents API at a
+// given ref.
+type GitHubContentsChecker struct {
+	Org, Repo, Ref string
+	Token          string
+	Client         *http.Client
+	BaseURL        string // GitHub API base; "" for DefaultGitHubAPIURL
+}
+
+// FileExists reports whether repoPath exists at Ref. A 404 means absent; any
+// other non-200 status is an error.
+func (c GitHubContentsChecker) FileExists(repoPath string) (bool, error) {
+	endpoint := fmt.Sprintf("/repos/%s/%s/contents/%s", c.Org, c.Repo, escapeRepoPath(repoPath))
+	if c.Ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(c.Ref)
+	}
+	resp, err := githubGet(c.Client, c.BaseURL, c.Token, endpoint)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("GitHub contents API returned HTTP %d for %s%s", resp.StatusCode, repoPath, rateLimitHint(resp))
+	}
+}
+
+// escapeRepoPath escapes each segment of a repository path for use in a URL.
+func escapeRepoPath(p string) string {
+	parts := strings.Split(p, "/")
+	for i, part := range parts {
+		parts[i] = url.PathEscape(part)
+	}
+	return strings.Join(parts, "/")
+}
+
+// GitHubDefaultBranch returns the default branch of org/repo.
+// baseURL overrides the GitHub API URL (use "" for default).
+func GitHubDefaultBranch(org, repo, token string, client *http.Client, baseURL string) (string, error) {
+	resp, err := githubGet(client, baseURL, token, fmt.Sprintf("/repos/%s/%s", org, repo))
+	if err != nil {
+		return "", fmt.Errorf("GitHub repo request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("GitHub repo returned HTTP %d for %s/%s%s", resp.StatusCode, org, repo, rateLimitHint(resp))
+	}
+	var data struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
+		return "", fmt.Errorf("parsing GitHub repo response: %w", err)
+	}
+	if data.DefaultBranch == "" {
+		return "", fmt.Errorf("GitHub repo response for %s/%s has no default_branch", org, repo)
+	}
+	return data.DefaultBranch, nil
+}
+
+// LocalDefaultBranch returns the default branch of a local checkout: the
+// branch origin/HEAD points to, or else the checked-out branch.
+func LocalDefaultBranch(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "symbolic-ref", "--short", "refs/remotes/origin/HEAD").Output()
+	if err == nil {
+		return strings.TrimPrefix(strings.TrimSpace(string(out)), "origin/"), nil
+	}
+	out, err = exec.Command("git", "-C", dir, "symbolic-ref", "--short", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("cannot determine the branch of %s: %w", dir, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// githubGet performs an authenticated GET against the GitHub REST API.
+func githubGet(client *http.Client, baseURL, token, endpoint string) (*http.Response, error) {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	req, err := http.NewRequest("GET", strings.TrimRight(baseURL, "/")+endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+	return client.Do(req)
+}
+
+// AuditPathRefs checks that every relative PathRef in the project exists in
+// the primary repository. Absolute URLs are left to AuditProject. A file
+// that is missing fails; a checker error also fails, with the error recorded
+// so that outages are distinguishable from moved files.
+func AuditPathRefs(project Project, branch string, checker RepoFileChecker) []AuditCheck {
+	var checks []AuditCheck
+	for _, t := range ResolvePathRefs(project, branch) {
+		if t.RepoPath == "" && t.Error == "" {
+			continue // absolute URL
+		}
+		check := AuditCheck{Field: t.Field, URL: t.URL}
+		if t.URL == "" {
+			check.URL = t.Path
+		}
+		if t.Error != "" {
+			check.Status = "fail"
+			check.Error = t.Error
+			checks = append(checks, check)
+			continue
+		}
+		exists, err := checker.FileExists(t.RepoPath)
+		switch {
+		case err != nil:
+			check.Status = "fail"
+			check.Error = err.Error()
+		case exists:
+			check.Status = "pass"
+		default:
+			check.Status = "fail"
+			check.Error = fmt.Sprintf("%s not found in repository", t.RepoPath)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// AddChecks appends checks to the result and updates its counters.
+func (r *AuditResult) AddChecks(checks []AuditCheck) {
+	for _, c := range checks {
+		switch c.Status {
+		case "pass":
+			r.PassCount++
+		case "fail":
+			r.FailCount++
+		case "skip":
+			r.SkipCount++
+		}
+		r.Checks = append(r.Checks, c)
+	}
+}
@@ -0,0 +1,109 @@
+package projects
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolvePathRefs(t *testing.T) {
+	project := validBaseProject()
+	project.Repositories = []RepositoryEntry{
+		{URL: "https://github.com/test/other"},
+		{URL: "https://github.com/test/repo/", Primary: true},
+	}
+	project.Governance = &GovernanceConfig{
+		Contributing:  &PathRef{Path: "./CONTRIBUTING.md"},
+		GovernanceDoc: &PathRef{Path: "/docs/GOVERNANCE.md"},
+		CodeOfConduct: &PathRef{Path: "https://github.com/cncf/foundation/blob/main/code-of-conduct.md"},
+		ChangeProcess: &PathRef{Path: "../outside.md"},
+	}
+
+	byField := make(map[string]PathRefTarget)
+	for _, target := range ResolvePathRefs(project, "main") {
+		byField[target.Field] = target
+	}
+
+	if got := byField["governance.contributing.path"]; got.RepoPath != "CONTRIBUTING.md" || got.URL != "https://github.com/test/repo/blob/main/CONTRIBUTING.md" {
+		t.Errorf("contributing = %+v", got)
+	}
+	if got := byField["governance.governance_doc.path"]; got.RepoPath != "docs/GOVERNANCE.md" {
+		t.Errorf("governance_doc = %+v", got)
+	}
+	if got := byField["governance.code_of_conduct.path"]; got.RepoPath != "" || got.URL != project.Governance.CodeOfConduct.Path {
+		t.Errorf("absolute URL should be kept as-is: %+v", got)
+	}
+	if got := byField["governance.change_process.path"]; !strings.Contains(got.Error, "outside the repository") {
+		t.Errorf("change_process = %+v, want outside-repository error", got)
+	}
+}
+
+func TestAuditPathRefsLocal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "docs"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "docs", "GOVERNANCE.md"), "# Governance\n")
+
+	project := validBaseProject()
+	project.Governance = &GovernanceConfig{
+		GovernanceDoc: &PathRef{Path: "docs/GOVERNANCE.md"},
+		Contributing:  &PathRef{Path: "CONTRIBUTING.md"},
+	}
+
+	checks := AuditPathRefs(project, "main", LocalRepoChecker{Dir: dir})
+	status := make(map[string]AuditCheck)
+	for _, c := range checks {
+		status[c.Field] = c
+	}
+	if c := status["governance.governance_doc.path"]; c.Status != "pass" {
+		t.Errorf("governance_doc = %+v, want pass", c)
+	}
+	if c := status["governance.contributing.path"]; c.Status != "fail" || !strings.Contains(c.Error, "not found") {
+		t.Errorf("contributing = %+v, want not-found failure", c)
+	}
+
+	var result AuditResult
+	result.AddChecks(checks)
+	if result.PassCount != 1 || result.FailCount != 1 {
+		t.Errorf("counts = %d pass, %d fail; want 1, 1", result.PassCount, result.FailCount)
+	}
+}
+
+func TestGitHubContentsChecker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/test/repo":
+			w.Write([]byte(`{"default_branch": "trunk"}`))
+		case "/repos/test/repo/contents/GOVERNANCE.md":
+			if r.URL.Query().Get("ref") != "trunk" {
+				t.Errorf("ref = %q, want trunk", r.URL.Query().Get("ref"))
+			}
+			w.Write([]byte(`{}`))
+		case "/repos/test/repo/contents/BROKEN.md":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	branch, err := GitHubDefaultBranch("test", "repo", "", server.Client(), server.URL)
+	if err != nil || branch != "trunk" {
+		t.Fatalf("GitHubDefaultBranch = %q, %v", branch, err)
+	}
+
+	checker := GitHubContentsChecker{Org: "test", Repo: "repo", Ref: branch, Client: server.Client(), BaseURL: server.URL}
+	if ok, err := checker.FileExists("GOVERNANCE.md"); !ok || err != nil {
+		t.Errorf("GOVERNANCE.md = %v, %v; want true, nil", ok, err)
+	}
+	if ok, err := checker.FileExists("MISSING.md"); ok || err != nil {
+		t.Errorf("MISSING.md = %v, %v; want false, nil", ok, err)
+	}
+	if _, err := checker.FileExists("BROKEN.md"); err == nil {
+		t.Error("expected an error for HTTP 502, not a missing file")
+	}
+}
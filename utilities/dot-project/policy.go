@@ -0,0 +1,220 @@
+package projects
+
+import (
+	_ "embed"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultPolicyYAML is the bundled maturity policy.
+//
+//go:embed policy/maturity.yaml
+var defaultPolicyYAML []byte
+
+// PolicyLevel says how strongly a policy rule applies in a maturity phase.
+type PolicyLevel string
+
+const (
+	PolicyRequired  PolicyLevel = "required"  // missing field is an error
+	PolicySuggested PolicyLevel = "suggested" // missing field is a warning
+)
+
+// PolicyRule declares, per maturity phase, whether a project.yaml field must
+// or should be present.
+type PolicyRule struct {
+	Field       string                 `json:"field" yaml:"field"`                                 // project.yaml path, e.g. governance.code_of_conduct
+	Description string                 `json:"description,omitempty" yaml:"description,omitempty"` // Shown in diagnostics
+	Phases      map[string]PolicyLevel `json:"phases" yaml:"phases"`                               // Maturity phase → level; absent phases are not checked
+	When        string                 `json:"when,omitempty" yaml:"when,omitempty"`               // project.yaml path; the rule applies only when it is set
+}
+
+// Policy is a set of maturity-aware field rules applied on top of the
+// structural validation.
+type Policy struct {
+	Rules []PolicyRule `json:"rules" yaml:"rules"`
+}
+
+// DefaultPolicy returns the bundled policy/maturity.yaml.
+func DefaultPolicy() (*Policy, error) {
+	return LoadPolicy(defaultPolicyYAML)
+}
+
+// LoadPolicyFile reads a policy from a YAML file.
+func LoadPolicyFile(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %v", err)
+	}
+	return LoadPolicy(data)
+}
+
+// LoadPolicy parses a YAML policy and checks that every rule names a real
+// project.yaml field, a known maturity phase and a known level.
+func LoadPolicy(data []byte) (*Policy, error) {
+	var policy Policy
+	decoder := yaml.NewDecoder(strings.NewReader(string(data)))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&policy); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %v", err)
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.Field == "" {
+			return nil, fmt.Errorf("rules[%d]: field is required", i)
+		}
+		if _, ok := lookupProjectField(reflect.ValueOf(Project{}), rule.Field); !ok {
+			return nil, fmt.Errorf("rules[%d]: unknown project.yaml field %q", i, rule.Field)
+		}
+		if rule.When != "" {
+			if _, ok := lookupProjectField(reflect.ValueOf(Project{}), rule.When); !ok {
+				return nil, fmt.Errorf("rules[%d] (%s): unknown project.yaml field %q in when", i, rule.Field, rule.When)
+			}
+		}
+		for _, phase := range sortedKeys(rule.Phases) {
+			if !ValidMaturityPhases[phase] {
+				return nil, fmt.Errorf("rules[%d] (%s): unknown maturity phase %q", i, rule.Field, phase)
+			}
+			if level := rule.Phases[phase]; level != PolicyRequired && level != PolicySuggested {
+				return nil, fmt.Errorf("rules[%d] (%s): level for %s must be %q or %q, got %q", i, rule.Field, phase, PolicyRequired, PolicySuggested, level)
+			}
+		}
+	}
+	return &policy, nil
+}
+
+// SetPolicy sets the maturity policy applied after structural validation.
+// NewValidator uses the bundled policy; nil disables policy checks.
+func (pv *ProjectValidator) SetPolicy(policy *Policy) {
+	pv.policy = policy
+}
+
+// defaultMaturityPolicy loads the bundled policy for a new validator, logging
+// rather than failing so that a broken policy never blocks validation.
+func defaultMaturityPolicy() *Policy {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		log.Printf("Warning: bundled maturity policy unusable, policy checks disabled: %v", err)
+		return nil
+	}
+	return policy
+}
+
+// locatePolicyDiagnostics positions policy diagnostics against the source.
+// Missing fields resolve to their nearest existing parent section.
+func locatePolicyDiagnostics(diags []Diagnostic, content string) []Diagnostic {
+	if doc, err := parseYAMLDocument([]byte(content)); err == nil {
+		LocateDiagnostics(diags, doc)
+	}
+	return diags
+}
+
+// CurrentMaturityPhase returns the phase of the last maturity_log entry, or
+// an empty string when the log is empty.
+func CurrentMaturityPhase(project Project) string {
+	if len(project.MaturityLog) == 0 {
+		return ""
+	}
+	return project.MaturityLog[len(project.MaturityLog)-1].Phase
+}
+
+// Evaluate applies the policy to a project at its current maturity phase.
+// Missing required fields are errors and missing suggested fields warnings,
+// both under RuleMaturityPolicy. A field counts as present when it holds a
+// non-zero value; rules with When apply only when that field is present.
+func (p *Policy) Evaluate(project Project) []Diagnostic {
+	if p == nil {
+		return nil
+	}
+	phase := CurrentMaturityPhase(project)
+	if phase == "" {
+		return nil
+	}
+
+	root := reflect.ValueOf(project)
+	var diags []Diagnostic
+	for _, rule := range p.Rules {
+		level, ok := rule.Phases[phase]
+		if !ok {
+			continue
+		}
+		if rule.When != "" {
+			if v, ok := lookupProjectField(root, rule.When); !ok || v.IsZero() {
+				continue
+			}
+		}
+		if v, ok := lookupProjectField(root, rule.Field); ok && !v.IsZero() {
+			continue
+		}
+
+		what := rule.Field
+		if rule.Description != "" {
+			what = fmt.Sprintf("%s (%s)", rule.Field, rule.Description)
+		}
+		d := Diagnostic{
+			Path:     rule.Field,
+			Rule:     RuleMaturityPolicy,
+			Severity: SeverityError,
+			Message:  fmt.Sprintf("%s is required for %s projects", what, phase),
+		}
+		if level == PolicySuggested {
+			d.Severity = SeverityWarning
+			d.Message = fmt.Sprintf("%s is suggested for %s projects", what, phase)
+		}
+		diags = append(diags, d.withFix("add %s to project.yaml", policyFieldHint(root, rule.Field)))
+	}
+	return diags
+}
+
+// policyFieldHint describes what to add for a field: PathRef fields need a
+// nested path key.
+func policyFieldHint(root reflect.Value, field string) string {
+	if v, ok := lookupProjectField(root, field); ok && v.Type() == reflect.TypeOf(PathRef{}) {
+		return field + ".path"
+	}
+	return field
+}
+
+// lookupProjectField follows a dotted project.yaml path through v by json
+// tag names. Nil pointers along the way yield the zero value of the field's
+// type, so the lookup succeeds for every path that exists in the schema.
+func lookupProjectField(v reflect.Value, path string) (reflect.Value, bool) {
+	for _, key := range strings.Split(path, ".") {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v = reflect.Zero(v.Type().Elem())
+			} else {
+				v = v.Elem()
+			}
+		}
+		if v.Kind() != reflect.Struct {
+			return reflect.Value{}, false
+		}
+		found := false
+		for i := 0; i < v.NumField(); i++ {
+			f := v.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			if name, _ := jsonFieldName(f); name == key {
+				v = v.Field(i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return reflect.Value{}, false
+		}
+	}
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return reflect.Zero(v.Type().Elem()), true
+		}
+		v = v.Elem()
+	}
+	return v, true
+}
@@ -0,0 +1,136 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefaultPolicyLoads(t *testing.T) {
+	policy, err := DefaultPolicy()
+	if err != nil {
+		t.Fatalf("DefaultPolicy: %v", err)
+	}
+	if len(policy.Rules) == 0 {
+		t.Fatal("bundled policy has no rules")
+	}
+}
+
+func TestLoadPolicyErrors(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown field", "rules:\n  - field: governance.nope\n    phases: {graduated: required}\n", "unknown project.yaml field"},
+		{"unknown phase", "rules:\n  - field: legal.license\n    phases: {emerging: required}\n", "unknown maturity phase"},
+		{"bad level", "rules:\n  - field: legal.license\n    phases: {graduated: mandatory}\n", "must be"},
+		{"missing field", "rules:\n  - phases: {graduated: required}\n", "field is required"},
+		{"unknown key", "rules:\n  - field: legal.license\n    level: required\n", "failed to parse policy"},
+		{"unknown when", "rules:\n  - field: legal.license\n    when: nope\n    phases: {graduated: required}\n", "in when"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadPolicy([]byte(tt.yaml))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestPolicyEvaluate(t *testing.T) {
+	policy, err := LoadPolicy([]byte(`rules:
+  - field: governance.code_of_conduct
+    description: Code of conduct
+    phases: {incubating: required, graduated: required}
+  - field: governance.contributor_ladder
+    phases: {graduated: suggested}
+  - field: governance.maintainer_lifecycle
+    phases: {graduated: required}
+  - field: legal.license
+    phases: {sandbox: suggested}
+  - field: governance.sub_project_list
+    when: subprojects
+    phases: {graduated: required}
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	project := validBaseProject()
+	project.MaturityLog = append(project.MaturityLog, MaturityEntry{
+		Phase: "graduated",
+		Date:  time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Issue: "https://github.com/cncf/toc/issues/2",
+	})
+	project.Governance = &GovernanceConfig{
+		MaintainerLifecycle: MaintainerLifecycle{MentoringProgram: []string{"https://example.com/mentoring"}},
+	}
+
+	diags := policy.Evaluate(project)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+	coc := diags[0]
+	if coc.Path != "governance.code_of_conduct" || coc.Severity != SeverityError || coc.Rule != RuleMaturityPolicy {
+		t.Errorf("code_of_conduct diagnostic = %+v", coc)
+	}
+	if coc.Message != "governance.code_of_conduct (Code of conduct) is required for graduated projects" {
+		t.Errorf("message = %q", coc.Message)
+	}
+	if coc.Fix != "add governance.code_of_conduct.path to project.yaml" {
+		t.Errorf("fix = %q", coc.Fix)
+	}
+	if ladder := diags[1]; ladder.Path != "governance.contributor_ladder" || ladder.Severity != SeverityWarning {
+		t.Errorf("contributor_ladder diagnostic = %+v", ladder)
+	}
+
+	// Rules with when apply once the project sets that field.
+	project.Subprojects = []Subproject{{Name: "Kind"}}
+	diags = policy.Evaluate(project)
+	if len(diags) != 3 || diags[2].Path != "governance.sub_project_list" || diags[2].Severity != SeverityError {
+		t.Errorf("diagnostics with subprojects = %v", diags)
+	}
+	project.Subprojects = nil
+
+	// Only the current (last) phase applies.
+	project.MaturityLog = project.MaturityLog[:1]
+	diags = policy.Evaluate(project)
+	if len(diags) != 1 || diags[0].Path != "legal.license" || diags[0].Severity != SeverityWarning {
+		t.Errorf("sandbox diagnostics = %v", diags)
+	}
+
+	project.Legal = &LegalConfig{License: &PathRef{Path: "LICENSE"}}
+	if diags := policy.Evaluate(project); len(diags) != 0 {
+		t.Errorf("expected no diagnostics once license is set, got %v", diags)
+	}
+}
+
+func TestValidateProjectAppliesPolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "project.yaml")
+	writeFile(t, path, strings.Replace(validProjectYAML(), "phase: sandbox", "phase: graduated", 1))
+
+	pv := newTestValidator(t)
+	result, err := pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid {
+		t.Error("graduated project without governance should fail the default policy")
+	}
+	d, ok := findDiagnostic(result.Diagnostics, "governance.code_of_conduct")
+	if !ok || d.Rule != RuleMaturityPolicy || d.Line == 0 {
+		t.Errorf("expected positioned code_of_conduct policy diagnostic, got %v", result.Diagnostics)
+	}
+
+	// The threat model check is not part of the policy.
+	pv.SetPolicy(nil)
+	result, _ = pv.validateProject(path)
+	if len(result.Errors) != 1 {
+		t.Errorf("policy disabled, expected only the threat model error, got %v", result.Errors)
+	}
+	if d, ok := findDiagnostic(result.Diagnostics, "security.threat_model"); !ok || d.Rule != RuleThreatModel {
+		t.Errorf("threat model diagnostic = %+v", d)
+	}
+}
@@ -0,0 +1,365 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ChangeKind classifies a FieldChange.
+type ChangeKind string
+
+const (
+	ChangeAdded   ChangeKind = "added"
+	ChangeRemoved ChangeKind = "removed"
+	ChangeChanged ChangeKind = "changed"
+)
+
+// FieldChange is one field-level difference between two versions of a
+// project.yaml.
+type FieldChange struct {
+	Path    string     `json:"path" yaml:"path"`                   // project.yaml path, e.g. repositories[2] or security.contact.email
+	Kind    ChangeKind `json:"kind" yaml:"kind"`                   // added, removed or changed
+	Old     string     `json:"old,omitempty" yaml:"old,omitempty"` // Previous value, rendered for display
+	New     string     `json:"new,omitempty" yaml:"new,omitempty"` // Current value, rendered for display
+	Summary string     `json:"summary" yaml:"summary"`             // One-line human-readable description
+}
+
+// DiffProjects reports the field-level differences from old to new. Structs
+// and maps are compared field by field and key by key. Lists of strings or
+// of items with a url or name are matched by that value; other lists, such
+// as maturity_log, by index, so appended or dropped entries show up as added
+// or removed items. Absent optional sections compare equal to empty ones.
+// Changes are ordered by their position in the Project type.
+func DiffProjects(old, new Project) []FieldChange {
+	var changes []FieldChange
+	diffValues("", reflect.ValueOf(old), reflect.ValueOf(new), &changes)
+	return changes
+}
+
+// DiffProjectYAML decodes two project.yaml documents and diffs them. Unknown
+// fields are ignored so that content written for an older schema can still
+// be compared.
+func DiffProjectYAML(oldContent, newContent string) ([]FieldChange, error) {
+	var old, new Project
+	if err := yaml.Unmarshal([]byte(oldContent), &old); err != nil {
+		return nil, fmt.Errorf("parsing previous project.yaml: %w", err)
+	}
+	if err := yaml.Unmarshal([]byte(newContent), &new); err != nil {
+		return nil, fmt.Errorf("parsing current project.yaml: %w", err)
+	}
+	return DiffProjects(old, new), nil
+}
+
+// diffValues appends the differences between a and b, which have the same
+// type, to changes.
+func diffValues(path string, a, b reflect.Value, changes *[]FieldChange) {
+	a, b = derefOrZero(a), derefOrZero(b)
+
+	switch a.Kind() {
+	case reflect.Struct:
+		if a.Type() == timeType {
+			diffLeaf(path, a, b, changes)
+			return
+		}
+		for i := 0; i < a.NumField(); i++ {
+			f := a.Type().Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			name, _ := jsonFieldName(f)
+			if name == "" {
+				continue
+			}
+			diffValues(joinDiffPath(path, name), a.Field(i), b.Field(i), changes)
+		}
+
+	case reflect.Slice, reflect.Array:
+		if diffKeyedList(path, a, b, changes) {
+			return
+		}
+		n := a.Len()
+		if b.Len() > n {
+			n = b.Len()
+		}
+		for i := 0; i < n; i++ {
+			elemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= a.Len():
+				*changes = append(*changes, elementChange(path, elemPath, ChangeAdded, b.Index(i)))
+			case i >= b.Len():
+				*changes = append(*changes, elementChange(path, elemPath, ChangeRemoved, a.Index(i)))
+			default:
+				diffValues(elemPath, a.Index(i), b.Index(i), changes)
+			}
+		}
+
+	case reflect.Map:
+		keys := make(map[string]bool)
+		for _, k := range a.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, k := range b.MapKeys() {
+			keys[k.String()] = true
+		}
+		for _, key := range sortedKeys(keys) {
+			k := reflect.ValueOf(key).Convert(a.Type().Key())
+			av, bv := a.MapIndex(k), b.MapIndex(k)
+			keyPath := joinDiffPath(path, key)
+			switch {
+			case !av.IsValid():
+				*changes = append(*changes, elementChange(path, keyPath, ChangeAdded, bv))
+			case !bv.IsValid():
+				*changes = append(*changes, elementChange(path, keyPath, ChangeRemoved, av))
+			default:
+				diffValues(keyPath, av, bv, changes)
+			}
+		}
+
+	default:
+		diffLeaf(path, a, b, changes)
+	}
+}
+
+// diffKeyedList compares lists whose items have an identity (strings, or
+// structs with a url or name field) by that identity, so inserting an item
+// in the middle reads as one addition rather than a cascade of changes.
+// Matched items are compared field by field under their new index. It
+// returns false when the items have no identity or it is not unique, and
+// the caller falls back to comparing by index.
+func diffKeyedList(path string, a, b reflect.Value, changes *[]FieldChange) bool {
+	oldIdx, ok := listKeys(a)
+	if !ok {
+		return false
+	}
+	newIdx, ok := listKeys(b)
+	if !ok {
+		return false
+	}
+	for i := 0; i < a.Len(); i++ {
+		key, _ := diffKey(a.Index(i))
+		if _, kept := newIdx[key]; !kept {
+			*changes = append(*changes, elementChange(path, fmt.Sprintf("%s[%d]", path, i), ChangeRemoved, a.Index(i)))
+		}
+	}
+	for j := 0; j < b.Len(); j++ {
+		key, _ := diffKey(b.Index(j))
+		elemPath := fmt.Sprintf("%s[%d]", path, j)
+		if i, existed := oldIdx[key]; existed {
+			diffValues(elemPath, a.Index(i), b.Index(j), changes)
+		} else {
+			*changes = append(*changes, elementChange(path, elemPath, ChangeAdded, b.Index(j)))
+		}
+	}
+	return true
+}
+
+// listKeys maps the identity of each list item to its index. It fails if an
+// item has no identity or two items share one.
+func listKeys(list reflect.Value) (map[string]int, bool) {
+	keys := make(map[string]int, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		key, ok := diffKey(list.Index(i))
+		if !ok {
+			return nil, false
+		}
+		if _, dup := keys[key]; dup {
+			return nil, false
+		}
+		keys[key] = i
+	}
+	return keys, true
+}
+
+// diffKey returns the identity of a list item: the string itself, or the
+// url or name field of a struct.
+func diffKey(v reflect.Value) (string, bool) {
+	v = derefOrZero(v)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), true
+	case reflect.Struct:
+		for _, want := range []string{"url", "name"} {
+			for i := 0; i < v.NumField(); i++ {
+				f := v.Type().Field(i)
+				if name, _ := jsonFieldName(f); name == want && f.Type.Kind() == reflect.String {
+					return v.Field(i).String(), true
+				}
+			}
+		}
+	}
+	return "", false
+}
+
+// diffLeaf compares two scalar values. Going from the zero value to a set
+// value is an addition, the reverse a removal.
+func diffLeaf(path string, a, b reflect.Value, changes *[]FieldChange) {
+	if reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return
+	}
+	oldStr, newStr := renderDiffValue(a), renderDiffValue(b)
+	switch {
+	case a.IsZero():
+		*changes = append(*changes, FieldChange{Path: path, Kind: ChangeAdded, New: newStr,
+			Summary: fmt.Sprintf("%s set to %s", path, newStr)})
+	case b.IsZero():
+		*changes = append(*changes, FieldChange{Path: path, Kind: ChangeRemoved, Old: oldStr,
+			Summary: fmt.Sprintf("%s removed (was %s)", path, oldStr)})
+	default:
+		*changes = append(*changes, FieldChange{Path: path, Kind: ChangeChanged, Old: oldStr, New: newStr,
+			Summary: fmt.Sprintf("%s changed from %s to %s", path, oldStr, newStr)})
+	}
+}
+
+// elementChange describes a whole list item or map entry that was added or
+// removed. maturity_log entries read as phase transitions.
+func elementChange(listPath, path string, kind ChangeKind, v reflect.Value) FieldChange {
+	rendered := renderDiffValue(v)
+	c := FieldChange{Path: path, Kind: kind}
+	if kind == ChangeAdded {
+		c.New = rendered
+	} else {
+		c.Old = rendered
+	}
+
+	if listPath == "maturity_log" {
+		if e, ok := derefOrZero(v).Interface().(MaturityEntry); ok {
+			verb := "gained"
+			if kind == ChangeRemoved {
+				verb = "lost"
+			}
+			c.Summary = fmt.Sprintf("maturity_log %s %s entry (%s)", verb, e.Phase, e.Date.Format("2006-01-02"))
+			return c
+		}
+	}
+	c.Summary = fmt.Sprintf("%s %s: %s", path, kind, rendered)
+	if kind == ChangeRemoved {
+		c.Summary = fmt.Sprintf("%s removed (was %s)", path, rendered)
+	}
+	return c
+}
+
+// renderDiffValue formats a value for a change summary: strings are quoted,
+// dates are shown as YYYY-MM-DD, repositories as their URL and anything
+// else as compact JSON.
+func renderDiffValue(v reflect.Value) string {
+	v = derefOrZero(v)
+	switch x := v.Interface().(type) {
+	case string:
+		return strconv.Quote(x)
+	case time.Time:
+		return x.Format("2006-01-02")
+	case RepositoryEntry:
+		return strconv.Quote(x.URL)
+	case MaturityEntry:
+		return fmt.Sprintf("%s (%s)", x.Phase, x.Date.Format("2006-01-02"))
+	}
+	data, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprint(v.Interface())
+	}
+	return string(data)
+}
+
+// derefOrZero follows pointers and interfaces, replacing nil with the zero
+// value of the element type so that absent and empty compare equal.
+func derefOrZero(v reflect.Value) reflect.Value {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			if v.Kind() == reflect.Interface {
+				return v
+			}
+			return reflect.Zero(v.Type().Elem())
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+func joinDiffPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
+
+// ProjectChanges groups the field changes of one project in a validation run.
+type ProjectChanges struct {
+	URL         string        `json:"url" yaml:"url"`
+	ProjectName string        `json:"project_name,omitempty" yaml:"project_name,omitempty"`
+	New         bool          `json:"new,omitempty" yaml:"new,omitempty"` // First time the project was seen
+	Changes     []FieldChange `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// CollectProjectChanges extracts the changed projects from validation
+// results, sorted by project name. It is the data behind the change digest.
+func CollectProjectChanges(results []ValidationResult) []ProjectChanges {
+	var out []ProjectChanges
+	for _, r := range results {
+		if !r.Changed {
+			continue
+		}
+		out = append(out, ProjectChanges{
+			URL:         r.URL,
+			ProjectName: r.ProjectName,
+			New:         r.PreviousHash == "",
+			Changes:     r.FieldChanges,
+		})
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return strings.ToLower(out[i].ProjectName) < strings.ToLower(out[j].ProjectName)
+	})
+	return out
+}
+
+// FormatChangeDigest renders a Markdown digest of what changed across all
+// projects in a validation run, e.g. for a weekly report.
+func FormatChangeDigest(results []ValidationResult) string {
+	changed := CollectProjectChanges(results)
+
+	var b strings.Builder
+	b.WriteString("# Project metadata changes\n\n")
+	if len(changed) == 0 {
+		b.WriteString(fmt.Sprintf("No changes across %d projects.\n", len(results)))
+		return b.String()
+	}
+
+	var added []ProjectChanges
+	for _, p := range changed {
+		if p.New {
+			added = append(added, p)
+			continue
+		}
+		b.WriteString(fmt.Sprintf("## %s\n\n", digestProjectName(p)))
+		if len(p.Changes) == 0 {
+			b.WriteString("- content changed, but no field-level differences (formatting, comments or unknown fields)\n")
+		}
+		for _, c := range p.Changes {
+			b.WriteString(fmt.Sprintf("- %s\n", c.Summary))
+		}
+		b.WriteString("\n")
+	}
+	if len(added) > 0 {
+		b.WriteString("## New projects\n\n")
+		for _, p := range added {
+			b.WriteString(fmt.Sprintf("- %s\n", digestProjectName(p)))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("Summary: %d of %d projects changed (%d new)\n", len(changed), len(results), len(added)))
+	return b.String()
+}
+
+func digestProjectName(p ProjectChanges) string {
+	if p.ProjectName == "" {
+		return p.URL
+	}
+	return fmt.Sprintf("%s (%s)", p.ProjectName, p.URL)
+}
@@ -0,0 +1,120 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func changeSummaries(changes []FieldChange) []string {
+	var out []string
+	for _, c := range changes {
+		out = append(out, c.Summary)
+	}
+	return out
+}
+
+func TestDiffProjects(t *testing.T) {
+	old := validBaseProject()
+	old.Website = "https://old.example.com"
+	old.Repositories = []RepositoryEntry{
+		{URL: "https://github.com/test/a"},
+		{URL: "https://github.com/test/b"},
+		{URL: "https://github.com/test/c"},
+	}
+
+	new := validBaseProject()
+	new.Website = "https://new.example.com"
+	new.Repositories = []RepositoryEntry{
+		{URL: "https://github.com/test/a", Primary: true},
+		{URL: "https://github.com/test/c"},
+	}
+	new.MaturityLog = append(new.MaturityLog, MaturityEntry{
+		Phase: "incubating",
+		Date:  time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		Issue: "https://github.com/cncf/toc/issues/2",
+	})
+	new.Security = &SecurityConfig{Contact: &SecurityContact{Email: "security@example.com"}}
+	new.Social = map[string]string{"twitter": "https://twitter.com/test"}
+
+	got := changeSummaries(DiffProjects(old, new))
+	want := []string{
+		`repositories[1] removed (was "https://github.com/test/b")`,
+		`repositories[0].primary set to true`,
+		`website changed from "https://old.example.com" to "https://new.example.com"`,
+		`maturity_log gained incubating entry (2025-03-01)`,
+		`social.twitter added: "https://twitter.com/test"`,
+		`security.contact.email set to "security@example.com"`,
+	}
+	for _, w := range want {
+		found := false
+		for _, g := range got {
+			if g == w {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("missing change %q in:\n  %s", w, strings.Join(got, "\n  "))
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("got %d changes, want %d:\n  %s", len(got), len(want), strings.Join(got, "\n  "))
+	}
+}
+
+func TestDiffProjectsIdentical(t *testing.T) {
+	p := validBaseProject()
+	q := validBaseProject()
+	q.Security = &SecurityConfig{} // empty section equals absent
+	if changes := DiffProjects(p, q); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changeSummaries(changes))
+	}
+}
+
+func TestDiffProjectYAML(t *testing.T) {
+	oldYAML := validProjectYAML()
+	newYAML := strings.Replace(oldYAML, "description: A valid test project", "description: A better description", 1)
+	changes, err := DiffProjectYAML(oldYAML, newYAML)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0].Path != "description" || changes[0].Kind != ChangeChanged {
+		t.Errorf("changes = %+v", changes)
+	}
+	if _, err := DiffProjectYAML("name: [", newYAML); err == nil {
+		t.Error("expected an error for invalid previous YAML")
+	}
+}
+
+func TestValidateProjectsRecordsFieldChanges(t *testing.T) {
+	dir := t.TempDir()
+	projectPath := filepath.Join(dir, "project.yaml")
+	writeFile(t, projectPath, validProjectYAML())
+	listPath := filepath.Join(dir, "projectlist.yaml")
+	writeFile(t, listPath, "projects:\n  - url: \""+projectPath+"\"\n")
+	cacheDir := filepath.Join(dir, "cache")
+
+	first, err := NewValidator(cacheDir).ValidateAll(listPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest := FormatChangeDigest(first); !strings.Contains(digest, "## New projects") {
+		t.Errorf("first run should list the project as new:\n%s", digest)
+	}
+
+	writeFile(t, projectPath, validProjectYAML()+"website: https://test.example.com\n")
+	second, err := NewValidator(cacheDir).ValidateAll(listPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second[0].FieldChanges) != 1 || second[0].FieldChanges[0].Path != "website" {
+		t.Fatalf("FieldChanges = %+v", second[0].FieldChanges)
+	}
+	digest := FormatChangeDigest(second)
+	for _, want := range []string{"## Test Project", `- website set to "https://test.example.com"`, "Summary: 1 of 1 projects changed (0 new)"} {
+		if !strings.Contains(digest, want) {
+			t.Errorf("digest missing %q:\n%s", want, digest)
+		}
+	}
+}
@@ -0,0 +1,406 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MaintainerFieldDiff is a field on which maintainers.yaml and the foundation
+// CSV disagree for the same handle.
+type MaintainerFieldDiff struct {
+	Handle string `json:"handle" yaml:"handle"`
+	Field  string `json:"field" yaml:"field"` // company or email
+	YAML   string `json:"yaml" yaml:"yaml"`
+	CSV    string `json:"csv" yaml:"csv"`
+}
+
+// MaintainerReconciliation compares the project-maintainers team of one
+// maintainers.yaml entry with the matching blocks of the foundation CSV.
+type MaintainerReconciliation struct {
+	ProjectID   string                `json:"project_id" yaml:"project_id"`
+	CSVBlocks   []string              `json:"csv_blocks" yaml:"csv_blocks"` // CSV project labels that matched
+	OnlyInCSV   []TeamMember          `json:"only_in_csv,omitempty" yaml:"only_in_csv,omitempty"`
+	OnlyInYAML  []TeamMember          `json:"only_in_yaml,omitempty" yaml:"only_in_yaml,omitempty"`
+	Differences []MaintainerFieldDiff `json:"differences,omitempty" yaml:"differences,omitempty"`
+}
+
+// InSync reports whether the two sources agree.
+func (r MaintainerReconciliation) InSync() bool {
+	return len(r.OnlyInCSV) == 0 && len(r.OnlyInYAML) == 0 && len(r.Differences) == 0
+}
+
+// ReconcileMaintainers compares entry's project-maintainers with the CSV
+// blocks matching the entry's project_id, org, or any of names. Handles are
+// compared case-insensitively. Company and email are only compared when both
+// sides have a value, since the CSV usually has no email column and older
+// maintainers.yaml files list bare handles.
+func ReconcileMaintainers(entry MaintainerEntry, blocks []MaintainerBlock, names ...string) (MaintainerReconciliation, error) {
+	result := MaintainerReconciliation{ProjectID: entry.ProjectID}
+	candidates := normalizeCandidates(append([]string{entry.ProjectID, entry.Org}, names...)...)
+	for _, b := range blocks {
+		if blockMatches(b.Project, candidates) {
+			result.CSVBlocks = append(result.CSVBlocks, b.Project)
+		}
+	}
+	if len(result.CSVBlocks) == 0 {
+		return result, fmt.Errorf("no project in the maintainers CSV matches %s", strings.Join(sortedKeys(candidates), ", "))
+	}
+
+	csvMembers := MatchProjectMaintainerMembers(blocks, sortedKeys(candidates)...)
+	csvByHandle := make(map[string]TeamMember)
+	for _, m := range csvMembers {
+		csvByHandle[strings.ToLower(m.Handle)] = m
+	}
+
+	yamlByHandle := make(map[string]bool)
+	for _, m := range projectMaintainerMembers(entry) {
+		key := strings.ToLower(m.Handle)
+		yamlByHandle[key] = true
+		c, ok := csvByHandle[key]
+		if !ok {
+			result.OnlyInYAML = append(result.OnlyInYAML, m)
+			continue
+		}
+		for _, f := range []struct{ name, yaml, csv string }{
+			{"company", m.Company, c.Company},
+			{"email", m.Email, c.Email},
+		} {
+			if f.yaml != "" && f.csv != "" && !strings.EqualFold(strings.TrimSpace(f.yaml), strings.TrimSpace(f.csv)) {
+				result.Differences = append(result.Differences, MaintainerFieldDiff{Handle: m.Handle, Field: f.name, YAML: f.yaml, CSV: f.csv})
+			}
+		}
+	}
+	for _, m := range csvMembers {
+		if !yamlByHandle[strings.ToLower(m.Handle)] {
+			result.OnlyInCSV = append(result.OnlyInCSV, m)
+		}
+	}
+	return result, nil
+}
+
+// projectMaintainerMembers returns the members of the entry's
+// project-maintainers teams with normalized handles, de-duplicated.
+func projectMaintainerMembers(entry MaintainerEntry) []TeamMember {
+	seen := make(map[string]bool)
+	var members []TeamMember
+	for _, team := range entry.Teams {
+		if team.Name != "project-maintainers" {
+			continue
+		}
+		for _, m := range team.TeamMembers() {
+			m.Handle = strings.TrimPrefix(strings.TrimSpace(m.Handle), "@")
+			key := strings.ToLower(m.Handle)
+			if key == "" || seen[key] {
+				continue
+			}
+			seen[key] = true
+			members = append(members, m)
+		}
+	}
+	return members
+}
+
+// FormatReconciliation renders reconciliation results as text.
+func FormatReconciliation(results []MaintainerReconciliation) string {
+	var b strings.Builder
+	b.WriteString("Maintainers Reconciliation Report\n")
+	b.WriteString("=================================\n\n")
+
+	outOfSync := 0
+	for _, r := range results {
+		if r.InSync() {
+			b.WriteString(fmt.Sprintf("IN SYNC: %s (CSV: %s)\n\n", r.ProjectID, strings.Join(r.CSVBlocks, "; ")))
+			continue
+		}
+		outOfSync++
+		b.WriteString(fmt.Sprintf("OUT OF SYNC: %s (CSV: %s)\n", r.ProjectID, strings.Join(r.CSVBlocks, "; ")))
+		for _, m := range r.OnlyInCSV {
+			b.WriteString(fmt.Sprintf("  + %s only in CSV%s\n", m.Handle, memberDetails(m)))
+		}
+		for _, m := range r.OnlyInYAML {
+			b.WriteString(fmt.Sprintf("  - %s only in maintainers.yaml%s\n", m.Handle, memberDetails(m)))
+		}
+		for _, d := range r.Differences {
+			b.WriteString(fmt.Sprintf("  ~ %s %s: %q in maintainers.yaml, %q in CSV\n", d.Handle, d.Field, d.YAML, d.CSV))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(fmt.Sprintf("Summary: %d project(s) reconciled, %d out of sync\n", len(results), outOfSync))
+	return b.String()
+}
+
+// memberDetails renders " (name, company)" for the known details of m.
+func memberDetails(m TeamMember) string {
+	var parts []string
+	for _, s := range []string{m.Name, m.Company, m.Email} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " (" + strings.Join(parts, ", ") + ")"
+}
+
+// PatchMaintainersYAML applies the CSV side of a reconciliation to
+// maintainers.yaml content: members only in the CSV are added to the
+// project-maintainers team, members only in the YAML are removed, and
+// differing fields take the CSV value. Only the affected members' lines are
+// rewritten, so the rest of the file keeps its exact layout.
+func PatchMaintainersYAML(content []byte, r MaintainerReconciliation) ([]byte, error) {
+	doc, err := parseYAMLDocument(content)
+	if err != nil {
+		return nil, fmt.Errorf("parsing maintainers YAML: %w", err)
+	}
+	members := projectMaintainersNode(documentRoot(doc), r.ProjectID)
+	if members == nil {
+		return nil, fmt.Errorf("no project-maintainers team for project_id %q", r.ProjectID)
+	}
+	if len(members.Content) == 0 || members.Style&yaml.FlowStyle != 0 {
+		return nil, fmt.Errorf("the project-maintainers members of %q must be a non-empty block list to be patched", r.ProjectID)
+	}
+	ed := newYAMLEditor(content)
+	errEdit := func(handle string) error {
+		return fmt.Errorf("cannot edit member %s in place; update maintainers.yaml by hand", handle)
+	}
+
+	remove := make(map[string]bool)
+	for _, m := range r.OnlyInYAML {
+		remove[strings.ToLower(m.Handle)] = true
+	}
+	for _, n := range members.Content {
+		if handle := memberNodeHandle(n); remove[strings.ToLower(handle)] && !ed.deleteItem(n) {
+			return nil, errEdit(handle)
+		}
+	}
+
+	for _, d := range r.Differences {
+		for _, n := range members.Content {
+			if !strings.EqualFold(memberNodeHandle(n), d.Handle) {
+				continue
+			}
+			indent := strings.Repeat(" ", n.Column-1)
+			field := d.Field + ": " + renderScalar(d.CSV, 0)
+			switch v := mappingValue(n, d.Field); {
+			case n.Kind == yaml.ScalarNode:
+				// A plain handle becomes a mapping with the field added.
+				if !ed.replaceScalar(n, "handle: "+renderScalar(n.Value, n.Style)) {
+					return nil, errEdit(d.Handle)
+				}
+				ed.insertAfter(n.Line, indent+field)
+			case v != nil:
+				if !ed.setScalar(v, d.CSV) {
+					return nil, errEdit(d.Handle)
+				}
+			default:
+				ed.insertAfter(lastLine(n), indent+field)
+			}
+		}
+	}
+
+	last := members.Content[len(members.Content)-1]
+	first := ed.line(members.Content[0].Line)
+	dash := strings.IndexRune(string(first), '-')
+	if dash < 0 {
+		return nil, errEdit(memberNodeHandle(members.Content[0]))
+	}
+	for _, m := range r.OnlyInCSV {
+		ed.insertAfter(lastLine(last), memberLines(m, strings.Repeat(" ", dash))...)
+	}
+	return ed.bytes(), nil
+}
+
+// projectMaintainersNode returns the members sequence of the first
+// project-maintainers team of the entry with the given project_id.
+func projectMaintainersNode(root *yaml.Node, projectID string) *yaml.Node {
+	entries := mappingValue(root, "maintainers")
+	if entries == nil || entries.Kind != yaml.SequenceNode {
+		return nil
+	}
+	for _, entry := range entries.Content {
+		if id := mappingValue(entry, "project_id"); id == nil || id.Value != projectID {
+			continue
+		}
+		teams := mappingValue(entry, "teams")
+		if teams == nil || teams.Kind != yaml.SequenceNode {
+			return nil
+		}
+		for _, team := range teams.Content {
+			if name := mappingValue(team, "name"); name != nil && name.Value == "project-maintainers" {
+				if members := mappingValue(team, "members"); members != nil && members.Kind == yaml.SequenceNode {
+					return members
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// memberNodeHandle returns the normalized handle of a members list item in
+// either form.
+func memberNodeHandle(n *yaml.Node) string {
+	v := n
+	if n.Kind == yaml.MappingNode {
+		v = mappingValue(n, "handle")
+	}
+	if v == nil || v.Kind != yaml.ScalarNode {
+		return ""
+	}
+	return strings.TrimPrefix(strings.TrimSpace(v.Value), "@")
+}
+
+// memberLines renders a members list item with its dash at indent, as a
+// plain handle when m has no details.
+func memberLines(m TeamMember, indent string) []string {
+	if m.isHandleOnly() {
+		return []string{indent + "- " + renderScalar(m.Handle, 0)}
+	}
+	lines := []string{indent + "- handle: " + renderScalar(m.Handle, 0)}
+	for _, f := range [][2]string{{"name", m.Name}, {"email", m.Email}, {"company", m.Company}, {"role", m.Role}} {
+		if f[1] != "" {
+			lines = append(lines, indent+"  "+f[0]+": "+renderScalar(f[1], 0))
+		}
+	}
+	return lines
+}
+
+// PatchFoundationCSV applies the maintainers.yaml side of a reconciliation to
+// the foundation CSV: members only in the YAML get a new row at the end of
+// the project's last matched block, rows for members only in the CSV are
+// removed, and differing fields take the YAML value. Rows that do not change
+// are kept byte-for-byte.
+func PatchFoundationCSV(content []byte, r MaintainerReconciliation) ([]byte, error) {
+	csvFile, err := scanFoundationCSV(content)
+	if err != nil {
+		return nil, err
+	}
+	cols := csvFile.cols
+	matched := make(map[int]bool)
+	labels := make(map[string]bool)
+	for _, l := range r.CSVBlocks {
+		labels[l] = true
+	}
+	for i, b := range csvFile.blocks {
+		if labels[b.Project] {
+			matched[i] = true
+		}
+	}
+	if len(matched) == 0 {
+		return nil, fmt.Errorf("no block in the CSV matches %s", strings.Join(r.CSVBlocks, "; "))
+	}
+
+	remove := make(map[string]bool)
+	for _, m := range r.OnlyInCSV {
+		remove[strings.ToLower(m.Handle)] = true
+	}
+	updates := make(map[string][]MaintainerFieldDiff)
+	for _, d := range r.Differences {
+		if d.Field == "email" && cols.email < 0 {
+			continue
+		}
+		updates[strings.ToLower(d.Handle)] = append(updates[strings.ToLower(d.Handle)], d)
+	}
+
+	rows := csvFile.rows
+	lastMatched := -1
+	var out []foundationCSVRow
+	for i := 0; i < len(rows); i++ {
+		row := rows[i]
+		if row.block < 0 || !matched[row.block] {
+			out = append(out, row)
+			continue
+		}
+		handle := strings.ToLower(cols.member(row.record).Handle)
+		if remove[handle] {
+			// Carry the block's project label over to the next row so the
+			// forward-filled columns still line up.
+			if field(row.record, csvColProject) != "" && i+1 < len(rows) && rows[i+1].block == row.block && field(rows[i+1].record, csvColProject) == "" {
+				next := withColumns(rows[i+1].record, map[int]string{
+					csvColStatus:  field(row.record, csvColStatus),
+					csvColProject: field(row.record, csvColProject),
+				})
+				rows[i+1] = foundationCSVRow{raw: encodeCSVRecord(next, rows[i+1].raw), record: next, block: row.block}
+			}
+			continue
+		}
+		if diffs := updates[handle]; len(diffs) > 0 {
+			set := make(map[int]string)
+			for _, d := range diffs {
+				if d.Field == "company" {
+					set[cols.company] = d.YAML
+				} else {
+					set[cols.email] = d.YAML
+				}
+			}
+			record := withColumns(row.record, set)
+			row = foundationCSVRow{raw: encodeCSVRecord(record, row.raw), record: record, block: row.block}
+		}
+		out = append(out, row)
+		lastMatched = len(out) - 1
+	}
+
+	if len(r.OnlyInYAML) > 0 {
+		if lastMatched < 0 {
+			return nil, fmt.Errorf("no row left in the CSV for %s", strings.Join(r.CSVBlocks, "; "))
+		}
+		width := len(rows[0].record)
+		var added []foundationCSVRow
+		for _, m := range r.OnlyInYAML {
+			set := map[int]string{cols.handle: m.Handle, cols.name: m.Name, cols.company: m.Company}
+			if cols.email >= 0 {
+				set[cols.email] = m.Email
+			}
+			if cols.role >= 0 {
+				set[cols.role] = m.Role
+			}
+			record := withColumns(make([]string, width), set)
+			added = append(added, foundationCSVRow{raw: encodeCSVRecord(record, out[lastMatched].raw), record: record})
+		}
+		if !strings.HasSuffix(out[lastMatched].raw, "\n") {
+			out[lastMatched].raw += "\n"
+		}
+		out = append(out[:lastMatched+1], append(added, out[lastMatched+1:]...)...)
+	}
+
+	var b strings.Builder
+	for _, row := range out {
+		b.WriteString(row.raw)
+	}
+	return []byte(b.String()), nil
+}
+
+// withColumns returns a copy of record with the given columns set, growing
+// the record if needed. Negative column indices are ignored.
+func withColumns(record []string, set map[int]string) []string {
+	out := append([]string(nil), record...)
+	for i := range set {
+		if i < 0 {
+			continue
+		}
+		for len(out) <= i {
+			out = append(out, "")
+		}
+		out[i] = set[i]
+	}
+	return out
+}
+
+// encodeCSVRecord encodes one record, ending it like like (CRLF, LF, or
+// nothing at end of file).
+func encodeCSVRecord(record []string, like string) string {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.UseCRLF = strings.HasSuffix(like, "\r\n")
+	_ = w.Write(record)
+	w.Flush()
+	if !strings.HasSuffix(like, "\n") {
+		return strings.TrimRight(buf.String(), "\r\n")
+	}
+	return buf.String()
+}
@@ -0,0 +1,241 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+const reconcileCSV = `,Project,Maintainer Name,Company,Github Name,OWNERS/MAINTAINERS
+Graduated,Envoy,Alyssa Wilk,Google,alyssawilk,
+,,Matt Klein,Lyft,mattklein123,
+,,Harvey Tuch,Google,htuch,
+Graduated,Linkerd,William Morgan,Buoyant,wmorgan,
+`
+
+const reconcileYAML = `maintainers:
+  - project_id: envoy
+    org: envoyproxy
+    teams:
+      - name: project-maintainers
+        # keep this comment
+        members:
+          - alyssawilk
+          - handle: MattKlein123
+            company: Microsoft
+          - oldmaintainer
+`
+
+func reconcileSample(t *testing.T) (MaintainerReconciliation, []byte) {
+	t.Helper()
+	var config MaintainersConfig
+	if err := yaml.Unmarshal([]byte(reconcileYAML), &config); err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := ParseFoundationMaintainersCSV([]byte(reconcileCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := ReconcileMaintainers(config.Maintainers[0], blocks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r, []byte(reconcileYAML)
+}
+
+func TestReconcileMaintainers(t *testing.T) {
+	r, _ := reconcileSample(t)
+	if r.InSync() {
+		t.Fatal("expected the sample to be out of sync")
+	}
+	if len(r.CSVBlocks) != 1 || r.CSVBlocks[0] != "Envoy" {
+		t.Errorf("CSVBlocks = %v", r.CSVBlocks)
+	}
+	if len(r.OnlyInCSV) != 1 || r.OnlyInCSV[0].Handle != "htuch" || r.OnlyInCSV[0].Company != "Google" {
+		t.Errorf("OnlyInCSV = %+v", r.OnlyInCSV)
+	}
+	if len(r.OnlyInYAML) != 1 || r.OnlyInYAML[0].Handle != "oldmaintainer" {
+		t.Errorf("OnlyInYAML = %+v", r.OnlyInYAML)
+	}
+	want := MaintainerFieldDiff{Handle: "MattKlein123", Field: "company", YAML: "Microsoft", CSV: "Lyft"}
+	if len(r.Differences) != 1 || r.Differences[0] != want {
+		t.Errorf("Differences = %+v, want %+v", r.Differences, want)
+	}
+
+	if _, err := ReconcileMaintainers(MaintainerEntry{ProjectID: "unknown"}, nil); err == nil {
+		t.Error("expected an error when no CSV block matches")
+	}
+}
+
+func TestPatchMaintainersYAML(t *testing.T) {
+	r, content := reconcileSample(t)
+	patched, err := PatchMaintainersYAML(content, r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(patched)
+	for _, want := range []string{"# keep this comment", "company: Lyft", "handle: htuch", "company: Google"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("patched YAML missing %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "oldmaintainer") {
+		t.Errorf("patched YAML still lists oldmaintainer:\n%s", got)
+	}
+
+	// Re-reconciling the patched file against the same CSV finds nothing.
+	var config MaintainersConfig
+	if err := yaml.Unmarshal(patched, &config); err != nil {
+		t.Fatal(err)
+	}
+	blocks, _ := ParseFoundationMaintainersCSV([]byte(reconcileCSV))
+	again, err := ReconcileMaintainers(config.Maintainers[0], blocks)
+	if err != nil || !again.InSync() {
+		t.Errorf("patched YAML not in sync: %+v, %v", again, err)
+	}
+}
+
+func TestPatchMaintainersYAMLOnlyTouchesReconciledMembers(t *testing.T) {
+	r, _ := reconcileSample(t)
+	content := `# Maintainers of Envoy
+
+maintainers:
+  - project_id: envoy
+    org: envoyproxy   # GitHub org
+
+    teams:
+      - name: project-maintainers
+        members:
+          - alyssawilk        # lead
+          - handle: MattKlein123
+            company: Microsoft
+          - oldmaintainer     # emeritus soon
+
+      - name: reviewers
+        members: [someone]
+`
+	want := `# Maintainers of Envoy
+
+maintainers:
+  - project_id: envoy
+    org: envoyproxy   # GitHub org
+
+    teams:
+      - name: project-maintainers
+        members:
+          - alyssawilk        # lead
+          - handle: MattKlein123
+            company: Lyft
+          - handle: htuch
+            name: Harvey Tuch
+            company: Google
+
+      - name: reviewers
+        members: [someone]
+`
+	patched, err := PatchMaintainersYAML([]byte(content), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(patched) != want {
+		t.Errorf("patch touched more than the reconciled members:\n%s", UnifiedDiff("want", "got", []byte(want), patched))
+	}
+}
+
+func TestPatchFoundationCSV(t *testing.T) {
+	r, _ := reconcileSample(t)
+	patched, err := PatchFoundationCSV([]byte(reconcileCSV), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `,Project,Maintainer Name,Company,Github Name,OWNERS/MAINTAINERS
+Graduated,Envoy,Alyssa Wilk,Google,alyssawilk,
+,,Matt Klein,Microsoft,mattklein123,
+,,,,oldmaintainer,
+Graduated,Linkerd,William Morgan,Buoyant,wmorgan,
+`
+	if string(patched) != want {
+		t.Errorf("patched CSV =\n%s\nwant\n%s", patched, want)
+	}
+}
+
+func TestPatchFoundationCSVCarriesProjectLabel(t *testing.T) {
+	r := MaintainerReconciliation{ProjectID: "envoy", CSVBlocks: []string{"Envoy"}, OnlyInCSV: []TeamMember{{Handle: "alyssawilk"}}}
+	patched, err := PatchFoundationCSV([]byte(strings.ReplaceAll(reconcileCSV, "\n", "\r\n")), r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blocks, err := ParseFoundationMaintainersCSV(patched)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if blocks[0].Project != "Envoy" || blocks[0].Status != "Graduated" || strings.Join(blocks[0].Handles, ",") != "mattklein123,htuch" {
+		t.Errorf("blocks[0] = %+v", blocks[0])
+	}
+	if !strings.Contains(string(patched), "Graduated,Envoy,Matt Klein,Lyft,mattklein123,\r\n") {
+		t.Errorf("patched CSV lost CRLF or label:\n%q", patched)
+	}
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	if d := UnifiedDiff("a", "b", []byte("x\n"), []byte("x\n")); d != "" {
+		t.Errorf("diff of equal input = %q", d)
+	}
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\n3\n4\nfive\n6\n7\n8\n9\n10\neleven\n"
+	want := `--- a/f
++++ b/f
+@@ -2,9 +2,10 @@
+ 2
+ 3
+ 4
+-5
++five
+ 6
+ 7
+ 8
+ 9
+ 10
++eleven
+`
+	if got := UnifiedDiff("a/f", "b/f", []byte(a), []byte(b)); got != want {
+		t.Errorf("UnifiedDiff =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestDiffLinesLargeInput(t *testing.T) {
+	var a []string
+	for i := 0; i < 20000; i++ {
+		a = append(a, fmt.Sprintf(",,Maintainer %d,Company,handle%d,\n", i, i))
+	}
+	b := append([]string(nil), a...)
+	b[10] = ",,Changed,Company,changed,\n"
+	b = append(b[:15000], b[15001:]...)
+	b = append(b, ",,New,Company,new,\n")
+
+	ops := diffLines(a, b)
+	var removed, added []string
+	var oldText, newText []string
+	for _, op := range ops {
+		switch op.kind {
+		case '-':
+			removed = append(removed, op.text)
+		case '+':
+			added = append(added, op.text)
+		}
+		if op.kind != '+' {
+			oldText = append(oldText, op.text)
+		}
+		if op.kind != '-' {
+			newText = append(newText, op.text)
+		}
+	}
+	if len(removed) != 2 || len(added) != 2 {
+		t.Errorf("removed %q, added %q; want two of each", removed, added)
+	}
+	if strings.Join(oldText, "") != strings.Join(a, "") || strings.Join(newText, "") != strings.Join(b, "") {
+		t.Error("diff does not reproduce its inputs")
+	}
+}
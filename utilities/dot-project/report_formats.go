@@ -0,0 +1,250 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolName  = "dot-project-validator"
+	sarifToolURI   = "https://github.com/cncf/automation/tree/main/utilities/dot-project"
+)
+
+// annotatedFile groups the diagnostics reported against a single source file.
+type annotatedFile struct {
+	path  string
+	diags []Diagnostic
+}
+
+// projectAnnotatedFiles converts project results into per-file diagnostics.
+func projectAnnotatedFiles(results []ValidationResult) []annotatedFile {
+	files := make([]annotatedFile, 0, len(results))
+	for _, r := range results {
+		files = append(files, annotatedFile{
+			path:  annotationPath(r.URL),
+			diags: reportDiagnostics(r.Errors, r.Diagnostics),
+		})
+	}
+	return files
+}
+
+// maintainerAnnotatedFiles converts maintainer results into per-file diagnostics.
+func maintainerAnnotatedFiles(results []MaintainerValidationResult) []annotatedFile {
+	files := make([]annotatedFile, 0, len(results))
+	for _, r := range results {
+		files = append(files, annotatedFile{
+			path:  annotationPath(r.File),
+			diags: reportDiagnostics(r.Errors, r.Diagnostics),
+		})
+	}
+	return files
+}
+
+// annotationPath turns a result URL into the path GitHub expects in
+// annotations: file:// URLs and absolute paths become relative to
+// GITHUB_WORKSPACE (or the working directory) when they live beneath it.
+// Remote URLs are returned unchanged.
+func annotationPath(raw string) string {
+	if isHTTPURL(raw) {
+		return raw
+	}
+	p := strings.TrimPrefix(raw, "file://")
+	if !filepath.IsAbs(p) {
+		return filepath.ToSlash(p)
+	}
+	base := os.Getenv("GITHUB_WORKSPACE")
+	if base == "" {
+		base, _ = os.Getwd()
+	}
+	if base != "" {
+		if rel, err := filepath.Rel(base, p); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.ToSlash(rel)
+		}
+	}
+	return filepath.ToSlash(p)
+}
+
+// --- GitHub Actions workflow commands ---
+
+// formatGitHubAnnotations renders diagnostics as GitHub Actions workflow
+// commands (::error file=...,line=...::message) followed by a plain summary.
+func formatGitHubAnnotations(files []annotatedFile, kind string) string {
+	var b strings.Builder
+	var errCount, warnCount int
+	for _, f := range files {
+		for _, d := range f.diags {
+			command := "error"
+			switch d.Severity {
+			case SeverityWarning:
+				command = "warning"
+				warnCount++
+			case SeverityInfo:
+				command = "notice"
+			default:
+				errCount++
+			}
+
+			props := []string{}
+			if f.path != "" {
+				props = append(props, "file="+escapeGitHubProperty(f.path))
+			}
+			if d.Line > 0 {
+				props = append(props, fmt.Sprintf("line=%d", d.Line))
+				if d.Column > 0 {
+					props = append(props, fmt.Sprintf("col=%d", d.Column))
+				}
+			}
+			if d.Rule != "" {
+				props = append(props, "title="+escapeGitHubProperty(d.Rule))
+			}
+
+			msg := d.Message
+			if d.Fix != "" {
+				msg += "\nfix: " + d.Fix
+			}
+			b.WriteString(fmt.Sprintf("::%s %s::%s\n", command, strings.Join(props, ","), escapeGitHubData(msg)))
+		}
+	}
+	b.WriteString(fmt.Sprintf("Summary: %d %s validated, %d errors, %d warnings\n", len(files), kind, errCount, warnCount))
+	return b.String()
+}
+
+// escapeGitHubData escapes a workflow command message.
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+// escapeGitHubProperty escapes a workflow command property value.
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
+
+// --- SARIF 2.1.0 ---
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID string `json:"id"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId,omitempty"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+// FormatSARIF renders project and maintainer results as a single SARIF 2.1.0
+// log, suitable for upload to GitHub code scanning. Either slice may be nil.
+func FormatSARIF(projectResults []ValidationResult, maintainerResults []MaintainerValidationResult) (string, error) {
+	files := append(projectAnnotatedFiles(projectResults), maintainerAnnotatedFiles(maintainerResults)...)
+
+	ruleSet := make(map[string]bool)
+	results := []sarifResult{}
+	for _, f := range files {
+		for _, d := range f.diags {
+			res := sarifResult{
+				RuleID:  d.Rule,
+				Level:   sarifLevel(d.Severity),
+				Message: sarifMessage{Text: d.Message},
+			}
+			if d.Fix != "" {
+				res.Message.Text += " (fix: " + d.Fix + ")"
+			}
+			if f.path != "" {
+				loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: f.path},
+				}}
+				if d.Line > 0 {
+					loc.PhysicalLocation.Region = &sarifRegion{StartLine: d.Line, StartColumn: d.Column}
+				}
+				res.Locations = []sarifLocation{loc}
+			}
+			if d.Rule != "" {
+				ruleSet[d.Rule] = true
+			}
+			results = append(results, res)
+		}
+	}
+
+	rules := []sarifRule{}
+	for _, id := range sortedKeys(ruleSet) {
+		rules = append(rules, sarifRule{ID: id})
+	}
+
+	doc := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchemaURI,
+		Runs: []sarifRun{{
+			Tool:    sarifTool{Driver: sarifDriver{Name: sarifToolName, InformationURI: sarifToolURI, Rules: rules}},
+			Results: results,
+		}},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data) + "\n", nil
+}
+
+// sarifLevel maps a diagnostic severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
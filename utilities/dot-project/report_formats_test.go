@@ -0,0 +1,126 @@
+package projects
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatResultsGitHub(t *testing.T) {
+	workspace := t.TempDir()
+	t.Setenv("GITHUB_WORKSPACE", workspace)
+
+	pv := newTestValidator(t)
+	results := []ValidationResult{{
+		URL:   "file://" + filepath.Join(workspace, "project.yaml"),
+		Valid: false,
+		Diagnostics: []Diagnostic{
+			{Path: "slug", Rule: RuleSlugFormat, Severity: SeverityError, Message: "slug must be lowercase, got: A,B", Line: 2, Column: 7, Fix: `use slug "a-b"`},
+			{Path: "audits", Rule: "audit-freshness", Severity: SeverityWarning, Message: "audit is 100% stale"},
+		},
+	}}
+
+	out, err := pv.FormatResults(results, "github")
+	if err != nil {
+		t.Fatalf("FormatResults github: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected 2 annotations and a summary, got:\n%s", out)
+	}
+	wantErr := `::error file=project.yaml,line=2,col=7,title=slug-format::slug must be lowercase, got: A,B%0Afix: use slug "a-b"`
+	if lines[0] != wantErr {
+		t.Errorf("error annotation:\n got %s\nwant %s", lines[0], wantErr)
+	}
+	wantWarn := `::warning file=project.yaml,title=audit-freshness::audit is 100%25 stale`
+	if lines[1] != wantWarn {
+		t.Errorf("warning annotation:\n got %s\nwant %s", lines[1], wantWarn)
+	}
+	if !strings.Contains(lines[2], "1 errors, 1 warnings") {
+		t.Errorf("summary = %q", lines[2])
+	}
+}
+
+func TestFormatMaintainersResultsGitHubFallsBackToErrors(t *testing.T) {
+	pv := newTestValidator(t)
+	out, err := pv.FormatMaintainersResults([]MaintainerValidationResult{{
+		ProjectID: "p",
+		File:      "maintainers.yaml",
+		Errors:    []string{"team 'project-maintainers' is required"},
+	}}, "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(out, "::error file=maintainers.yaml::team 'project-maintainers' is required\n") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
+
+func TestFormatSARIF(t *testing.T) {
+	projectResults := []ValidationResult{{
+		URL: "project.yaml",
+		Diagnostics: []Diagnostic{
+			{Path: "name", Rule: RuleRequired, Severity: SeverityError, Message: "name is required", Line: 1, Column: 1},
+			{Path: "audits", Rule: "audit-freshness", Severity: SeverityWarning, Message: "stale audit"},
+		},
+	}}
+	maintainerResults := []MaintainerValidationResult{{
+		File:        "maintainers.yaml",
+		Diagnostics: []Diagnostic{{Rule: RuleRequiredTeam, Severity: SeverityError, Message: "team 'project-maintainers' is required", Line: 3}},
+	}}
+
+	out, err := FormatSARIF(projectResults, maintainerResults)
+	if err != nil {
+		t.Fatalf("FormatSARIF: %v", err)
+	}
+
+	var doc sarifLog
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if doc.Version != "2.1.0" || len(doc.Runs) != 1 {
+		t.Fatalf("unexpected SARIF envelope: %+v", doc)
+	}
+	run := doc.Runs[0]
+	if len(run.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(run.Results))
+	}
+	if len(run.Tool.Driver.Rules) != 3 {
+		t.Errorf("expected 3 distinct rules, got %+v", run.Tool.Driver.Rules)
+	}
+
+	first := run.Results[0]
+	if first.Level != "error" || first.RuleID != RuleRequired {
+		t.Errorf("first result = %+v", first)
+	}
+	loc := first.Locations[0].PhysicalLocation
+	if loc.ArtifactLocation.URI != "project.yaml" || loc.Region == nil || loc.Region.StartLine != 1 {
+		t.Errorf("first location = %+v", loc)
+	}
+	if run.Results[1].Level != "warning" || run.Results[1].Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("warning without line should have no region: %+v", run.Results[1])
+	}
+	if run.Results[2].Locations[0].PhysicalLocation.ArtifactLocation.URI != "maintainers.yaml" {
+		t.Errorf("maintainer result location = %+v", run.Results[2].Locations)
+	}
+}
+
+func TestAnnotationPath(t *testing.T) {
+	workspace := t.TempDir()
+	t.Setenv("GITHUB_WORKSPACE", workspace)
+
+	tests := []struct {
+		in, want string
+	}{
+		{"file://" + filepath.Join(workspace, "sub", "project.yaml"), "sub/project.yaml"},
+		{"project.yaml", "project.yaml"},
+		{"https://example.com/project.yaml", "https://example.com/project.yaml"},
+		{"/elsewhere/project.yaml", "/elsewhere/project.yaml"},
+	}
+	for _, tt := range tests {
+		if got := annotationPath(tt.in); got != tt.want {
+			t.Errorf("annotationPath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
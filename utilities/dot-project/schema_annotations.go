@@ -0,0 +1,122 @@
+package projects
+
+// projectSchemaAnnotations returns the descriptions and constraints layered
+// on top of the reflected project schema. Keys are "Type" or "Type.field"
+// (see SchemaAnnotation). It is a function rather than a variable so that
+// registry-driven values such as the schema_version enum are current.
+func projectSchemaAnnotations() map[string]SchemaAnnotation {
+	return map[string]SchemaAnnotation{
+		// Project
+		"Project.schema_version": {Description: "Schema version", Enum: append([]string(nil), SupportedSchemaVersions...)},
+		"Project.slug":           {Description: "Unique project identifier", Pattern: "^[a-z0-9][a-z0-9-]*[a-z0-9]$|^[a-z0-9]$", NonEmpty: true},
+		"Project.name":           {Description: "Project display name", NonEmpty: true},
+		"Project.description":    {Description: "One-line project description", NonEmpty: true},
+		"Project.type":           {Description: "Project type (e.g., project, platform, specification)"},
+		"Project.project_lead": {
+			Description: "One or more GitHub handles or team references (org/team-name) for the project lead(s). Accepts a plain string (single lead) or a list (multiple leads).",
+		},
+		"Project.slack_channels": {Description: "CNCF Slack channels (one or more). Mark the channel most end-users should join with primary: true."},
+		"Project.maturity_log":   {Description: "Maturity phase transition history (chronological order)", NonEmpty: true},
+		"Project.repositories": {
+			Description: "Repositories. Each entry is a plain URL string or an object with url and optional tags and primary flag.",
+			NonEmpty:    true,
+		},
+		"Project.website":       {Description: "Project website URL", Format: "uri", Optional: true},
+		"Project.artwork":       {Description: "Artwork/logo URL", Format: "uri", Optional: true},
+		"Project.social":        {Description: "Social platform URLs", Format: "uri", Optional: true},
+		"Project.mailing_lists": {Description: "Mailing list addresses", Optional: true},
+		"Project.audits":        {Description: "Security/performance audits", Optional: true},
+		"Project.adopters":      {Description: "Link to ADOPTERS.md or adopters list"},
+		"Project.package_managers": {
+			Description: "Registry identifiers. Each key is a registry name (e.g., docker, npm, fedora). Each value is a single identifier string or a list of identifiers (e.g., multiple Docker images).",
+		},
+		"Project.security":      {Description: "Security configuration"},
+		"Project.governance":    {Description: "Governance configuration"},
+		"Project.legal":         {Description: "Legal configuration"},
+		"Project.documentation": {Description: "Documentation configuration"},
+		"Project.landscape":     {Description: "CNCF Landscape location"},
+		"Project.subprojects":   {Description: "Structured sub-project entries for large projects", Optional: true},
+
+		// RepositoryEntry
+		"RepositoryEntry.url":     {Description: "Repository URL", Format: "uri"},
+		"RepositoryEntry.tags":    {Description: "Labels for grouping repositories (e.g., core, sig-apps)"},
+		"RepositoryEntry.primary": {Description: "Whether this is the main project repository"},
+
+		// MaturityEntry
+		"MaturityEntry.phase": {Enum: []string{"sandbox", "incubating", "graduated", "archived"}},
+		"MaturityEntry.issue": {Description: "TOC issue URL", NonEmpty: true},
+
+		// Audit
+		"Audit.type": {Description: "Audit type (e.g., security, performance)", NonEmpty: true},
+		"Audit.url":  {Format: "uri"},
+
+		// PathRef
+		"PathRef.path": {Description: "File path or URL", NonEmpty: true},
+
+		// SlackChannel
+		"SlackChannel.workspace": {Description: "Slack workspace identifier (e.g., cncf)"},
+		"SlackChannel.link":      {Description: "Invite or channel URL", Format: "uri"},
+		"SlackChannel.name":      {Description: "Channel name (e.g., #kubernetes-dev)", Pattern: "^#", NonEmpty: true},
+		"SlackChannel.primary":   {Description: "Whether this is the primary channel for end-users"},
+
+		// Subproject
+		"Subproject.name":           {Description: "Subproject display name", NonEmpty: true},
+		"Subproject.slug":           {Description: "Subproject identifier", Pattern: "^[a-z0-9][a-z0-9-]*[a-z0-9]$|^[a-z0-9]$"},
+		"Subproject.description":    {Description: "One-line subproject description"},
+		"Subproject.website":        {Description: "Subproject website URL", Format: "uri"},
+		"Subproject.repositories":   {Description: "Subproject repositories, in the same forms as the project's", NonEmpty: true},
+		"Subproject.project_lead":   {Description: "GitHub handles or team references (org/team-name) for the subproject lead(s)"},
+		"Subproject.slack_channels": {Description: "Slack channels for the subproject"},
+		"Subproject.maturity":       {Description: "Subproject maturity phase; defaults to the project's current phase", Enum: []string{"sandbox", "incubating", "graduated", "archived"}},
+
+		// SecurityConfig / SecurityContact
+		"SecurityConfig.policy":  {Optional: true},
+		"SecurityConfig.contact": {Description: "Security contact information"},
+		"SecurityContact": {
+			Description:   "Security contact information. At least one of email or advisory_url must be provided.",
+			AnyOfRequired: [][]string{{"email"}, {"advisory_url"}},
+		},
+		"SecurityContact.email": {Description: "Security contact email address", Format: "email"},
+		"SecurityContact.advisory_url": {
+			Description: "GitHub Security Advisory URL",
+			Format:      "uri",
+			Pattern:     githubAdvisoryURLPattern.String(),
+		},
+
+		// GovernanceConfig
+		"GovernanceConfig.vendor_neutrality_statement": {Description: "Vendor neutrality statement"},
+		"GovernanceConfig.decision_making_process":     {Description: "Decision-making process documentation"},
+		"GovernanceConfig.roles_and_teams":             {Description: "Roles and teams documentation"},
+		"GovernanceConfig.code_of_conduct":             {Description: "Code of conduct"},
+		"GovernanceConfig.sub_project_list":            {Description: "Subproject listing"},
+		"GovernanceConfig.sub_project_docs":            {Description: "Subproject documentation"},
+		"GovernanceConfig.contributor_ladder":          {Description: "Contributor ladder documentation"},
+		"GovernanceConfig.change_process":              {Description: "Change process documentation"},
+		"GovernanceConfig.comms_channels":              {Description: "Communication channels listing"},
+		"GovernanceConfig.community_calendar":          {Description: "Community calendar"},
+		"GovernanceConfig.contributor_guide":           {Description: "Contributor guide"},
+		"GovernanceConfig.maintainer_lifecycle":        {Description: "Maintainer lifecycle documentation"},
+
+		// MaintainerLifecycle
+		"MaintainerLifecycle":                    {Description: "Maintainer lifecycle documentation"},
+		"MaintainerLifecycle.onboarding_doc":     {Description: "URL to maintainer onboarding documentation"},
+		"MaintainerLifecycle.progression_ladder": {Description: "URL to maintainer advancement path documentation (committer → maintainer → lead)"},
+		"MaintainerLifecycle.mentoring_program":  {Description: "URLs to mentoring and onboarding support program documentation", Format: "uri"},
+		"MaintainerLifecycle.offboarding_policy": {Description: "URL to emeritus/offboarding policy documentation"},
+
+		// LegalConfig / IdentityType
+		"LegalConfig.identity_type": {Description: "Contributor identity agreement (DCO, CLA, or none)"},
+		"IdentityType": {
+			Description: "Contributor identity agreements. DCO can be used alone or with CLA. CLA requires DCO unless cla_only is true.",
+		},
+		"IdentityType.has_dco":  {Description: "Whether the project uses DCO", Optional: true},
+		"IdentityType.has_cla":  {Description: "Whether the project uses CLA (requires DCO unless cla_only is true)", Optional: true},
+		"IdentityType.cla_only": {Description: "Exception: allows CLA without DCO for projects with special approval"},
+		"IdentityType.dco_url":  {Description: "Link to DCO document"},
+		"IdentityType.cla_url":  {Description: "Link to CLA document"},
+
+		// LandscapeConfig
+		"LandscapeConfig.category":    {Description: "CNCF Landscape category", NonEmpty: true},
+		"LandscapeConfig.subcategory": {Description: "CNCF Landscape subcategory", NonEmpty: true},
+	}
+}
@@ -0,0 +1,434 @@
+package projects
+
+import (
+	_ "embed"
+	"fmt"
+	"log"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// publishedProjectSchema is the schema editors and the GitHub workflow use.
+// The validator checks projects against this file, not against the Go types
+// it was generated from, so a stale checked-in schema shows up at runtime.
+//
+//go:embed schema/project.schema.json
+var publishedProjectSchema []byte
+
+// PublishedProjectSchema returns the bundled schema/project.schema.json.
+func PublishedProjectSchema() (*JSONSchema, error) {
+	return LoadJSONSchema(publishedProjectSchema)
+}
+
+// schemaIssueURL is where schema/Go disagreements should be reported.
+const schemaIssueURL = "https://github.com/cncf/automation/issues"
+
+// ValidateAgainstSchema checks a parsed YAML document against a JSON Schema
+// and returns one error diagnostic per violation, using the same field paths
+// as the Go rules and positioned at the offending node.
+//
+// It implements the subset of draft 2020-12 the project schema uses: type,
+// enum, pattern, format (uri, email, date-time), minLength, minItems,
+// required, properties, additionalProperties, items, oneOf, anyOf and local
+// $ref. YAML timestamps count as strings, as they would in JSON.
+func ValidateAgainstSchema(schema *JSONSchema, doc *yaml.Node) []Diagnostic {
+	sv := &schemaValidator{root: schema, patterns: make(map[string]*regexp.Regexp)}
+	diags := sv.validate(schema, documentRoot(doc), "")
+	LocateDiagnostics(diags, doc)
+	return diags
+}
+
+// schemaValidator evaluates a schema against yaml.Node trees.
+type schemaValidator struct {
+	root     *JSONSchema
+	patterns map[string]*regexp.Regexp
+}
+
+func (sv *schemaValidator) validate(s *JSONSchema, n *yaml.Node, path string) []Diagnostic {
+	if s == nil || n == nil {
+		return nil
+	}
+	for n.Kind == yaml.AliasNode && n.Alias != nil {
+		n = n.Alias
+	}
+
+	var diags []Diagnostic
+	fail := func(p, format string, args ...interface{}) {
+		diags = append(diags, errorDiag(p, RuleJSONSchema, format, args...))
+	}
+	label := schemaPathLabel(path)
+
+	if s.Ref != "" {
+		target, err := sv.resolve(s.Ref)
+		if err != nil {
+			fail(path, "%s: %v", label, err)
+			return diags
+		}
+		diags = append(diags, sv.validate(target, n, path)...)
+	}
+
+	if s.Type != "" && !yamlNodeHasType(n, s.Type) {
+		fail(path, "%s must be %s, got %s", label, schemaTypeArticle(s.Type), yamlNodeTypeName(n))
+		// Other keywords are meaningless against the wrong kind of value.
+		return diags
+	}
+
+	if len(s.Enum) > 0 && n.Kind == yaml.ScalarNode && !containsString(s.Enum, n.Value) {
+		fail(path, "%s must be one of %v, got %q", label, s.Enum, n.Value)
+	}
+
+	if isYAMLString(n) {
+		if s.MinLength != nil && len([]rune(n.Value)) < *s.MinLength {
+			fail(path, "%s must not be empty", label)
+		} else {
+			if s.Pattern != "" {
+				re, err := sv.pattern(s.Pattern)
+				if err != nil {
+					fail(path, "%s: invalid schema pattern %q: %v", label, s.Pattern, err)
+				} else if !re.MatchString(n.Value) {
+					fail(path, "%s does not match pattern %s: %s", label, s.Pattern, n.Value)
+				}
+			}
+			if s.Format != "" && !validSchemaFormat(s.Format, n.Value) {
+				fail(path, "%s is not a valid %s: %s", label, s.Format, n.Value)
+			}
+		}
+	}
+
+	switch n.Kind {
+	case yaml.SequenceNode:
+		if s.MinItems != nil && len(n.Content) < *s.MinItems {
+			fail(path, "%s must have at least %d item(s)", label, *s.MinItems)
+		}
+		if s.Items != nil {
+			for i, item := range n.Content {
+				diags = append(diags, sv.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	case yaml.MappingNode:
+		present := make(map[string]bool)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			key, value := n.Content[i].Value, n.Content[i+1]
+			present[key] = true
+			childPath := joinSchemaPath(path, key)
+			if prop, ok := s.Properties[key]; ok {
+				diags = append(diags, sv.validate(prop, value, childPath)...)
+				continue
+			}
+			switch extra := s.AdditionalProperties.(type) {
+			case bool:
+				if !extra {
+					fail(childPath, "%s is not an allowed field", schemaPathLabel(childPath))
+				}
+			case *JSONSchema:
+				diags = append(diags, sv.validate(extra, value, childPath)...)
+			}
+		}
+		for _, req := range s.Required {
+			if !present[req] {
+				p := joinSchemaPath(path, req)
+				fail(p, "%s is required", schemaPathLabel(p))
+			}
+		}
+	}
+
+	if len(s.OneOf) > 0 {
+		matched := 0
+		for _, branch := range s.OneOf {
+			if len(sv.validate(branch, n, path)) == 0 {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail(path, "%s must match exactly one allowed form, matched %d", label, matched)
+		}
+	}
+
+	if len(s.AnyOf) > 0 {
+		matched := false
+		for _, branch := range s.AnyOf {
+			if len(sv.validate(branch, n, path)) == 0 {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			if fields := requiredAlternatives(s.AnyOf); fields != nil {
+				fail(path, "%s must have at least one of %s", label, strings.Join(fields, " or "))
+			} else {
+				fail(path, "%s does not match any allowed form", label)
+			}
+		}
+	}
+
+	return diags
+}
+
+// resolve follows a local "#/$defs/Name" reference.
+func (sv *schemaValidator) resolve(ref string) (*JSONSchema, error) {
+	name, ok := strings.CutPrefix(ref, "#/$defs/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported $ref %q", ref)
+	}
+	target, ok := sv.root.Defs[name]
+	if !ok || target == nil {
+		return nil, fmt.Errorf("unresolved $ref %q", ref)
+	}
+	return target, nil
+}
+
+// pattern compiles and caches a schema pattern.
+func (sv *schemaValidator) pattern(expr string) (*regexp.Regexp, error) {
+	if re, ok := sv.patterns[expr]; ok {
+		return re, nil
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, err
+	}
+	sv.patterns[expr] = re
+	return re, nil
+}
+
+// requiredAlternatives returns the field names when every anyOf branch only
+// requires properties, as in "email or advisory_url"; otherwise nil.
+func requiredAlternatives(branches []*JSONSchema) []string {
+	var fields []string
+	for _, b := range branches {
+		if len(b.Required) == 0 || b.Type != "" || b.Ref != "" || len(b.Properties) > 0 {
+			return nil
+		}
+		fields = append(fields, strings.Join(b.Required, " and "))
+	}
+	return fields
+}
+
+// yamlNodeHasType reports whether a YAML node is an instance of a JSON
+// Schema type.
+func yamlNodeHasType(n *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return n.Kind == yaml.MappingNode
+	case "array":
+		return n.Kind == yaml.SequenceNode
+	case "string":
+		return isYAMLString(n)
+	case "boolean":
+		return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!bool"
+	case "integer":
+		return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!int"
+	case "number":
+		return n.Kind == yaml.ScalarNode && (n.ShortTag() == "!!int" || n.ShortTag() == "!!float")
+	case "null":
+		return n.Kind == yaml.ScalarNode && n.ShortTag() == "!!null"
+	}
+	return false
+}
+
+// isYAMLString reports whether n would be a JSON string. Unquoted dates are
+// YAML timestamps but serialize to strings.
+func isYAMLString(n *yaml.Node) bool {
+	if n.Kind != yaml.ScalarNode {
+		return false
+	}
+	tag := n.ShortTag()
+	return tag == "!!str" || tag == "!!timestamp"
+}
+
+// yamlNodeTypeName describes a node in JSON Schema type terms.
+func yamlNodeTypeName(n *yaml.Node) string {
+	switch n.Kind {
+	case yaml.MappingNode:
+		return "object"
+	case yaml.SequenceNode:
+		return "array"
+	}
+	switch n.ShortTag() {
+	case "!!bool":
+		return "boolean"
+	case "!!int":
+		return "integer"
+	case "!!float":
+		return "number"
+	case "!!null":
+		return "null"
+	}
+	return "string"
+}
+
+// schemaTypeArticle returns "a string", "an object", etc.
+func schemaTypeArticle(typ string) string {
+	switch typ {
+	case "object", "array", "integer":
+		return "an " + typ
+	}
+	return "a " + typ
+}
+
+// yamlTimestampLayouts are the timestamp forms YAML accepts for !!timestamp.
+var yamlTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-1-2T15:4:5.999999999Z07:00",
+	"2006-1-2t15:4:5.999999999Z07:00",
+	"2006-1-2 15:4:5.999999999",
+	"2006-1-2",
+}
+
+// validSchemaFormat asserts a format keyword. uri and email use the same
+// checks as the Go rules so the two agree on what a valid value is.
+func validSchemaFormat(format, value string) bool {
+	switch format {
+	case "uri":
+		return isValidURL(value)
+	case "email":
+		_, err := mail.ParseAddress(value)
+		return err == nil
+	case "date-time":
+		for _, layout := range yamlTimestampLayouts {
+			if _, err := time.Parse(layout, value); err == nil {
+				return true
+			}
+		}
+		return false
+	}
+	// Unknown formats are annotations only.
+	return true
+}
+
+// joinSchemaPath appends a mapping key to a diagnostic path.
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// schemaPathLabel names a path in messages, using "document" for the root.
+func schemaPathLabel(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// SetSchema sets the JSON Schema projects are checked against alongside the
+// Go rules. NewValidator uses the bundled schema; nil disables the check.
+func (pv *ProjectValidator) SetSchema(schema *JSONSchema) {
+	pv.schema = schema
+}
+
+// defaultProjectSchema loads the bundled schema for a new validator, logging
+// rather than failing so that a broken schema never blocks validation.
+func defaultProjectSchema() *JSONSchema {
+	schema, err := PublishedProjectSchema()
+	if err != nil {
+		log.Printf("Warning: bundled JSON Schema unusable, schema check disabled: %v", err)
+		return nil
+	}
+	return schema
+}
+
+// checkSchemaAgreement runs the JSON Schema over project content that the Go
+// rules have already validated and returns any disagreements between them.
+func checkSchemaAgreement(schema *JSONSchema, content string, goDiags []Diagnostic) []Diagnostic {
+	doc, err := parseYAMLDocument([]byte(content))
+	if err != nil {
+		return nil
+	}
+	return schemaDisagreements(goDiags, ValidateAgainstSchema(schema, doc))
+}
+
+// schemaBackedRules are the Go rules the JSON Schema also expresses. An
+// error from one of these rules should always coincide with a schema error
+// on the same field; rules such as chronological ordering or single-primary
+// cannot be written in JSON Schema and are not compared.
+var schemaBackedRules = map[string]bool{
+	RuleRequired:         true,
+	RuleSlugFormat:       true,
+	RuleSlackChannelName: true,
+	RuleMaturityPhase:    true,
+	RuleSchemaVersion:    true,
+	RuleURLFormat:        true,
+	RuleSecurityContact:  true,
+	RuleEmailFormat:      true,
+	RuleAdvisoryURL:      true,
+}
+
+// schemaDisagreements compares Go rule diagnostics with JSON Schema
+// diagnostics for the same document. A schema error on a field the Go rules
+// accept, or a schema-backed Go error on a field the schema accepts, means
+// the published schema and the validator have drifted apart; each such case
+// is returned as a warning asking for a bug report.
+func schemaDisagreements(goDiags, schemaDiags []Diagnostic) []Diagnostic {
+	var out []Diagnostic
+	for _, sd := range schemaDiags {
+		if !anyRelatedError(goDiags, sd.Path) {
+			d := Diagnostic{
+				Path:     sd.Path,
+				Rule:     RuleSchemaDisagreement,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("JSON Schema rejects a value the Go rules accept: %s", sd.Message),
+				Line:     sd.Line,
+				Column:   sd.Column,
+			}
+			out = append(out, d.withFix("this is a validator bug; please report it at %s", schemaIssueURL))
+		}
+	}
+	for _, gd := range goDiags {
+		if gd.Severity != SeverityError || !schemaBackedRules[gd.Rule] {
+			continue
+		}
+		if !anyRelatedError(schemaDiags, gd.Path) {
+			d := Diagnostic{
+				Path:     gd.Path,
+				Rule:     RuleSchemaDisagreement,
+				Severity: SeverityWarning,
+				Message:  fmt.Sprintf("Go rules reject a value the JSON Schema accepts (%s): %s", gd.Rule, gd.Message),
+				Line:     gd.Line,
+				Column:   gd.Column,
+			}
+			out = append(out, d.withFix("this is a validator bug; please report it at %s", schemaIssueURL))
+		}
+	}
+	return out
+}
+
+// anyRelatedError reports whether diags holds an error on path, on one of
+// its ancestors or on one of its descendants. The two engines sometimes
+// report the same problem one level apart, e.g. an invalid repository URL
+// at repositories[0] versus repositories[0].url.
+func anyRelatedError(diags []Diagnostic, path string) bool {
+	for _, d := range diags {
+		if d.Severity != SeverityError {
+			continue
+		}
+		if pathsRelated(d.Path, path) {
+			return true
+		}
+	}
+	return false
+}
+
+// pathsRelated reports whether one diagnostic path equals or contains the other.
+func pathsRelated(a, b string) bool {
+	if a == b || a == "" || b == "" {
+		return true
+	}
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	return strings.HasPrefix(b, a+".") || strings.HasPrefix(b, a+"[")
+}
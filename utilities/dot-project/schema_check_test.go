@@ -0,0 +1,178 @@
+package projects
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func publishedSchema(t *testing.T) *JSONSchema {
+	t.Helper()
+	schema, err := PublishedProjectSchema()
+	if err != nil {
+		t.Fatalf("PublishedProjectSchema: %v", err)
+	}
+	return schema
+}
+
+func schemaDiagnosticsFor(t *testing.T, content string) []Diagnostic {
+	t.Helper()
+	doc, err := parseYAMLDocument([]byte(content))
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	return ValidateAgainstSchema(publishedSchema(t), doc)
+}
+
+func TestLoadJSONSchemaAdditionalProperties(t *testing.T) {
+	schema := publishedSchema(t)
+	if schema.AdditionalProperties != false {
+		t.Errorf("top-level additionalProperties = %#v, want false", schema.AdditionalProperties)
+	}
+	if _, ok := schema.Properties["social"].AdditionalProperties.(*JSONSchema); !ok {
+		t.Errorf("social additionalProperties = %#v, want *JSONSchema", schema.Properties["social"].AdditionalProperties)
+	}
+}
+
+func TestValidateAgainstSchemaValid(t *testing.T) {
+	content := validProjectYAML() + `project_lead: [alice, org/team]
+package_managers:
+  docker: [org/image, org/image-arm64]
+  npm: pkg
+security:
+  contact:
+    email: security@example.com
+`
+	if diags := schemaDiagnosticsFor(t, content); len(diags) != 0 {
+		t.Errorf("expected no schema errors, got %v", diags)
+	}
+}
+
+// Each invalid document must be rejected by both engines on the same field,
+// so no disagreement is reported.
+func TestSchemaAgreesWithGoRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		replace [2]string
+		append  string
+		path    string
+	}{
+		{name: "empty name", replace: [2]string{"name: Test Project", `name: ""`}, path: "name"},
+		{name: "bad slug", replace: [2]string{"slug: test-project", "slug: Test_Project"}, path: "slug"},
+		{name: "unsupported version", replace: [2]string{`"1.0.0"`, `"9.9.9"`}, path: "schema_version"},
+		{name: "bad phase", replace: [2]string{"phase: sandbox", "phase: beta"}, path: "maturity_log[0].phase"},
+		{name: "missing date", replace: [2]string{"    date: 2024-01-15\n", ""}, path: "maturity_log[0].date"},
+		{name: "bad repo url", replace: [2]string{"https://github.com/test/repo", "not-a-url"}, path: "repositories[0]"},
+		{name: "slack name", append: "slack_channels:\n  - name: general\n", path: "slack_channels[0].name"},
+		{name: "empty contact", append: "security:\n  contact: {}\n", path: "security.contact"},
+		{name: "bad email", append: "security:\n  contact:\n    email: nope\n", path: "security.contact.email"},
+		{name: "bad social url", append: "social:\n  twitter: twitter.com/x\n", path: "social.twitter"},
+		{name: "empty path", append: "adopters:\n  path: \"\"\n", path: "adopters.path"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := validProjectYAML() + tt.append
+			if tt.replace[0] != "" {
+				content = strings.Replace(content, tt.replace[0], tt.replace[1], 1)
+			}
+			_, goDiags, parsed := validateProjectContent(content)
+			if !parsed {
+				t.Fatalf("content did not parse: %v", goDiags)
+			}
+			if _, ok := findDiagnostic(goDiags, tt.path); !ok {
+				t.Errorf("Go rules: no diagnostic at %s in %v", tt.path, goDiags)
+			}
+			schemaDiags := schemaDiagnosticsFor(t, content)
+			if !anyRelatedError(schemaDiags, tt.path) {
+				t.Errorf("schema: no error related to %s in %v", tt.path, schemaDiags)
+			}
+			if d := schemaDisagreements(goDiags, schemaDiags); len(d) != 0 {
+				t.Errorf("unexpected disagreements: %v", d)
+			}
+		})
+	}
+}
+
+func TestSchemaDisagreements(t *testing.T) {
+	t.Run("schema stricter", func(t *testing.T) {
+		// YAML decodes the integer into Project.Name, but the schema wants a string.
+		content := strings.Replace(validProjectYAML(), "name: Test Project", "name: 123", 1)
+		_, goDiags, _ := validateProjectContent(content)
+		got := checkSchemaAgreement(publishedSchema(t), content, goDiags)
+		if len(got) != 1 {
+			t.Fatalf("expected 1 disagreement, got %v", got)
+		}
+		d := got[0]
+		if d.Path != "name" || d.Rule != RuleSchemaDisagreement || d.Severity != SeverityWarning || d.Line != 3 {
+			t.Errorf("disagreement = %+v", d)
+		}
+		if !strings.Contains(d.Message, "JSON Schema rejects") || !strings.Contains(d.Fix, schemaIssueURL) {
+			t.Errorf("message/fix = %q / %q", d.Message, d.Fix)
+		}
+	})
+
+	t.Run("Go stricter", func(t *testing.T) {
+		// Simulate a stale published schema that lost the slug pattern.
+		schema := publishedSchema(t)
+		schema.Properties["slug"].Pattern = ""
+		content := strings.Replace(validProjectYAML(), "slug: test-project", "slug: Test_Project", 1)
+		_, goDiags, _ := validateProjectContent(content)
+		got := checkSchemaAgreement(schema, content, goDiags)
+		d, ok := findDiagnostic(got, "slug")
+		if !ok || !strings.Contains(d.Message, "Go rules reject") || !strings.Contains(d.Message, RuleSlugFormat) {
+			t.Errorf("expected Go-stricter disagreement on slug, got %v", got)
+		}
+	})
+
+	t.Run("rules outside the schema are ignored", func(t *testing.T) {
+		content := validProjectYAML() + "slack_channels:\n  - name: \"#a\"\n    primary: true\n  - name: \"#b\"\n    primary: true\n"
+		_, goDiags, _ := validateProjectContent(content)
+		if got := checkSchemaAgreement(publishedSchema(t), content, goDiags); len(got) != 0 {
+			t.Errorf("single-primary is not expressible in JSON Schema, got %v", got)
+		}
+	})
+}
+
+func TestValidateProjectReportsSchemaDisagreement(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "project.yaml")
+	writeFile(t, path, strings.Replace(validProjectYAML(), "name: Test Project", "name: 123", 1))
+
+	pv := newTestValidator(t)
+	result, err := pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || len(result.Errors) != 0 {
+		t.Errorf("disagreements must not fail validation: %+v", result)
+	}
+	if _, ok := findDiagnostic(result.Diagnostics, "name"); !ok {
+		t.Errorf("expected disagreement diagnostic, got %v", result.Diagnostics)
+	}
+
+	pv.SetSchema(nil)
+	result, _ = pv.validateProject(path)
+	for _, d := range result.Diagnostics {
+		if d.Rule == RuleSchemaDisagreement {
+			t.Errorf("schema check disabled, got %v", d)
+		}
+	}
+}
+
+func TestPathsRelated(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"repositories[0]", "repositories[0].url", true},
+		{"security.contact.email", "security.contact", true},
+		{"name", "name", true},
+		{"slug", "slack_channels", false},
+		{"repositories[1]", "repositories[10]", false},
+	}
+	for _, tt := range tests {
+		if got := pathsRelated(tt.a, tt.b); got != tt.want {
+			t.Errorf("pathsRelated(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
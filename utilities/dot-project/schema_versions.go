@@ -0,0 +1,204 @@
+package projects
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersionSpec describes one released version of the project.yaml schema.
+//
+// Versions form a chain: each spec's Upgrade migrates a document from the
+// previous registered version to this one, so a file can be brought forward
+// any number of versions by applying the upgrades in order.
+type SchemaVersionSpec struct {
+	Version string // Semantic version, e.g. "1.1.0"
+
+	// Rules are additional checks that apply only to documents declaring this
+	// version, on top of the shared structural validation. May be nil.
+	Rules func(project Project) []Diagnostic
+
+	// Upgrade rewrites the top-level mapping of a document at the previous
+	// version into this version's shape, returning one human-readable line per
+	// change. It must not touch schema_version; the migrator sets that. Steps
+	// may change scalars in place and add or remove mapping pairs and
+	// sequence items; the migrator writes exactly those edits back into the
+	// file. Nil for the first version, and for versions that only add
+	// optional fields.
+	Upgrade func(root *yaml.Node) ([]string, error)
+}
+
+// schemaVersions is the ordered registry of known schema versions, oldest first.
+var schemaVersions = []SchemaVersionSpec{
+	{Version: "1.0.0"},
+}
+
+// RegisterSchemaVersion appends a new schema version to the registry. The
+// version must be valid semver and newer than every registered version.
+func RegisterSchemaVersion(spec SchemaVersionSpec) error {
+	if _, err := parseSemver(spec.Version); err != nil {
+		return err
+	}
+	if latest := LatestSchemaVersion(); compareSemver(spec.Version, latest) <= 0 {
+		return fmt.Errorf("schema version %s must be newer than the latest registered version %s", spec.Version, latest)
+	}
+	schemaVersions = append(schemaVersions, spec)
+	SupportedSchemaVersions = append(SupportedSchemaVersions, spec.Version)
+	return nil
+}
+
+// LatestSchemaVersion returns the newest registered schema version.
+func LatestSchemaVersion() string {
+	return schemaVersions[len(schemaVersions)-1].Version
+}
+
+// lookupSchemaVersion returns the registry index of version, or -1.
+func lookupSchemaVersion(version string) int {
+	for i, s := range schemaVersions {
+		if s.Version == version {
+			return i
+		}
+	}
+	return -1
+}
+
+// schemaVersionDiagnostics runs the version-specific rules for the project's
+// declared schema_version and notes when a newer version is available.
+// Unknown versions are reported by the structural validation, not here.
+func schemaVersionDiagnostics(project Project) []Diagnostic {
+	idx := lookupSchemaVersion(project.SchemaVersion)
+	if idx < 0 {
+		return nil
+	}
+	var diags []Diagnostic
+	if rules := schemaVersions[idx].Rules; rules != nil {
+		diags = append(diags, rules(project)...)
+	}
+	if latest := LatestSchemaVersion(); project.SchemaVersion != latest {
+		diags = append(diags, Diagnostic{
+			Path:     "schema_version",
+			Rule:     RuleSchemaVersion,
+			Severity: SeverityInfo,
+			Message:  fmt.Sprintf("schema_version %s is older than the latest version %s", project.SchemaVersion, latest),
+			Fix:      "run: migrate upgrade -in project.yaml",
+		})
+	}
+	return diags
+}
+
+// MigrationReport summarizes an in-place schema upgrade.
+type MigrationReport struct {
+	From    string   `json:"from" yaml:"from"`
+	To      string   `json:"to" yaml:"to"`
+	Changes []string `json:"changes,omitempty" yaml:"changes,omitempty"`
+}
+
+// UpgradeProjectYAML migrates project.yaml content to the target schema
+// version (the latest when target is empty) by applying every registered
+// upgrade step between the declared version and the target. The steps edit
+// a yaml.Node tree whose changes are then written back into the source text,
+// so everything the steps do not touch keeps its exact bytes, including
+// blank lines and comment alignment. The content is returned unchanged when
+// no migration is needed.
+func UpgradeProjectYAML(content []byte, target string) ([]byte, MigrationReport, error) {
+	if target == "" {
+		target = LatestSchemaVersion()
+	}
+	report := MigrationReport{To: target}
+
+	doc, err := parseYAMLDocument(content)
+	if err != nil {
+		return nil, report, fmt.Errorf("parsing project YAML: %w", err)
+	}
+	root := documentRoot(doc)
+	snapshot := snapshotNodes(root)
+
+	versionNode := mappingValue(root, "schema_version")
+	if versionNode == nil || versionNode.Value == "" {
+		return nil, report, fmt.Errorf("schema_version is missing; cannot determine which upgrades to apply")
+	}
+	report.From = versionNode.Value
+
+	from := lookupSchemaVersion(report.From)
+	if from < 0 {
+		return nil, report, fmt.Errorf("unknown schema_version %s (known: %v)", report.From, SupportedSchemaVersions)
+	}
+	to := lookupSchemaVersion(target)
+	if to < 0 {
+		return nil, report, fmt.Errorf("unknown target schema version %s (known: %v)", target, SupportedSchemaVersions)
+	}
+	if to < from {
+		return nil, report, fmt.Errorf("cannot downgrade from %s to %s", report.From, target)
+	}
+	if to == from {
+		return content, report, nil
+	}
+
+	for _, step := range schemaVersions[from+1 : to+1] {
+		if step.Upgrade == nil {
+			continue
+		}
+		changes, err := step.Upgrade(root)
+		if err != nil {
+			return nil, report, fmt.Errorf("upgrading to %s: %w", step.Version, err)
+		}
+		for _, c := range changes {
+			report.Changes = append(report.Changes, fmt.Sprintf("%s: %s", step.Version, c))
+		}
+	}
+	setMappingScalar(root, "schema_version", target)
+	report.Changes = append(report.Changes, fmt.Sprintf("schema_version: %s -> %s", report.From, target))
+
+	ed := newYAMLEditor(content)
+	if err := ed.applyTreeEdits(root, snapshot, ""); err != nil {
+		return nil, report, fmt.Errorf("writing upgraded YAML: %w", err)
+	}
+	out := ed.bytes()
+	if _, err := parseYAMLDocument(out); err != nil {
+		return nil, report, fmt.Errorf("upgraded YAML does not parse: %w", err)
+	}
+	return out, report, nil
+}
+
+// parseSemver parses a MAJOR.MINOR.PATCH version string.
+func parseSemver(v string) ([3]int, error) {
+	var out [3]int
+	parts := strings.Split(v, ".")
+	if len(parts) != 3 {
+		return out, fmt.Errorf("invalid schema version %q (expected MAJOR.MINOR.PATCH)", v)
+	}
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 {
+			return out, fmt.Errorf("invalid schema version %q (expected MAJOR.MINOR.PATCH)", v)
+		}
+		out[i] = n
+	}
+	return out, nil
+}
+
+// compareSemver returns -1, 0 or 1 comparing a to b. Unparseable versions
+// sort before valid ones.
+func compareSemver(a, b string) int {
+	va, errA := parseSemver(a)
+	vb, errB := parseSemver(b)
+	switch {
+	case errA != nil && errB != nil:
+		return 0
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	}
+	for i := range va {
+		if va[i] != vb[i] {
+			if va[i] < vb[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,241 @@
+package projects
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// withSchemaVersions restores the schema registry after a test registers
+// synthetic versions.
+func withSchemaVersions(t *testing.T) {
+	t.Helper()
+	savedVersions := append([]SchemaVersionSpec(nil), schemaVersions...)
+	savedSupported := append([]string(nil), SupportedSchemaVersions...)
+	t.Cleanup(func() {
+		schemaVersions = savedVersions
+		SupportedSchemaVersions = savedSupported
+	})
+}
+
+func registerTestSchemaVersion(t *testing.T) {
+	t.Helper()
+	err := RegisterSchemaVersion(SchemaVersionSpec{
+		Version: "1.1.0",
+		Upgrade: func(root *yaml.Node) ([]string, error) {
+			if mappingValue(root, "type") == nil {
+				return nil, nil
+			}
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				if root.Content[i].Value == "type" {
+					root.Content[i].Value = "project_type"
+				}
+			}
+			return []string{"renamed type to project_type"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("RegisterSchemaVersion: %v", err)
+	}
+}
+
+func TestRegisterSchemaVersion(t *testing.T) {
+	withSchemaVersions(t)
+
+	if err := RegisterSchemaVersion(SchemaVersionSpec{Version: "1.0.0"}); err == nil {
+		t.Error("expected error registering an existing version")
+	}
+	if err := RegisterSchemaVersion(SchemaVersionSpec{Version: "2"}); err == nil {
+		t.Error("expected error registering an invalid version")
+	}
+	registerTestSchemaVersion(t)
+	if got := LatestSchemaVersion(); got != "1.1.0" {
+		t.Errorf("LatestSchemaVersion = %s, want 1.1.0", got)
+	}
+	if SupportedSchemaVersions[len(SupportedSchemaVersions)-1] != "1.1.0" {
+		t.Errorf("SupportedSchemaVersions not updated: %v", SupportedSchemaVersions)
+	}
+}
+
+func TestUpgradeProjectYAML(t *testing.T) {
+	withSchemaVersions(t)
+	registerTestSchemaVersion(t)
+
+	content := []byte(`# Project metadata
+schema_version: "1.0.0" # bump with migrate upgrade
+slug: test-project
+name: Test Project
+type: app # kept on rename
+`)
+	out, report, err := UpgradeProjectYAML(content, "")
+	if err != nil {
+		t.Fatalf("UpgradeProjectYAML: %v", err)
+	}
+	if report.From != "1.0.0" || report.To != "1.1.0" {
+		t.Errorf("report = %+v", report)
+	}
+	wantChanges := []string{"1.1.0: renamed type to project_type", "schema_version: 1.0.0 -> 1.1.0"}
+	if strings.Join(report.Changes, "|") != strings.Join(wantChanges, "|") {
+		t.Errorf("changes = %v, want %v", report.Changes, wantChanges)
+	}
+
+	got := string(out)
+	for _, want := range []string{
+		"# Project metadata",
+		`schema_version: "1.1.0" # bump with migrate upgrade`,
+		"project_type: app # kept on rename",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("upgraded YAML missing %q:\n%s", want, got)
+		}
+	}
+
+	// Upgrading again is a no-op.
+	again, report, err := UpgradeProjectYAML(out, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(report.Changes) != 0 || string(again) != got {
+		t.Errorf("second upgrade should be a no-op, got %v", report.Changes)
+	}
+}
+
+func TestUpgradeProjectYAMLTemplateRoundTrip(t *testing.T) {
+	withSchemaVersions(t)
+	registerTestSchemaVersion(t)
+
+	content, err := os.ReadFile("template/project.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, _, err := UpgradeProjectYAML(content, "")
+	if err != nil {
+		t.Fatalf("UpgradeProjectYAML: %v", err)
+	}
+
+	want := strings.Replace(string(content), `schema_version: "1.0.0"`, `schema_version: "1.1.0"`, 1)
+	want = strings.Replace(want, "\ntype: ", "\nproject_type: ", 1)
+	if want == string(content) {
+		t.Fatal("template no longer declares schema_version 1.0.0 and type")
+	}
+	if string(out) != want {
+		t.Errorf("upgrade touched more than the migrated keys:\n%s",
+			UnifiedDiff("want", "got", []byte(want), out))
+	}
+}
+
+func TestUpgradeProjectYAMLStructuralEdits(t *testing.T) {
+	withSchemaVersions(t)
+	err := RegisterSchemaVersion(SchemaVersionSpec{
+		Version: "1.1.0",
+		Upgrade: func(root *yaml.Node) ([]string, error) {
+			var kept []*yaml.Node
+			for i := 0; i+1 < len(root.Content); i += 2 {
+				if root.Content[i].Value != "legacy" {
+					kept = append(kept, root.Content[i], root.Content[i+1])
+				}
+			}
+			root.Content = append(kept,
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "added"},
+				&yaml.Node{Kind: yaml.ScalarNode, Value: "yes", Style: yaml.DoubleQuotedStyle},
+			)
+			tags := mappingValue(root, "tags")
+			tags.Content = append(tags.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "new"})
+			return []string{"dropped legacy"}, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content := []byte(`schema_version: 1.0.0
+name: Test   # aligned
+
+# Removed with its comment and body.
+legacy:
+  nested: true
+
+  more: false
+tags:
+  - old  # first
+`)
+	out, _, err := UpgradeProjectYAML(content, "")
+	if err != nil {
+		t.Fatalf("UpgradeProjectYAML: %v", err)
+	}
+	want := `schema_version: 1.1.0
+name: Test   # aligned
+
+tags:
+  - old  # first
+  - new
+added: "yes"
+`
+	if string(out) != want {
+		t.Errorf("upgraded YAML:\n%s", UnifiedDiff("want", "got", []byte(want), out))
+	}
+}
+
+func TestUpgradeProjectYAMLErrors(t *testing.T) {
+	withSchemaVersions(t)
+	registerTestSchemaVersion(t)
+
+	tests := []struct {
+		name    string
+		content string
+		target  string
+		want    string
+	}{
+		{"missing version", "slug: x\n", "", "schema_version is missing"},
+		{"unknown version", "schema_version: 0.9.0\n", "", "unknown schema_version"},
+		{"unknown target", "schema_version: 1.0.0\n", "3.0.0", "unknown target"},
+		{"downgrade", "schema_version: 1.1.0\n", "1.0.0", "cannot downgrade"},
+		{"not a mapping", "- a\n- b\n", "", "must be a mapping"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := UpgradeProjectYAML([]byte(tt.content), tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchemaVersionDiagnostics(t *testing.T) {
+	withSchemaVersions(t)
+	registerTestSchemaVersion(t)
+
+	diags := ValidateProjectDiagnostics(Project{SchemaVersion: "1.0.0"})
+	d, ok := findDiagnostic(diags, "schema_version")
+	if !ok || d.Severity != SeverityInfo {
+		t.Fatalf("expected info diagnostic for older schema_version, got %v", diags)
+	}
+	if !strings.Contains(d.Fix, "migrate upgrade") {
+		t.Errorf("fix = %q", d.Fix)
+	}
+
+	diags = ValidateProjectDiagnostics(Project{SchemaVersion: "1.1.0"})
+	if _, ok := findDiagnostic(diags, "schema_version"); ok {
+		t.Errorf("latest version should not be flagged: %v", diags)
+	}
+}
+
+func TestCompareSemver(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"1.0.0", "1.0.0", 0},
+		{"1.0.0", "1.1.0", -1},
+		{"1.10.0", "1.9.0", 1},
+		{"bogus", "1.0.0", -1},
+	}
+	for _, tt := range tests {
+		if got := compareSemver(tt.a, tt.b); got != tt.want {
+			t.Errorf("compareSemver(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package projects
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// socialPlatform describes how one social key is checked and normalized.
+type socialPlatform struct {
+	name         string                       // display name used in messages
+	example      string                       // example URL used in fix hints
+	matchHost    func(host string) bool       // whether a URL host belongs to the platform
+	matchPath    func(path string) bool       // nil accepts any path
+	handle       *regexp.Regexp               // handle-only values; the first group is the handle
+	canonical    func(groups []string) string // canonical URL for a matched handle
+	landscapeKey string                       // landscape.yml key, under extra: unless "twitter"
+}
+
+// socialPlatforms maps the social keys the validator understands to their
+// platform rules. Other keys only need to be valid URLs.
+var socialPlatforms = map[string]*socialPlatform{
+	"twitter":  twitterPlatform,
+	"x":        twitterPlatform,
+	"linkedin": linkedinPlatform,
+	"youtube":  youtubePlatform,
+	"mastodon": mastodonPlatform,
+	"bluesky":  blueskyPlatform,
+	"bsky":     blueskyPlatform,
+	"discord":  discordPlatform,
+	"slack":    slackPlatform,
+}
+
+// socialAliases maps alternative social keys to the canonical key.
+var socialAliases = map[string]string{
+	"x":    "twitter",
+	"bsky": "bluesky",
+}
+
+var (
+	twitterPlatform = &socialPlatform{
+		name:         "Twitter/X",
+		example:      "https://twitter.com/<handle>",
+		matchHost:    hostIn("twitter.com", "x.com"),
+		matchPath:    nonRootPath,
+		handle:       regexp.MustCompile(`^@?([A-Za-z0-9_]{1,15})$`),
+		canonical:    func(g []string) string { return "https://twitter.com/" + g[1] },
+		landscapeKey: "twitter",
+	}
+	linkedinPlatform = &socialPlatform{
+		name:         "LinkedIn",
+		example:      "https://www.linkedin.com/company/<name>",
+		matchHost:    hostIn("linkedin.com"),
+		matchPath:    nonRootPath,
+		handle:       regexp.MustCompile(`^([a-z0-9][a-z0-9-]{1,99})$`),
+		canonical:    func(g []string) string { return "https://www.linkedin.com/company/" + g[1] },
+		landscapeKey: "linkedin_url",
+	}
+	youtubePlatform = &socialPlatform{
+		name:         "YouTube",
+		example:      "https://www.youtube.com/@<handle>",
+		matchHost:    hostIn("youtube.com", "youtu.be"),
+		matchPath:    nonRootPath,
+		handle:       regexp.MustCompile(`^(@[A-Za-z0-9._-]{3,30})$`),
+		canonical:    func(g []string) string { return "https://www.youtube.com/" + g[1] },
+		landscapeKey: "youtube_url",
+	}
+	// Mastodon is federated, so any instance host is accepted as long as the
+	// path is a profile.
+	mastodonPlatform = &socialPlatform{
+		name:    "Mastodon",
+		example: "https://<instance>/@<user>",
+		matchPath: func(p string) bool {
+			return strings.HasPrefix(p, "/@") || strings.HasPrefix(p, "/users/")
+		},
+		handle:       regexp.MustCompile(`^@?([A-Za-z0-9_]+)@([A-Za-z0-9.-]+\.[A-Za-z]{2,})$`),
+		canonical:    func(g []string) string { return "https://" + strings.ToLower(g[2]) + "/@" + g[1] },
+		landscapeKey: "mastodon_url",
+	}
+	blueskyPlatform = &socialPlatform{
+		name:         "Bluesky",
+		example:      "https://bsky.app/profile/<handle>",
+		matchHost:    hostIn("bsky.app"),
+		matchPath:    func(p string) bool { return strings.HasPrefix(p, "/profile/") && len(p) > len("/profile/") },
+		handle:       regexp.MustCompile(`^@?([A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)+)$`),
+		canonical:    func(g []string) string { return "https://bsky.app/profile/" + strings.ToLower(g[1]) },
+		landscapeKey: "bluesky_url",
+	}
+	discordPlatform = &socialPlatform{
+		name:         "Discord",
+		example:      "https://discord.gg/<invite>",
+		matchHost:    hostIn("discord.gg", "discord.com", "discordapp.com"),
+		matchPath:    nonRootPath,
+		handle:       regexp.MustCompile(`^([A-Za-z0-9-]{2,32})$`),
+		canonical:    func(g []string) string { return "https://discord.gg/" + g[1] },
+		landscapeKey: "discord_url",
+	}
+	slackPlatform = &socialPlatform{
+		name:         "Slack",
+		example:      "https://<workspace>.slack.com",
+		matchHost:    func(host string) bool { _, ok := slackHostWorkspace(host, ""); return ok },
+		handle:       regexp.MustCompile(`^(` + slackWorkspacePattern + `)$`),
+		canonical:    func(g []string) string { return "https://" + g[1] + ".slack.com" },
+		landscapeKey: "slack_url",
+	}
+)
+
+// slackWorkspacePattern matches a Slack workspace subdomain.
+const slackWorkspacePattern = `[a-z0-9][a-z0-9-]*`
+
+var slackWorkspaceRe = regexp.MustCompile(`^` + slackWorkspacePattern + `$`)
+
+// slackWorkspaceAliases maps the short workspace identifiers used in
+// project.yaml to the workspace subdomain they stand for.
+var slackWorkspaceAliases = map[string]string{
+	"cncf": "cloud-native",
+}
+
+// slackInviteHosts are invite redirectors whose workspace is known.
+var slackInviteHosts = map[string]string{
+	"slack.cncf.io":       "cloud-native",
+	"slack.k8s.io":        "kubernetes",
+	"slack.kubernetes.io": "kubernetes",
+}
+
+func hostIn(domains ...string) func(string) bool {
+	return func(host string) bool {
+		for _, d := range domains {
+			if host == d || strings.HasSuffix(host, "."+d) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+func nonRootPath(p string) bool {
+	return strings.Trim(p, "/") != ""
+}
+
+// slackHostWorkspace reports whether host serves Slack and, when it can be
+// told from the URL, the workspace it belongs to. path is only consulted for
+// join.slack.com and communityinviter.com invite links.
+func slackHostWorkspace(host, path string) (string, bool) {
+	host = strings.ToLower(host)
+	switch {
+	case host == "join.slack.com":
+		// https://join.slack.com/t/<workspace>/shared_invite/...
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		if len(parts) >= 2 && parts[0] == "t" {
+			return parts[1], true
+		}
+		return "", true
+	case host == "slack.com", host == "www.slack.com", host == "app.slack.com":
+		return "", true
+	case strings.HasSuffix(host, ".slack.com"):
+		ws := strings.TrimSuffix(host, ".slack.com")
+		if strings.Contains(ws, ".") {
+			return "", true
+		}
+		return ws, true
+	case host == "communityinviter.com", host == "www.communityinviter.com":
+		// https://communityinviter.com/apps/<workspace>/<name>
+		parts := strings.Split(strings.Trim(path, "/"), "/")
+		if len(parts) >= 2 && parts[0] == "apps" {
+			return parts[1], true
+		}
+		return "", true
+	}
+	if ws, ok := slackInviteHosts[host]; ok {
+		return ws, true
+	}
+	// Projects often run their own redirector, e.g. slack.example.io.
+	return "", strings.HasPrefix(host, "slack.")
+}
+
+// canonicalSlackWorkspace resolves a workspace identifier to its subdomain.
+func canonicalSlackWorkspace(ws string) string {
+	ws = strings.ToLower(strings.TrimSpace(ws))
+	if alias, ok := slackWorkspaceAliases[ws]; ok {
+		return alias
+	}
+	return ws
+}
+
+// NormalizeSocialLink returns the canonical URL for a social value. Handle-only
+// values such as "@project" for twitter are expanded for the known platforms;
+// URLs are returned unchanged. ok is false when the value is neither a valid
+// URL nor a handle for the platform.
+func NormalizeSocialLink(platform, value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	if isValidURL(value) {
+		return value, true
+	}
+	p, known := socialPlatforms[strings.ToLower(platform)]
+	if !known {
+		return "", false
+	}
+	groups := p.handle.FindStringSubmatch(value)
+	if groups == nil {
+		return "", false
+	}
+	return p.canonical(groups), true
+}
+
+// socialDiagnostics checks each social link: values must be URLs, and for the
+// known platforms the URL must point at that platform. A handle-only value is
+// still an error, with the canonical URL as its fix.
+func socialDiagnostics(social map[string]string) []Diagnostic {
+	var diags []Diagnostic
+	for _, key := range sortedKeys(social) {
+		raw := social[key]
+		path := "social." + key
+		p := socialPlatforms[strings.ToLower(key)]
+
+		if !isValidURL(raw) {
+			d := errorDiag(path, RuleURLFormat, "social.%s is not a valid URL: %s", key, raw)
+			if canonical, ok := NormalizeSocialLink(key, raw); ok {
+				d = d.withFix("use %q", canonical)
+			}
+			diags = append(diags, d)
+			continue
+		}
+		if p == nil {
+			continue
+		}
+		u, _ := url.Parse(raw)
+		host := strings.ToLower(u.Hostname())
+		if p.matchHost != nil && !p.matchHost(host) {
+			diags = append(diags, errorDiag(path, RuleSocialPlatform, "social.%s must be a %s link, got host %s", key, p.name, host).
+				withFix("use a URL like %s", p.example))
+		} else if p.matchPath != nil && !p.matchPath(u.Path) {
+			diags = append(diags, errorDiag(path, RuleSocialPlatform, "social.%s must link to a %s profile, got: %s", key, p.name, raw).
+				withFix("use a URL like %s", p.example))
+		}
+	}
+	return diags
+}
+
+// slackChannelDiagnostics checks that a channel's workspace is a workspace
+// name and that its link is a Slack URL in that workspace. path is the
+// channel's path, e.g. "slack_channels[0]".
+func slackChannelDiagnostics(path string, ch SlackChannel) []Diagnostic {
+	var diags []Diagnostic
+	if ch.Workspace != "" && !slackWorkspaceRe.MatchString(ch.Workspace) {
+		d := errorDiag(path+".workspace", RuleSlackWorkspace,
+			"%s.workspace must be a workspace name such as \"cncf\", got: %s", path, ch.Workspace)
+		if u, err := url.Parse(ch.Workspace); err == nil {
+			if ws, _ := slackHostWorkspace(u.Hostname(), u.Path); ws != "" {
+				d = d.withFix("use %q", ws)
+			}
+		}
+		diags = append(diags, d)
+	}
+	if ch.Link == "" || !isValidURL(ch.Link) {
+		return diags
+	}
+
+	link := path + ".link"
+	u, _ := url.Parse(ch.Link)
+	linkWorkspace, isSlack := slackHostWorkspace(u.Hostname(), u.Path)
+	if !isSlack {
+		// The host may be an invite redirector we do not know about.
+		d := Diagnostic{
+			Path:     link,
+			Rule:     RuleSlackLink,
+			Severity: SeverityWarning,
+			Message:  fmt.Sprintf("%s is not a known Slack or Slack invite URL: %s", link, ch.Link),
+		}
+		return append(diags, d.withFix("link to the channel, e.g. https://<workspace>.slack.com/archives/<channel>"))
+	}
+	if ch.Workspace == "" || linkWorkspace == "" || !slackWorkspaceRe.MatchString(ch.Workspace) {
+		return diags
+	}
+	if canonicalSlackWorkspace(linkWorkspace) != canonicalSlackWorkspace(ch.Workspace) {
+		diags = append(diags, errorDiag(link, RuleSlackLink, "%s is in the %s workspace, not %s", link, linkWorkspace, ch.Workspace).
+			withFix("set workspace: %q or link to a channel in %s", linkWorkspace, ch.Workspace))
+	}
+	return diags
+}
+
+// LandscapeSocialLinks returns the landscape.yml social fields for a project,
+// keyed by landscape key ("twitter", "slack_url", "discord_url", ...). Handles
+// are expanded to URLs and invalid values are skipped. slack_url falls back to
+// the primary Slack channel link when social.slack is not set.
+func LandscapeSocialLinks(project Project) map[string]string {
+	links := make(map[string]string)
+	for _, key := range sortedKeys(project.Social) {
+		p, ok := socialPlatforms[strings.ToLower(key)]
+		if !ok {
+			continue
+		}
+		// Prefer the canonical key when an alias is also set (twitter over x).
+		if canonical, alias := socialAliases[strings.ToLower(key)]; alias && project.Social[canonical] != "" {
+			continue
+		}
+		if link, ok := NormalizeSocialLink(key, project.Social[key]); ok {
+			links[p.landscapeKey] = link
+		}
+	}
+	if _, ok := links["slack_url"]; !ok {
+		if ch, ok := primarySlackEntry(project); ok && isValidURL(ch.Link) {
+			links["slack_url"] = ch.Link
+		}
+	}
+	return links
+}
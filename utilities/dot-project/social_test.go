@@ -1,6 +1,8 @@
 package projects
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -43,3 +45,129 @@ func TestSocialValidation(t *testing.T) {
 		}
 	}
 }
+
+func TestNormalizeSocialLink(t *testing.T) {
+	tests := []struct {
+		platform, value, want string
+		ok                    bool
+	}{
+		{"twitter", "@kubernetesio", "https://twitter.com/kubernetesio", true},
+		{"x", "kubernetesio", "https://twitter.com/kubernetesio", true},
+		{"twitter", "https://x.com/kubernetesio", "https://x.com/kubernetesio", true},
+		{"twitter", "not a handle", "", false},
+		{"linkedin", "kubernetes", "https://www.linkedin.com/company/kubernetes", true},
+		{"youtube", "@kubernetescommunity", "https://www.youtube.com/@kubernetescommunity", true},
+		{"mastodon", "@cncf@hachyderm.io", "https://hachyderm.io/@cncf", true},
+		{"bluesky", "@cncf.io", "https://bsky.app/profile/cncf.io", true},
+		{"discord", "abc123", "https://discord.gg/abc123", true},
+		{"slack", "kubernetes", "https://kubernetes.slack.com", true},
+		{"github", "kubernetes", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := NormalizeSocialLink(tt.platform, tt.value)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("NormalizeSocialLink(%q, %q) = %q, %v; want %q, %v", tt.platform, tt.value, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestSocialPlatformDiagnostics(t *testing.T) {
+	tests := []struct {
+		key, value string
+		rule       string // "" means no diagnostic
+		fix        string
+	}{
+		{"twitter", "https://twitter.com/kubernetesio", "", ""},
+		{"x", "https://x.com/kubernetesio", "", ""},
+		{"twitter", "https://github.com/kubernetes", RuleSocialPlatform, "https://twitter.com/<handle>"},
+		{"twitter", "@kubernetesio", RuleURLFormat, `"https://twitter.com/kubernetesio"`},
+		{"linkedin", "https://www.linkedin.com/company/kubernetes", "", ""},
+		{"linkedin", "https://www.linkedin.com/", RuleSocialPlatform, ""},
+		{"youtube", "https://youtu.be/abc", "", ""},
+		{"mastodon", "https://hachyderm.io/@cncf", "", ""},
+		{"mastodon", "https://hachyderm.io/about", RuleSocialPlatform, ""},
+		{"bluesky", "https://bsky.app/profile/cncf.io", "", ""},
+		{"bluesky", "https://bsky.app/", RuleSocialPlatform, ""},
+		{"discord", "https://discord.com/invite/abc", "", ""},
+		{"discord", "https://slack.com/abc", RuleSocialPlatform, ""},
+		{"slack", "https://slack.cncf.io", "", ""},
+		{"slack", "https://discord.gg/abc", RuleSocialPlatform, ""},
+		{"blog", "https://kubernetes.io/blog", "", ""},
+	}
+	for _, tt := range tests {
+		diags := socialDiagnostics(map[string]string{tt.key: tt.value})
+		if tt.rule == "" {
+			if len(diags) != 0 {
+				t.Errorf("%s: %s: unexpected diagnostics %+v", tt.key, tt.value, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || diags[0].Rule != tt.rule || diags[0].Path != "social."+tt.key || !strings.Contains(diags[0].Fix, tt.fix) {
+			t.Errorf("%s: %s: diagnostics = %+v, want one %s", tt.key, tt.value, diags, tt.rule)
+		}
+	}
+}
+
+func TestSlackChannelDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		ch   SlackChannel
+		path string // "" means no diagnostic
+		fix  string
+		sev  Severity
+	}{
+		{"cncf alias", SlackChannel{Name: "#k8s", Workspace: "cncf", Link: "https://cloud-native.slack.com/archives/k8s"}, "", "", ""},
+		{"invite host", SlackChannel{Name: "#k8s", Workspace: "kubernetes", Link: "https://slack.k8s.io"}, "", "", ""},
+		{"join link", SlackChannel{Name: "#k8s", Workspace: "kubernetes", Link: "https://join.slack.com/t/kubernetes/shared_invite/zt-abc"}, "", "", ""},
+		{"no workspace", SlackChannel{Name: "#k8s", Link: "https://kubernetes.slack.com/archives/k8s"}, "", "", ""},
+		{"wrong workspace", SlackChannel{Name: "#k8s", Workspace: "cncf", Link: "https://kubernetes.slack.com/archives/k8s"}, "slack_channels[0].link", `workspace: "kubernetes"`, SeverityError},
+		{"cncf redirector", SlackChannel{Name: "#tag-security", Workspace: "cncf", Link: "https://slack.cncf.io"}, "", "", ""},
+		{"community inviter", SlackChannel{Name: "#tag-security", Workspace: "cncf", Link: "https://communityinviter.com/apps/cloud-native/cncf"}, "", "", ""},
+		{"community inviter elsewhere", SlackChannel{Name: "#k8s", Workspace: "cncf", Link: "https://communityinviter.com/apps/kubernetes/k8s"}, "slack_channels[0].link", `workspace: "kubernetes"`, SeverityError},
+		{"unknown host", SlackChannel{Name: "#k8s", Link: "https://discord.gg/abc"}, "slack_channels[0].link", "slack.com", SeverityWarning},
+		{"workspace url", SlackChannel{Name: "#k8s", Workspace: "https://kubernetes.slack.com"}, "slack_channels[0].workspace", `"kubernetes"`, SeverityError},
+	}
+	for _, tt := range tests {
+		diags := slackChannelDiagnostics("slack_channels[0]", tt.ch)
+		if tt.path == "" {
+			if len(diags) != 0 {
+				t.Errorf("%s: unexpected diagnostics %+v", tt.name, diags)
+			}
+			continue
+		}
+		if len(diags) != 1 || diags[0].Path != tt.path || !strings.Contains(diags[0].Fix, tt.fix) || diags[0].Severity != tt.sev {
+			t.Errorf("%s: diagnostics = %+v, want one %s at %s with fix containing %q", tt.name, diags, tt.sev, tt.path, tt.fix)
+		}
+	}
+}
+
+func TestLandscapeSocialLinks(t *testing.T) {
+	p := validBaseProject()
+	p.Social = map[string]string{
+		"x":        "@old",
+		"twitter":  "@kubernetesio",
+		"bsky":     "kubernetes.io",
+		"mastodon": "https://hachyderm.io/@kubernetes",
+		"discord":  "not a handle!",
+		"github":   "https://github.com/kubernetes",
+	}
+	p.SlackChannels = []SlackChannel{
+		{Name: "#dev", Link: "https://kubernetes.slack.com/archives/dev"},
+		{Name: "#users", Link: "https://kubernetes.slack.com/archives/users", Primary: true},
+	}
+	got := LandscapeSocialLinks(p)
+	want := map[string]string{
+		"twitter":      "https://twitter.com/kubernetesio",
+		"bluesky_url":  "https://bsky.app/profile/kubernetes.io",
+		"mastodon_url": "https://hachyderm.io/@kubernetes",
+		"slack_url":    "https://kubernetes.slack.com/archives/users",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LandscapeSocialLinks = %v, want %v", got, want)
+	}
+
+	entry := ProjectToLandscapeEntry(p)
+	if entry.Twitter != want["twitter"] || entry.Extra["bluesky_url"] != want["bluesky_url"] {
+		t.Errorf("landscape entry = %+v", entry)
+	}
+}
@@ -9,26 +9,37 @@ import (
 // StalenessResult contains the staleness check result for a project
 type StalenessResult struct {
 	ProjectSlug          string    `json:"project_slug"`
+	URL                  string    `json:"url,omitempty"`      // Project file, in fleet reports
+	Maturity             string    `json:"maturity,omitempty"` // Current maturity phase, in fleet reports
 	LastMaintainerUpdate time.Time `json:"last_maintainer_update"`
+	UpdateSource         string    `json:"update_source,omitempty"` // Where LastMaintainerUpdate came from: git, github, mtime or override
 	DaysSinceUpdate      int       `json:"days_since_update"`
 	IsStale              bool      `json:"is_stale"`
 	ProjectLead          string    `json:"project_lead,omitempty"`
 	SlackChannel         string    `json:"slack_channel,omitempty"`
 	Message              string    `json:"message"`
+	Error                string    `json:"error,omitempty"` // Why the project could not be checked
 }
 
 // primarySlackChannel returns the name of the project's primary Slack channel.
 // It prefers the entry marked primary, falling back to the first entry.
 func primarySlackChannel(project Project) string {
+	ch, _ := primarySlackEntry(project)
+	return ch.Name
+}
+
+// primarySlackEntry returns the Slack channel marked primary, or the first
+// channel if none is marked.
+func primarySlackEntry(project Project) (SlackChannel, bool) {
 	for _, ch := range project.SlackChannels {
 		if ch.Primary {
-			return ch.Name
+			return ch, true
 		}
 	}
 	if len(project.SlackChannels) > 0 {
-		return project.SlackChannels[0].Name
+		return project.SlackChannels[0], true
 	}
-	return ""
+	return SlackChannel{}, false
 }
 
 // CheckStaleness checks if a project's maintainer data is stale// A project is considered stale if its maintainers haven't been updated in the given threshold
@@ -74,7 +85,11 @@ func FormatStalenessResults(results []StalenessResult) string {
 		if r.IsStale {
 			staleCount++
 			b.WriteString(fmt.Sprintf("STALE: %s\n", r.ProjectSlug))
-			b.WriteString(fmt.Sprintf("  Last updated: %s (%d days ago)\n", r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate))
+			if r.UpdateSource != "" {
+				b.WriteString(fmt.Sprintf("  Last updated: %s (%d days ago, from %s)\n", r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate, r.UpdateSource))
+			} else {
+				b.WriteString(fmt.Sprintf("  Last updated: %s (%d days ago)\n", r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate))
+			}
 			if r.ProjectLead != "" {
 				b.WriteString(fmt.Sprintf("  Contact: @%s\n", r.ProjectLead))
 			}
@@ -0,0 +1,284 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// StalenessHistoryFunc locates the maintainers.yaml that belongs to a
+// project file: the history of the repository holding it and its path
+// there.
+type StalenessHistoryFunc func(projectURL string) (RepoHistory, string, error)
+
+// DefaultStalenessHistory looks for maintainers.yaml next to each project
+// file. Local paths and file:// URLs use the checkout's git history;
+// raw.githubusercontent.com and github.com blob URLs use the GitHub commits
+// API on the same ref.
+func DefaultStalenessHistory(token string, client *http.Client) StalenessHistoryFunc {
+	return func(projectURL string) (RepoHistory, string, error) {
+		if !strings.HasPrefix(projectURL, "http://") && !strings.HasPrefix(projectURL, "https://") {
+			dir := filepath.Dir(strings.TrimPrefix(projectURL, "file://"))
+			return LocalGitHistory{Dir: dir}, "maintainers.yaml", nil
+		}
+		u, err := url.Parse(projectURL)
+		if err != nil {
+			return nil, "", err
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		switch {
+		case u.Host == "raw.githubusercontent.com" && len(parts) >= 4:
+			// /org/repo/ref/path...
+		case u.Host == "github.com" && len(parts) >= 5 && parts[2] == "blob":
+			// /org/repo/blob/ref/path...
+			parts = append(parts[:2], parts[3:]...)
+		default:
+			return nil, "", fmt.Errorf("cannot find the git history of %s", projectURL)
+		}
+		history := GitHubCommitHistory{Org: parts[0], Repo: parts[1], Ref: parts[2], Token: token, Client: client}
+		return history, path.Join(path.Dir(strings.Join(parts[3:], "/")), "maintainers.yaml"), nil
+	}
+}
+
+// CheckFleetStaleness runs CheckStaleness on every project in the project
+// list (see ValidateAll for projectListPath), dating each project's
+// maintainer data by the last commit to its maintainers.yaml. Projects are
+// checked in parallel like ValidateProjects. A project that cannot be
+// fetched, parsed or dated gets a result with Error set. Results are ranked
+// with RankStalenessResults.
+func (pv *ProjectValidator) CheckFleetStaleness(projectListPath string, history StalenessHistoryFunc, thresholdDays int) ([]StalenessResult, error) {
+	if projectListPath != "" {
+		pv.config.ProjectListURL = projectListPath
+	}
+	projectURLs, err := pv.loadProjectList()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load project list: %v", err)
+	}
+
+	f := newFetcher(pv.client, pv.config)
+	workers := DefaultFetchConcurrency
+	if pv.config != nil && pv.config.Concurrency > 0 {
+		workers = pv.config.Concurrency
+	}
+
+	results := make([]StalenessResult, len(projectURLs))
+	sem := make(chan struct{}, workers)
+	var wg sync.WaitGroup
+	for i, projectURL := range projectURLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			results[i] = checkProjectStaleness(f, projectURL, history, thresholdDays)
+			if results[i].Error != "" {
+				log.Printf("Error checking staleness of %s: %s", projectURL, results[i].Error)
+			}
+		}()
+	}
+	wg.Wait()
+
+	RankStalenessResults(results)
+	return results, nil
+}
+
+// checkProjectStaleness fetches one project file and dates its maintainers.
+func checkProjectStaleness(f *fetcher, projectURL string, history StalenessHistoryFunc, thresholdDays int) StalenessResult {
+	failed := StalenessResult{URL: projectURL}
+	resp, err := f.fetch(projectURL, "")
+	if err != nil {
+		failed.Error = fmt.Sprintf("fetching project: %v", err)
+		return failed
+	}
+	var project Project
+	if err := yaml.Unmarshal([]byte(resp.Content), &project); err != nil {
+		failed.Error = fmt.Sprintf("parsing project: %v", err)
+		return failed
+	}
+	failed.ProjectSlug = project.Slug
+	failed.Maturity = CurrentMaturityPhase(project)
+
+	h, maintainersPath, err := history(projectURL)
+	if err != nil {
+		failed.Error = err.Error()
+		return failed
+	}
+	lastUpdate, err := h.LastCommit(maintainersPath)
+	if err != nil {
+		failed.Error = err.Error()
+		return failed
+	}
+
+	result := CheckStaleness(project, lastUpdate, thresholdDays)
+	result.URL = projectURL
+	result.Maturity = failed.Maturity
+	switch h.(type) {
+	case LocalGitHistory:
+		result.UpdateSource = "git"
+	case GitHubCommitHistory:
+		result.UpdateSource = "github"
+	}
+	return result
+}
+
+// stalenessMaturityOrder is the order of maturity groups in fleet reports.
+var stalenessMaturityOrder = map[string]int{"graduated": 0, "incubating": 1, "sandbox": 2, "archived": 3}
+
+func stalenessGroupRank(maturity string) int {
+	if r, ok := stalenessMaturityOrder[maturity]; ok {
+		return r
+	}
+	return len(stalenessMaturityOrder)
+}
+
+// RankStalenessResults sorts results by maturity (graduated first), then
+// longest without an update first. Projects that could not be checked come
+// last within their group.
+func RankStalenessResults(results []StalenessResult) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		if ga, gb := stalenessGroupRank(a.Maturity), stalenessGroupRank(b.Maturity); ga != gb {
+			return ga < gb
+		}
+		if (a.Error == "") != (b.Error == "") {
+			return a.Error == ""
+		}
+		if a.DaysSinceUpdate != b.DaysSinceUpdate {
+			return a.DaysSinceUpdate > b.DaysSinceUpdate
+		}
+		return a.ProjectSlug < b.ProjectSlug
+	})
+}
+
+// stalenessGroup is the results of one maturity level, in rank order.
+type stalenessGroup struct {
+	maturity string
+	results  []StalenessResult
+}
+
+// groupStalenessResults splits ranked results by maturity.
+func groupStalenessResults(results []StalenessResult) []stalenessGroup {
+	var groups []stalenessGroup
+	for _, r := range results {
+		m := r.Maturity
+		if m == "" {
+			m = "unknown"
+		}
+		if len(groups) == 0 || groups[len(groups)-1].maturity != m {
+			groups = append(groups, stalenessGroup{maturity: m})
+		}
+		g := &groups[len(groups)-1]
+		g.results = append(g.results, r)
+	}
+	return groups
+}
+
+// FormatFleetStaleness renders ranked results as markdown, json or csv.
+func FormatFleetStaleness(results []StalenessResult, format string) (string, error) {
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data) + "\n", nil
+	case "csv":
+		return formatFleetStalenessCSV(results)
+	case "markdown", "md", "":
+		return formatFleetStalenessMarkdown(results), nil
+	}
+	return "", fmt.Errorf("unknown format %q (want markdown, json or csv)", format)
+}
+
+func formatFleetStalenessMarkdown(results []StalenessResult) string {
+	var b strings.Builder
+	stale, failed := 0, 0
+	for _, r := range results {
+		if r.IsStale {
+			stale++
+		}
+		if r.Error != "" {
+			failed++
+		}
+	}
+	b.WriteString("# Maintainer Staleness Report\n\n")
+	b.WriteString(fmt.Sprintf("%d projects checked, %d stale, %d could not be checked.\n", len(results), stale, failed))
+
+	for _, g := range groupStalenessResults(results) {
+		groupStale := 0
+		for _, r := range g.results {
+			if r.IsStale {
+				groupStale++
+			}
+		}
+		b.WriteString(fmt.Sprintf("\n## %s (%d projects, %d stale)\n\n", titleCase(g.maturity), len(g.results), groupStale))
+		b.WriteString("| # | Project | Last update | Days | Status | Project lead | Slack |\n")
+		b.WriteString("|---|---------|-------------|------|--------|--------------|-------|\n")
+		for i, r := range g.results {
+			name := r.ProjectSlug
+			if name == "" {
+				name = r.URL
+			}
+			if r.Error != "" {
+				b.WriteString(fmt.Sprintf("| %d | %s | | | could not check: %s | | |\n", i+1, name, markdownCell(r.Error)))
+				continue
+			}
+			status := "ok"
+			if r.IsStale {
+				status = "**stale**"
+			}
+			lead := ""
+			if r.ProjectLead != "" {
+				lead = "@" + strings.ReplaceAll(r.ProjectLead, ", ", ", @")
+			}
+			b.WriteString(fmt.Sprintf("| %d | %s | %s | %d | %s | %s | %s |\n",
+				i+1, name, r.LastMaintainerUpdate.Format("2006-01-02"), r.DaysSinceUpdate, status, lead, markdownCell(r.SlackChannel)))
+		}
+	}
+	return b.String()
+}
+
+func formatFleetStalenessCSV(results []StalenessResult) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"maturity", "rank", "project", "last_update", "days_since_update", "stale", "project_lead", "slack_channel", "source", "url", "error"})
+	for _, g := range groupStalenessResults(results) {
+		for i, r := range g.results {
+			last, days := "", ""
+			if r.Error == "" {
+				last = r.LastMaintainerUpdate.Format(time.DateOnly)
+				days = strconv.Itoa(r.DaysSinceUpdate)
+			}
+			w.Write([]string{g.maturity, strconv.Itoa(i + 1), r.ProjectSlug, last, days, strconv.FormatBool(r.IsStale),
+				r.ProjectLead, r.SlackChannel, r.UpdateSource, r.URL, r.Error})
+		}
+	}
+	w.Flush()
+	return buf.String(), w.Error()
+}
+
+// markdownCell escapes pipes so a value stays in its table cell.
+func markdownCell(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// titleCase upper-cases the first letter of s.
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
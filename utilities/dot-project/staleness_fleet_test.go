@@ -0,0 +1,130 @@
+package projects
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCheckFleetStaleness(t *testing.T) {
+	recent := time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339)
+	dir := gitRepo(t, map[string]string{
+		"2020-01-01T00:00:00Z": "old/maintainers.yaml",
+		"2021-01-01T00:00:00Z": "older-grad/maintainers.yaml",
+		recent:                 "fresh/maintainers.yaml",
+	})
+	project := func(slug, phase string) string {
+		return strings.NewReplacer("test-project", slug, "sandbox", phase).Replace(validProjectYAML()) +
+			"project_lead: \"@" + slug + "-lead\"\nslack_channels:\n  - name: \"#" + slug + "\"\n    primary: true\n"
+	}
+	writeFile(t, filepath.Join(dir, "old/project.yaml"), project("old", "sandbox"))
+	writeFile(t, filepath.Join(dir, "older-grad/project.yaml"), project("older-grad", "graduated"))
+	writeFile(t, filepath.Join(dir, "fresh/project.yaml"), project("fresh", "sandbox"))
+	if err := os.MkdirAll(filepath.Join(dir, "unversioned"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "unversioned/project.yaml"), project("unversioned", "sandbox"))
+
+	list := filepath.Join(t.TempDir(), "projectlist.yaml")
+	writeFile(t, list, "projects:\n"+
+		"  - url: "+filepath.Join(dir, "fresh/project.yaml")+"\n"+
+		"  - url: "+filepath.Join(dir, "old/project.yaml")+"\n"+
+		"  - url: "+filepath.Join(dir, "unversioned/project.yaml")+"\n"+
+		"  - url: "+filepath.Join(dir, "older-grad/project.yaml")+"\n")
+
+	pv := newTestValidator(t)
+	results, err := pv.CheckFleetStaleness(list, DefaultStalenessHistory("", nil), 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var order []string
+	for _, r := range results {
+		order = append(order, r.ProjectSlug)
+	}
+	if got := strings.Join(order, ","); got != "older-grad,old,fresh,unversioned" {
+		t.Fatalf("ranking = %s", got)
+	}
+	grad, old, fresh, unversioned := results[0], results[1], results[2], results[3]
+	if !grad.IsStale || grad.Maturity != "graduated" || grad.UpdateSource != "git" {
+		t.Errorf("older-grad = %+v", grad)
+	}
+	if !old.IsStale || old.ProjectLead != "old-lead" || old.SlackChannel != "#old" {
+		t.Errorf("old = %+v", old)
+	}
+	if fresh.IsStale || fresh.DaysSinceUpdate != 10 {
+		t.Errorf("fresh = %+v", fresh)
+	}
+	if unversioned.Error == "" || unversioned.IsStale {
+		t.Errorf("unversioned = %+v", unversioned)
+	}
+
+	md, err := FormatFleetStaleness(results, "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"4 projects checked, 2 stale, 1 could not be checked",
+		"## Graduated (1 projects, 1 stale)",
+		"## Sandbox (3 projects, 1 stale)",
+		"| 1 | old | 2020-01-01 |",
+		"**stale** | @old-lead | #old |",
+		"| 3 | unversioned | | | could not check:",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+
+	out, err := FormatFleetStaleness(results, "csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 || strings.Join(rows[2][:3], ",") != "sandbox,1,old" || rows[2][7] != "#old" {
+		t.Errorf("csv rows = %v", rows)
+	}
+
+	out, err = FormatFleetStaleness(results, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []StalenessResult
+	if err := json.Unmarshal([]byte(out), &decoded); err != nil || len(decoded) != 4 {
+		t.Errorf("json = %v, %v", decoded, err)
+	}
+
+	if _, err := FormatFleetStaleness(results, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestDefaultStalenessHistory(t *testing.T) {
+	history := DefaultStalenessHistory("tok", nil)
+	tests := []struct {
+		url, org, repo, ref, path string
+	}{
+		{"https://raw.githubusercontent.com/kubernetes/.project/main/project.yaml", "kubernetes", ".project", "main", "maintainers.yaml"},
+		{"https://github.com/envoyproxy/.project/blob/main/sub/project.yaml", "envoyproxy", ".project", "main", "sub/maintainers.yaml"},
+	}
+	for _, tt := range tests {
+		h, path, err := history(tt.url)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.url, err)
+		}
+		gh, ok := h.(GitHubCommitHistory)
+		if !ok || gh.Org != tt.org || gh.Repo != tt.repo || gh.Ref != tt.ref || gh.Token != "tok" || path != tt.path {
+			t.Errorf("%s: history = %+v, path = %s", tt.url, h, path)
+		}
+	}
+	if _, _, err := history("https://example.com/project.yaml"); err == nil {
+		t.Error("expected an error for a host without git history")
+	}
+}
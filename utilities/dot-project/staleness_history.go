@@ -0,0 +1,236 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RepoHistory reports when a file in a repository was last changed. An
+// error means the history could not be read, including when no commit
+// touches the path.
+type RepoHistory interface {
+	LastCommit(repoPath string) (time.Time, error)
+}
+
+// LocalGitHistory reads history from a local git checkout.
+type LocalGitHistory struct {
+	Dir string
+}
+
+// LastCommit returns the committer date of the last commit touching
+// repoPath. In a shallow clone the boundary commit appears to add every
+// file, so a result that lands on it is rejected rather than reported as a
+// change made at clone time.
+func (h LocalGitHistory) LastCommit(repoPath string) (time.Time, error) {
+	out, err := exec.Command("git", "-C", h.Dir, "log", "-1", "--format=%H %cI", "--", repoPath).Output()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("reading git history of %s in %s: %w", repoPath, h.Dir, err)
+	}
+	hash, date, ok := strings.Cut(strings.TrimSpace(string(out)), " ")
+	if !ok {
+		return time.Time{}, fmt.Errorf("no commit in %s touches %s", h.Dir, repoPath)
+	}
+	if h.isShallowBoundary(hash) {
+		return time.Time{}, fmt.Errorf("the history of %s in %s is cut off by a shallow clone; fetch the full history (fetch-depth: 0)", repoPath, h.Dir)
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parsing commit date %q: %w", date, err)
+	}
+	return t, nil
+}
+
+// isShallowBoundary reports whether hash is listed in the repository's
+// shallow file.
+func (h LocalGitHistory) isShallowBoundary(hash string) bool {
+	out, err := exec.Command("git", "-C", h.Dir, "rev-parse", "--git-path", "shallow").Output()
+	if err != nil {
+		return false
+	}
+	shallow := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(shallow) {
+		shallow = filepath.Join(h.Dir, shallow)
+	}
+	data, err := os.ReadFile(shallow)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Fields(string(data)) {
+		if line == hash {
+			return true
+		}
+	}
+	return false
+}
+
+// GitHubCommitHistory reads history through the GitHub commits API.
+type GitHubCommitHistory struct {
+	Org, Repo string
+	Ref       string // branch or SHA; "" for the default branch
+	Token     string
+	Client    *http.Client
+	BaseURL   string // GitHub API base; "" for DefaultGitHubAPIURL
+}
+
+// LastCommit returns the committer date of the last commit touching
+// repoPath on Ref.
+func (h GitHubCommitHistory) LastCommit(repoPath string) (time.Time, error) {
+	q := url.Values{"path": {repoPath}, "per_page": {"1"}}
+	if h.Ref != "" {
+		q.Set("sha", h.Ref)
+	}
+	t, found, err := latestGitHubCommit(h.Client, h.BaseURL, h.Token, h.Org, h.Repo, q)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !found {
+		return time.Time{}, fmt.Errorf("no commit in %s/%s touches %s", h.Org, h.Repo, repoPath)
+	}
+	return t, nil
+}
+
+// latestGitHubCommit returns the committer date of the first commit listed
+// by the commits API for the query. found is false for an empty list.
+func latestGitHubCommit(client *http.Client, baseURL, token, org, repo string, q url.Values) (t time.Time, found bool, err error) {
+	resp, err := githubGet(client, baseURL, token, fmt.Sprintf("/repos/%s/%s/commits?%s", org, repo, q.Encode()))
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("GitHub commits request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusConflict:
+		return time.Time{}, false, nil // empty repository
+	default:
+		return time.Time{}, false, fmt.Errorf("GitHub commits API returned HTTP %d for %s/%s%s", resp.StatusCode, org, repo, rateLimitHint(resp))
+	}
+
+	var commits []struct {
+		Commit struct {
+			Committer struct {
+				Date time.Time `json:"date"`
+			} `json:"committer"`
+		} `json:"commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&commits); err != nil {
+		return time.Time{}, false, fmt.Errorf("parsing GitHub commits response: %w", err)
+	}
+	if len(commits) == 0 {
+		return time.Time{}, false, nil
+	}
+	return commits[0].Commit.Committer.Date, true, nil
+}
+
+// MaintainerActivity is when a maintainer last committed to any of the
+// project's repositories.
+type MaintainerActivity struct {
+	Handle      string    `json:"handle" yaml:"handle"`
+	LastCommit  time.Time `json:"last_commit,omitempty" yaml:"last_commit,omitempty"`
+	Repository  string    `json:"repository,omitempty" yaml:"repository,omitempty"` // Repository of the last commit
+	DaysSince   int       `json:"days_since_commit" yaml:"days_since_commit"`       // -1 when no commit was found
+	Inactive    bool      `json:"inactive" yaml:"inactive"`
+	LookupError string    `json:"lookup_error,omitempty" yaml:"lookup_error,omitempty"` // Set when activity could not be determined
+}
+
+// CommitActivitySource reports when a user last authored a commit in a
+// repository. found is false when they never did.
+type CommitActivitySource interface {
+	LastCommitBy(repoURL, handle string) (t time.Time, found bool, err error)
+}
+
+// GitHubCommitActivity looks up commits by author through the GitHub
+// commits API.
+type GitHubCommitActivity struct {
+	Token   string
+	Client  *http.Client
+	BaseURL string // GitHub API base; "" for DefaultGitHubAPIURL
+}
+
+// LastCommitBy returns the date of handle's most recent commit on the
+// default branch of the GitHub repository at repoURL.
+func (a GitHubCommitActivity) LastCommitBy(repoURL, handle string) (time.Time, bool, error) {
+	org, repo, err := ParseGitHubURL(repoURL)
+	if err != nil || repo == "" {
+		return time.Time{}, false, fmt.Errorf("%s is not a GitHub repository", repoURL)
+	}
+	return latestGitHubCommit(a.Client, a.BaseURL, a.Token, org, repo, url.Values{"author": {handle}, "per_page": {"1"}})
+}
+
+// CheckMaintainerActivity finds each maintainer's latest commit across
+// repos. A maintainer with no commit within thresholdDays is inactive; one
+// whose lookups failed is not judged. Lookups run in parallel, up to
+// DefaultFetchConcurrency at a time.
+func CheckMaintainerActivity(handles, repos []string, src CommitActivitySource, thresholdDays int) []MaintainerActivity {
+	results := make([]MaintainerActivity, len(handles))
+	sem := make(chan struct{}, DefaultFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, handle := range handles {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a := MaintainerActivity{Handle: handle, DaysSince: -1}
+			var errs []string
+			for _, repo := range repos {
+				sem <- struct{}{}
+				t, found, err := src.LastCommitBy(repo, handle)
+				<-sem
+				if err != nil {
+					errs = append(errs, err.Error())
+				} else if found && t.After(a.LastCommit) {
+					a.LastCommit, a.Repository = t, repo
+				}
+			}
+			if !a.LastCommit.IsZero() {
+				a.DaysSince = int(time.Since(a.LastCommit).Hours() / 24)
+			}
+			switch {
+			case a.DaysSince >= 0 && a.DaysSince <= thresholdDays:
+			case len(errs) > 0:
+				a.LookupError = strings.Join(errs, "; ")
+			default:
+				a.Inactive = true
+			}
+			results[i] = a
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// FormatMaintainerActivity renders activity results as a text table.
+func FormatMaintainerActivity(results []MaintainerActivity, thresholdDays int) string {
+	var b strings.Builder
+	b.WriteString("Maintainer Activity Report\n")
+	b.WriteString("==========================\n\n")
+
+	inactive := 0
+	for _, a := range results {
+		status := "active"
+		switch {
+		case a.Inactive:
+			status = "INACTIVE"
+			inactive++
+		case a.LookupError != "":
+			status = "unknown"
+		}
+		last := "never"
+		if !a.LastCommit.IsZero() {
+			last = fmt.Sprintf("%s (%d days ago, %s)", a.LastCommit.Format("2006-01-02"), a.DaysSince, a.Repository)
+		}
+		b.WriteString(fmt.Sprintf("  %-8s @%s: last commit %s\n", status, a.Handle, last))
+		if a.LookupError != "" {
+			b.WriteString(fmt.Sprintf("           lookup failed: %s\n", a.LookupError))
+		}
+	}
+
+	b.WriteString(fmt.Sprintf("\nSummary: %d maintainers checked, %d without a commit in %d days\n", len(results), inactive, thresholdDays))
+	return b.String()
+}
@@ -0,0 +1,164 @@
+package projects
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// gitRepo creates a repository with one commit per date, each touching the
+// named file, and returns its directory.
+func gitRepo(t *testing.T, commits map[string]string) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(env []string, args ...string) {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(cmd.Environ(), env...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	run(nil, "init", "-q")
+	for _, date := range sortedKeys(commits) {
+		file := commits[date]
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0755); err != nil {
+			t.Fatal(err)
+		}
+		writeFile(t, filepath.Join(dir, file), date+"\n")
+		run(nil, "add", file)
+		run([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date, "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@example.com", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@example.com"},
+			"commit", "-q", "-m", "update "+file)
+	}
+	return dir
+}
+
+func TestLocalGitHistory(t *testing.T) {
+	dir := gitRepo(t, map[string]string{
+		"2024-01-02T00:00:00Z": "maintainers.yaml",
+		"2024-06-01T00:00:00Z": "project.yaml",
+	})
+	h := LocalGitHistory{Dir: dir}
+
+	got, err := h.LastCommit("maintainers.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("LastCommit = %v, want %v", got, want)
+	}
+	if _, err := h.LastCommit("missing.yaml"); err == nil {
+		t.Error("expected an error for a path without commits")
+	}
+}
+
+func TestLocalGitHistoryShallowClone(t *testing.T) {
+	origin := gitRepo(t, map[string]string{
+		"2024-01-02T00:00:00Z": "maintainers.yaml",
+		"2024-06-01T00:00:00Z": "project.yaml",
+	})
+	clone := filepath.Join(t.TempDir(), "clone")
+	if out, err := exec.Command("git", "clone", "-q", "--depth", "1", "file://"+origin, clone).CombinedOutput(); err != nil {
+		t.Fatalf("clone: %v\n%s", err, out)
+	}
+	_, err := LocalGitHistory{Dir: clone}.LastCommit("maintainers.yaml")
+	if err == nil || !strings.Contains(err.Error(), "shallow") {
+		t.Errorf("err = %v, want a shallow clone error", err)
+	}
+}
+
+func TestGitHubCommitHistory(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/test/.project/commits" || r.URL.Query().Get("per_page") != "1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("path") == "maintainers.yaml" {
+			w.Write([]byte(`[{"commit": {"committer": {"date": "2024-01-02T00:00:00Z"}}}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	h := GitHubCommitHistory{Org: "test", Repo: ".project", BaseURL: server.URL}
+
+	got, err := h.LastCommit("maintainers.yaml")
+	if err != nil || !got.Equal(time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("LastCommit = %v, %v", got, err)
+	}
+	if _, err := h.LastCommit("other.yaml"); err == nil {
+		t.Error("expected an error for a path without commits")
+	}
+	if _, err := (GitHubCommitHistory{Org: "nope", Repo: "x", BaseURL: server.URL}).LastCommit("maintainers.yaml"); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
+
+type fakeActivity map[string]time.Time // "repo handle" -> last commit
+
+func (f fakeActivity) LastCommitBy(repo, handle string) (time.Time, bool, error) {
+	if handle == "flaky" {
+		return time.Time{}, false, errors.New("rate limited")
+	}
+	t, ok := f[repo+" "+handle]
+	return t, ok, nil
+}
+
+func TestCheckMaintainerActivity(t *testing.T) {
+	now := time.Now()
+	src := fakeActivity{
+		"r1 alice": now.AddDate(0, 0, -400),
+		"r2 alice": now.AddDate(0, 0, -10),
+		"r1 bob":   now.AddDate(0, 0, -300),
+	}
+	results := CheckMaintainerActivity([]string{"alice", "bob", "carol", "flaky"}, []string{"r1", "r2"}, src, 180)
+
+	alice, bob, carol, flaky := results[0], results[1], results[2], results[3]
+	if alice.Inactive || alice.Repository != "r2" || alice.DaysSince != 10 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if !bob.Inactive || bob.DaysSince != 300 {
+		t.Errorf("bob = %+v", bob)
+	}
+	if !carol.Inactive || carol.DaysSince != -1 {
+		t.Errorf("carol = %+v", carol)
+	}
+	if flaky.Inactive || flaky.LookupError == "" {
+		t.Errorf("flaky = %+v", flaky)
+	}
+
+	out := FormatMaintainerActivity(results, 180)
+	if !strings.Contains(out, "INACTIVE @bob") || !strings.Contains(out, "2 without a commit in 180 days") {
+		t.Errorf("unexpected report:\n%s", out)
+	}
+}
+
+func TestGitHubCommitActivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("author") == "alice" {
+			w.Write([]byte(`[{"commit": {"committer": {"date": "2024-03-04T00:00:00Z"}}}]`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+	a := GitHubCommitActivity{BaseURL: server.URL}
+
+	if got, found, err := a.LastCommitBy("https://github.com/test/repo", "alice"); !found || err != nil || got.Year() != 2024 {
+		t.Errorf("alice = %v, %v, %v", got, found, err)
+	}
+	if _, found, err := a.LastCommitBy("https://github.com/test/repo", "bob"); found || err != nil {
+		t.Errorf("bob = %v, %v", found, err)
+	}
+	if _, _, err := a.LastCommitBy("https://gitlab.com/test/repo", "alice"); err == nil {
+		t.Error("expected an error for a non-GitHub repository")
+	}
+}
@@ -0,0 +1,267 @@
+package projects
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// stalenessIssueMarker is the hidden comment that identifies the tracking
+// issue in a .project repository. The notifier finds its issue by this
+// marker, never by title, so maintainers are free to retitle it.
+const stalenessIssueMarker = "<!-- dot-project:staleness-reminder -->"
+
+// stalenessIssueLabel is applied to new tracking issues when the repository
+// has it. A missing label does not stop the issue from being filed.
+const stalenessIssueLabel = "maintainers"
+
+// StalenessIssue is the tracking issue the notifier files for a stale
+// project.
+type StalenessIssue struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// NewStalenessIssue returns the tracking issue for a stale result. The body
+// starts with the hidden marker. It leaves out the day count, which changes
+// daily, so that the issue is only edited when the last update date, the
+// threshold or the contacts change.
+func NewStalenessIssue(result StalenessResult, thresholdDays int) StalenessIssue {
+	var b strings.Builder
+	b.WriteString(stalenessIssueMarker + "\n")
+	b.WriteString(fmt.Sprintf("The maintainer list of **%s** has not been updated in over %d days, the review threshold.\n\n",
+		result.ProjectSlug, thresholdDays))
+	b.WriteString(fmt.Sprintf("`maintainers.yaml` last changed on %s", result.LastMaintainerUpdate.Format("2006-01-02")))
+	if result.UpdateSource != "" {
+		b.WriteString(fmt.Sprintf(" (according to %s)", result.UpdateSource))
+	}
+	b.WriteString(".\n\n")
+	b.WriteString("Please check that every listed maintainer is still active, add new maintainers, and move inactive ones to emeritus. ")
+	b.WriteString("If the list is already accurate, a commit that touches `maintainers.yaml` (for example a comment with the review date) is enough. ")
+	b.WriteString("This issue is closed automatically on the first check after the file is updated.\n")
+	if result.ProjectLead != "" || result.SlackChannel != "" {
+		b.WriteString("\n")
+	}
+	if result.ProjectLead != "" {
+		leads := strings.Split(result.ProjectLead, ", ")
+		b.WriteString(fmt.Sprintf("- Project lead: @%s\n", strings.Join(leads, ", @")))
+	}
+	if result.SlackChannel != "" {
+		b.WriteString(fmt.Sprintf("- Slack: %s\n", result.SlackChannel))
+	}
+	return StalenessIssue{
+		Title: fmt.Sprintf("Review maintainers: %s not updated in over %d days", result.ProjectSlug, thresholdDays),
+		Body:  b.String(),
+	}
+}
+
+// Notifier actions.
+const (
+	NotifyOpen   = "open"   // a new tracking issue is filed
+	NotifyUpdate = "update" // the open tracking issue gets the current body
+	NotifyClose  = "close"  // the project is fresh again and the issue is closed
+	NotifyNone   = "none"   // nothing to do
+)
+
+// NotifyResult is what the notifier did, or would do in a dry run.
+type NotifyResult struct {
+	Action   string         `json:"action"`
+	Issue    StalenessIssue `json:"issue"`
+	Number   int            `json:"number,omitempty"` // Existing or newly filed issue
+	IssueURL string         `json:"issue_url,omitempty"`
+	DryRun   bool           `json:"dry_run,omitempty"`
+}
+
+// StalenessNotifier keeps one tracking issue in a .project repository in
+// step with a project's staleness: it opens the issue when the project goes
+// stale, updates it while it stays stale, and closes it when fresh.
+type StalenessNotifier struct {
+	Org     string
+	Repo    string
+	Token   string
+	Client  *http.Client
+	BaseURL string // "" means DefaultGitHubAPIURL
+	DryRun  bool   // Look up the existing issue but change nothing
+}
+
+// githubIssue is the part of a GitHub issue the notifier reads.
+type githubIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// notifyUpdateSources are the update sources trusted to date
+// maintainers.yaml. A modification time reads as fresh in any new checkout
+// and would close the tracking issue without anyone reviewing the file.
+var notifyUpdateSources = map[string]bool{"git": true, "github": true, "override": true}
+
+// Notify brings the tracking issue in line with result. Running it again
+// with the same result changes nothing. Results that could not be checked,
+// or whose date does not come from git history, the GitHub API or an
+// explicit override, are rejected rather than treated as fresh.
+func (n StalenessNotifier) Notify(result StalenessResult, thresholdDays int) (NotifyResult, error) {
+	if result.Error != "" {
+		return NotifyResult{}, fmt.Errorf("project could not be checked: %s", result.Error)
+	}
+	if !notifyUpdateSources[result.UpdateSource] {
+		return NotifyResult{}, fmt.Errorf("the last update was dated by %q; notifying needs a date from git, github or -last-update", result.UpdateSource)
+	}
+	if n.Token == "" && !n.DryRun {
+		return NotifyResult{}, errors.New("filing issues requires a GitHub token (set GITHUB_TOKEN)")
+	}
+	out := NotifyResult{Action: NotifyNone, DryRun: n.DryRun}
+	existing, err := n.findIssue()
+	if err != nil {
+		return out, err
+	}
+	if existing != nil {
+		out.Number = existing.Number
+		out.IssueURL = existing.HTMLURL
+	}
+
+	repoPath := fmt.Sprintf("/repos/%s/%s/issues", n.Org, n.Repo)
+	switch {
+	case result.IsStale:
+		out.Issue = NewStalenessIssue(result, thresholdDays)
+		if existing == nil {
+			out.Action = NotifyOpen
+			if n.DryRun {
+				return out, nil
+			}
+			var created githubIssue
+			if err := n.send("POST", repoPath, map[string]any{"title": out.Issue.Title, "body": out.Issue.Body}, &created); err != nil {
+				return out, fmt.Errorf("opening issue: %w", err)
+			}
+			out.Number, out.IssueURL = created.Number, created.HTMLURL
+			// Best effort: the label may not exist in every repository.
+			_ = n.send("POST", fmt.Sprintf("%s/%d/labels", repoPath, created.Number), map[string]any{"labels": []string{stalenessIssueLabel}}, nil)
+			return out, nil
+		}
+		// Keep a title the maintainers changed; only the body is ours.
+		out.Issue.Title = existing.Title
+		if existing.Body == out.Issue.Body {
+			return out, nil
+		}
+		out.Action = NotifyUpdate
+		if n.DryRun {
+			return out, nil
+		}
+		if err := n.send("PATCH", fmt.Sprintf("%s/%d", repoPath, existing.Number), map[string]any{"body": out.Issue.Body}, nil); err != nil {
+			return out, fmt.Errorf("updating issue #%d: %w", existing.Number, err)
+		}
+	case existing != nil:
+		out.Action = NotifyClose
+		out.Issue = StalenessIssue{Title: existing.Title, Body: existing.Body}
+		if n.DryRun {
+			return out, nil
+		}
+		comment := fmt.Sprintf("`maintainers.yaml` was updated on %s. Closing.", result.LastMaintainerUpdate.Format("2006-01-02"))
+		if err := n.send("POST", fmt.Sprintf("%s/%d/comments", repoPath, existing.Number), map[string]any{"body": comment}, nil); err != nil {
+			return out, fmt.Errorf("commenting on issue #%d: %w", existing.Number, err)
+		}
+		if err := n.send("PATCH", fmt.Sprintf("%s/%d", repoPath, existing.Number), map[string]any{"state": "closed", "state_reason": "completed"}, nil); err != nil {
+			return out, fmt.Errorf("closing issue #%d: %w", existing.Number, err)
+		}
+	}
+	return out, nil
+}
+
+// findIssue returns the open issue whose body carries the marker, or nil.
+// Open issues are listed rather than searched, because the search index
+// lags behind and would let two runs in quick succession file duplicates.
+func (n StalenessNotifier) findIssue() (*githubIssue, error) {
+	for page := 1; ; page++ {
+		resp, err := githubGet(n.Client, n.BaseURL, n.Token, fmt.Sprintf("/repos/%s/%s/issues?state=open&per_page=100&page=%d", n.Org, n.Repo, page))
+		if err != nil {
+			return nil, fmt.Errorf("listing issues in %s/%s: %w", n.Org, n.Repo, err)
+		}
+		var issues []githubIssue
+		err = decodeGitHubResponse(resp, &issues)
+		if err != nil {
+			return nil, fmt.Errorf("listing issues in %s/%s: %w", n.Org, n.Repo, err)
+		}
+		for i, issue := range issues {
+			if issue.PullRequest == nil && strings.Contains(issue.Body, stalenessIssueMarker) {
+				return &issues[i], nil
+			}
+		}
+		if len(issues) < 100 {
+			return nil, nil
+		}
+	}
+}
+
+// send makes a GitHub API write request with a JSON body and decodes the
+// response into out when it is not nil.
+func (n StalenessNotifier) send(method, endpoint string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	baseURL := n.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	req, err := http.NewRequest(method, strings.TrimRight(baseURL, "/")+endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "token "+n.Token)
+	req.Header.Set("Accept", "application/vnd.github.v3+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", bootstrapUserAgent)
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: DefaultHTTPTimeout}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	return decodeGitHubResponse(resp, out)
+}
+
+// decodeGitHubResponse closes resp and decodes its JSON body into out, or
+// returns an error for a non-2xx status.
+func decodeGitHubResponse(resp *http.Response, out any) error {
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("GitHub API returned HTTP %d: %s%s", resp.StatusCode, strings.TrimSpace(string(msg)), rateLimitHint(resp))
+	}
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// FormatNotifyResult describes a notifier result. In a dry run it includes
+// the issue that would be filed.
+func FormatNotifyResult(r NotifyResult, org, repo string) string {
+	var b strings.Builder
+	verb := map[string]string{NotifyOpen: "Opened", NotifyUpdate: "Updated", NotifyClose: "Closed"}[r.Action]
+	if r.DryRun {
+		verb = map[string]string{NotifyOpen: "Would open", NotifyUpdate: "Would update", NotifyClose: "Would close"}[r.Action]
+	}
+	switch {
+	case r.Action == NotifyNone && r.Number != 0:
+		b.WriteString(fmt.Sprintf("Tracking issue %s/%s#%d is up to date\n", org, repo, r.Number))
+	case r.Action == NotifyNone:
+		b.WriteString(fmt.Sprintf("No tracking issue needed in %s/%s\n", org, repo))
+	case r.Number != 0:
+		b.WriteString(fmt.Sprintf("%s tracking issue %s/%s#%d\n", verb, org, repo, r.Number))
+	default:
+		b.WriteString(fmt.Sprintf("%s a tracking issue in %s/%s\n", verb, org, repo))
+	}
+	if r.DryRun && (r.Action == NotifyOpen || r.Action == NotifyUpdate) {
+		b.WriteString(fmt.Sprintf("\nTitle: %s\n\n%s", r.Issue.Title, r.Issue.Body))
+	}
+	return b.String()
+}
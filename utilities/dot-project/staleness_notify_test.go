@@ -0,0 +1,204 @@
+package projects
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeIssueTracker is an in-memory GitHub issues API for one repository.
+type fakeIssueTracker struct {
+	mu     sync.Mutex
+	issues map[int]*fakeIssue
+	next   int
+	writes []string
+}
+
+type fakeIssue struct {
+	Number      int       `json:"number"`
+	Title       string    `json:"title"`
+	Body        string    `json:"body"`
+	State       string    `json:"state"`
+	HTMLURL     string    `json:"html_url"`
+	PullRequest *struct{} `json:"pull_request,omitempty"`
+}
+
+func newFakeIssueTracker(t *testing.T) (*fakeIssueTracker, *httptest.Server) {
+	t.Helper()
+	f := &fakeIssueTracker{issues: make(map[int]*fakeIssue), next: 1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		const base = "/repos/test/.project/issues"
+		if !strings.HasPrefix(r.URL.Path, base) {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Method != http.MethodGet {
+			f.writes = append(f.writes, r.Method+" "+r.URL.Path)
+		}
+		var body map[string]any
+		if r.Method != http.MethodGet {
+			json.NewDecoder(r.Body).Decode(&body)
+		}
+		rest := strings.TrimPrefix(r.URL.Path, base)
+		switch {
+		case r.Method == http.MethodGet && rest == "":
+			open := []*fakeIssue{}
+			for n := 1; n < f.next; n++ {
+				if f.issues[n].State == "open" {
+					open = append(open, f.issues[n])
+				}
+			}
+			json.NewEncoder(w).Encode(open)
+		case r.Method == http.MethodPost && rest == "":
+			issue := &fakeIssue{Number: f.next, Title: body["title"].(string), Body: body["body"].(string), State: "open",
+				HTMLURL: fmt.Sprintf("https://github.com/test/.project/issues/%d", f.next)}
+			f.issues[f.next] = issue
+			f.next++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(issue)
+		case r.Method == http.MethodPatch:
+			var n int
+			fmt.Sscanf(rest, "/%d", &n)
+			issue := f.issues[n]
+			if b, ok := body["body"].(string); ok {
+				issue.Body = b
+			}
+			if s, ok := body["state"].(string); ok {
+				issue.State = s
+			}
+			json.NewEncoder(w).Encode(issue)
+		case r.Method == http.MethodPost:
+			// labels and comments
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte("{}"))
+		default:
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+		}
+	}))
+	return f, srv
+}
+
+func staleResult(days int) StalenessResult {
+	return StalenessResult{
+		ProjectSlug:          "test-project",
+		LastMaintainerUpdate: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		UpdateSource:         "git",
+		DaysSinceUpdate:      days,
+		IsStale:              days > 180,
+		ProjectLead:          "alice, bob",
+		SlackChannel:         "#test",
+	}
+}
+
+func TestStalenessNotifierLifecycle(t *testing.T) {
+	tracker, srv := newFakeIssueTracker(t)
+	defer srv.Close()
+	n := StalenessNotifier{Org: "test", Repo: ".project", Token: "test-token", BaseURL: srv.URL}
+
+	r, err := n.Notify(staleResult(200), 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Action != NotifyOpen || r.Number != 1 {
+		t.Fatalf("first run = %+v, want an opened issue #1", r)
+	}
+	body := tracker.issues[1].Body
+	for _, want := range []string{stalenessIssueMarker, "over 180 days", "2024-01-02", "@alice, @bob", "#test"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("issue body missing %q:\n%s", want, body)
+		}
+	}
+
+	// The same result again is a no-op.
+	writes := len(tracker.writes)
+	if r, err = n.Notify(staleResult(200), 180); err != nil || r.Action != NotifyNone || r.Number != 1 {
+		t.Fatalf("repeat run = %+v, %v; want no action on #1", r, err)
+	}
+	if len(tracker.writes) != writes {
+		t.Errorf("repeat run wrote %v", tracker.writes[writes:])
+	}
+
+	// A renamed issue is still found by its marker, and only the body changes.
+	tracker.issues[1].Title = "Renamed by a maintainer"
+	if r, err = n.Notify(staleResult(230), 200); err != nil || r.Action != NotifyUpdate {
+		t.Fatalf("run with a new threshold = %+v, %v; want an update", r, err)
+	}
+	if tracker.issues[1].Title != "Renamed by a maintainer" || !strings.Contains(tracker.issues[1].Body, "over 200 days") {
+		t.Errorf("issue after update = %+v", tracker.issues[1])
+	}
+	if len(tracker.issues) != 1 {
+		t.Errorf("%d issues filed, want 1", len(tracker.issues))
+	}
+
+	if r, err = n.Notify(staleResult(3), 180); err != nil || r.Action != NotifyClose {
+		t.Fatalf("fresh run = %+v, %v; want the issue closed", r, err)
+	}
+	if tracker.issues[1].State != "closed" {
+		t.Errorf("issue state = %s, want closed", tracker.issues[1].State)
+	}
+	if r, err = n.Notify(staleResult(3), 180); err != nil || r.Action != NotifyNone {
+		t.Errorf("fresh run without an issue = %+v, %v; want no action", r, err)
+	}
+}
+
+func TestStalenessNotifierConsecutiveDays(t *testing.T) {
+	tracker, srv := newFakeIssueTracker(t)
+	defer srv.Close()
+	n := StalenessNotifier{Org: "test", Repo: ".project", Token: "test-token", BaseURL: srv.URL}
+
+	if r, err := n.Notify(staleResult(200), 180); err != nil || r.Action != NotifyOpen {
+		t.Fatalf("first day = %+v, %v; want an opened issue", r, err)
+	}
+	writes := len(tracker.writes)
+	r, err := n.Notify(staleResult(201), 180)
+	if err != nil || r.Action != NotifyNone {
+		t.Fatalf("next day = %+v, %v; want no action", r, err)
+	}
+	if len(tracker.writes) != writes {
+		t.Errorf("next day wrote %v", tracker.writes[writes:])
+	}
+}
+
+func TestStalenessNotifierDryRun(t *testing.T) {
+	tracker, srv := newFakeIssueTracker(t)
+	defer srv.Close()
+	n := StalenessNotifier{Org: "test", Repo: ".project", BaseURL: srv.URL, DryRun: true}
+
+	r, err := n.Notify(staleResult(200), 180)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Action != NotifyOpen || len(tracker.writes) != 0 {
+		t.Errorf("dry run = %+v with writes %v; want a planned open and no writes", r, tracker.writes)
+	}
+	out := FormatNotifyResult(r, "test", ".project")
+	for _, want := range []string{"Would open a tracking issue in test/.project", "Title: Review maintainers: test-project", stalenessIssueMarker} {
+		if !strings.Contains(out, want) {
+			t.Errorf("dry run output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestStalenessNotifierRejectsUncheckedProjects(t *testing.T) {
+	n := StalenessNotifier{Org: "test", Repo: ".project", Token: "test-token", BaseURL: "http://127.0.0.1:0"}
+	if _, err := n.Notify(StalenessResult{Error: "fetching project: 404"}, 180); err == nil {
+		t.Error("expected an error for a project that could not be checked")
+	}
+	for _, source := range []string{"mtime", ""} {
+		fresh := staleResult(3)
+		fresh.UpdateSource = source
+		if _, err := n.Notify(fresh, 180); err == nil || !strings.Contains(err.Error(), "dated by") {
+			t.Errorf("source %q: err = %v, want a refusal", source, err)
+		}
+	}
+	if _, err := (StalenessNotifier{Org: "test", Repo: ".project"}).Notify(staleResult(200), 180); err == nil || !strings.Contains(err.Error(), "token") {
+		t.Errorf("err = %v, want a token error", err)
+	}
+}
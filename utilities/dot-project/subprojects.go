@@ -0,0 +1,88 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SubprojectMaturity returns the maturity phase of a subproject, falling back
+// to the parent project's current phase when the subproject sets none.
+func SubprojectMaturity(project Project, sp Subproject) string {
+	if sp.Maturity != "" {
+		return sp.Maturity
+	}
+	return CurrentMaturityPhase(project)
+}
+
+// subprojectKey identifies a subproject for duplicate detection: its slug,
+// or its lowercased name when no slug is given.
+func subprojectKey(sp Subproject) string {
+	if sp.Slug != "" {
+		return sp.Slug
+	}
+	return strings.ToLower(strings.TrimSpace(sp.Name))
+}
+
+// validateSubprojects checks each subprojects entry with the same rules as
+// the top-level project and rejects duplicate entries.
+func validateSubprojects(subprojects []Subproject) []Diagnostic {
+	var diags []Diagnostic
+	firstIndex := make(map[string]int)
+	for i, sp := range subprojects {
+		path := fmt.Sprintf("subprojects[%d]", i)
+		if sp.Name == "" {
+			diags = append(diags, errorDiag(path+".name", RuleRequired, "%s.name is required", path))
+		}
+		if sp.Slug != "" && !isValidSlug(sp.Slug) {
+			d := errorDiag(path+".slug", RuleSlugFormat, "%s.slug must be lowercase alphanumeric with hyphens, got: %s", path, sp.Slug)
+			if s := suggestSlug(sp.Slug); s != "" {
+				d = d.withFix("use slug %q", s)
+			}
+			diags = append(diags, d)
+		}
+		if key := subprojectKey(sp); key != "" {
+			if j, dup := firstIndex[key]; dup {
+				diags = append(diags, errorDiag(path, RuleDuplicateSubproject, "%s duplicates subprojects[%d]: %s", path, j, key).
+					withFix("merge the two entries or give them distinct names"))
+			} else {
+				firstIndex[key] = i
+			}
+		}
+		if sp.Website != "" && !isValidURL(sp.Website) {
+			diags = append(diags, errorDiag(path+".website", RuleURLFormat, "%s.website is not a valid URL: %s", path, sp.Website))
+		}
+		if sp.Maturity != "" && !ValidMaturityPhases[sp.Maturity] {
+			diags = append(diags, errorDiag(path+".maturity", RuleMaturityPhase, "%s.maturity has invalid value %q (allowed: sandbox, incubating, graduated, archived)", path, sp.Maturity))
+		}
+		diags = append(diags, validateRepositories(path+".repositories", sp.Repositories)...)
+		diags = append(diags, validateLeads(path+".project_lead", sp.ProjectLeads)...)
+		diags = append(diags, validateSlackChannels(path+".slack_channels", sp.SlackChannels)...)
+	}
+	return diags
+}
+
+// SubprojectLandscapeEntries converts each subproject into a landscape entry
+// linked to its parent through extra.parent_project. The website and
+// artwork fall back to the parent project's.
+func SubprojectLandscapeEntries(project Project) []LandscapeEntry {
+	var entries []LandscapeEntry
+	for _, sp := range project.Subprojects {
+		p := Project{
+			Name:          sp.Name,
+			Slug:          sp.Slug,
+			Description:   sp.Description,
+			Website:       sp.Website,
+			Artwork:       project.Artwork,
+			Repositories:  sp.Repositories,
+			SlackChannels: sp.SlackChannels,
+		}
+		if p.Website == "" {
+			p.Website = project.Website
+		}
+		entry := ProjectToLandscapeEntry(p)
+		entry.Project = SubprojectMaturity(project, sp)
+		entry.Extra["parent_project"] = project.Name
+		entries = append(entries, entry)
+	}
+	return entries
+}
@@ -0,0 +1,137 @@
+package projects
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+const subprojectsYAML = `subprojects:
+  - name: Kubectl
+    slug: kubectl
+    repositories:
+      - https://github.com/kubernetes/kubectl
+    project_lead: "@alice"
+    slack_channels:
+      - name: "#kubectl"
+        workspace: kubernetes
+        link: https://kubernetes.slack.com/archives/kubectl
+  - name: Kind
+    website: https://kind.sigs.k8s.io
+    maturity: incubating
+    repositories:
+      - url: https://github.com/kubernetes-sigs/kind
+        primary: true
+`
+
+func TestValidateSubprojects(t *testing.T) {
+	sp := func(name string) Subproject {
+		return Subproject{Name: name, Repositories: []RepositoryEntry{{URL: "https://github.com/test/" + name}}}
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(*Subproject)
+		path   string
+		rule   string
+	}{
+		{"valid", func(*Subproject) {}, "", ""},
+		{"missing name", func(s *Subproject) { s.Name = "" }, "subprojects[0].name", RuleRequired},
+		{"bad slug", func(s *Subproject) { s.Slug = "Bad_Slug" }, "subprojects[0].slug", RuleSlugFormat},
+		{"no repositories", func(s *Subproject) { s.Repositories = nil }, "subprojects[0].repositories", RuleRequired},
+		{"bad repository", func(s *Subproject) { s.Repositories[0].URL = "nope" }, "subprojects[0].repositories[0]", RuleURLFormat},
+		{"bad lead", func(s *Subproject) { s.ProjectLeads = StringOrSlice{"org/"} }, "subprojects[0].project_lead[0]", RuleProjectLeadFormat},
+		{"slack name", func(s *Subproject) { s.SlackChannels = []SlackChannel{{Name: "dev"}} }, "subprojects[0].slack_channels[0].name", RuleSlackChannelName},
+		{"bad maturity", func(s *Subproject) { s.Maturity = "beta" }, "subprojects[0].maturity", RuleMaturityPhase},
+		{"bad website", func(s *Subproject) { s.Website = "kind" }, "subprojects[0].website", RuleURLFormat},
+	}
+	for _, tt := range tests {
+		s := sp("kind")
+		tt.mutate(&s)
+		diags := validateSubprojects([]Subproject{s})
+		if tt.path == "" {
+			if len(diags) != 0 {
+				t.Errorf("%s: unexpected diagnostics %+v", tt.name, diags)
+			}
+			continue
+		}
+		if d, ok := findDiagnostic(diags, tt.path); !ok || d.Rule != tt.rule {
+			t.Errorf("%s: diagnostics = %+v, want %s at %s", tt.name, diags, tt.rule, tt.path)
+		}
+	}
+
+	diags := validateSubprojects([]Subproject{sp("kind"), sp("Kind")})
+	if d, ok := findDiagnostic(diags, "subprojects[1]"); !ok || d.Rule != RuleDuplicateSubproject {
+		t.Errorf("duplicate diagnostics = %+v", diags)
+	}
+}
+
+func TestValidateProjectWithSubprojects(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "project.yaml")
+	writeFile(t, path, validProjectYAML()+subprojectsYAML)
+
+	pv := newTestValidator(t)
+	pv.SetPolicy(nil)
+	result, err := pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || len(result.Diagnostics) != 0 {
+		t.Errorf("valid subprojects rejected: %+v", result.Diagnostics)
+	}
+
+	writeFile(t, path, validProjectYAML()+subprojectsYAML+"  - name: Broken\n    maturity: beta\n    repositories: [https://github.com/test/broken]\n")
+	result, err = pv.validateProject(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, ok := findDiagnostic(result.Diagnostics, "subprojects[2].maturity")
+	if !ok || d.Rule != RuleMaturityPhase || d.Line == 0 {
+		t.Errorf("diagnostic = %+v, want a located maturity-phase error", d)
+	}
+	for _, d := range result.Diagnostics {
+		if d.Rule == RuleSchemaDisagreement {
+			t.Errorf("JSON Schema and Go rules disagree: %+v", d)
+		}
+	}
+}
+
+func TestSubprojectLandscapeEntries(t *testing.T) {
+	p := validBaseProject()
+	p.Website = "https://test-project.io"
+	p.Subprojects = []Subproject{
+		{Name: "Kubectl", Slug: "kubectl", Repositories: []RepositoryEntry{{URL: "https://github.com/test/kubectl"}},
+			SlackChannels: []SlackChannel{{Name: "#kubectl", Link: "https://test.slack.com/archives/kubectl"}}},
+		{Name: "Kind", Website: "https://kind.sigs.k8s.io", Maturity: "incubating",
+			Repositories: []RepositoryEntry{{URL: "https://github.com/test/other"}, {URL: "https://github.com/test/kind", Primary: true}}},
+	}
+
+	entries := SubprojectLandscapeEntries(p)
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+	kubectl, kind := entries[0], entries[1]
+	if kubectl.HomepageURL != "https://test-project.io" || kubectl.Project != "sandbox" || kubectl.Extra["parent_project"] != "Test Project" ||
+		kubectl.Extra["slug"] != "kubectl" || kubectl.Extra["slack_url"] != "https://test.slack.com/archives/kubectl" {
+		t.Errorf("kubectl entry = %+v", kubectl)
+	}
+	if kind.HomepageURL != "https://kind.sigs.k8s.io" || kind.RepoURL != "https://github.com/test/kind" || kind.Project != "incubating" {
+		t.Errorf("kind entry = %+v", kind)
+	}
+	if _, ok := kind.Extra["slug"]; ok {
+		t.Errorf("kind entry has a slug without one in project.yaml: %+v", kind.Extra)
+	}
+}
+
+func TestCollectProjectURLsIncludesSubprojects(t *testing.T) {
+	p := validBaseProject()
+	p.Subprojects = []Subproject{{Name: "Kind", Website: "https://kind.sigs.k8s.io", Repositories: []RepositoryEntry{{URL: "https://github.com/test/kind"}}}}
+	fields := map[string]bool{}
+	for _, c := range collectProjectURLs(p) {
+		fields[c.Field] = true
+	}
+	for _, want := range []string{"subprojects[0].website", "subprojects[0].repositories[0]"} {
+		if !fields[want] {
+			t.Errorf("collectProjectURLs missing %s: %v", want, fields)
+		}
+	}
+}
@@ -20,3 +20,10 @@ func validBaseProject() Project {
 		Repositories: []RepositoryEntry{{URL: "https://github.com/test/repo"}},
 	}
 }
+
+// newTeam builds a team from members in either form.
+func newTeam(name string, members ...TeamMember) Team {
+	team := Team{Name: name}
+	team.setMembers(members)
+	return team
+}
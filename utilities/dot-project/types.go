@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -189,6 +190,10 @@ type Project struct {
 
 	// CNCF Landscape integration
 	Landscape *LandscapeConfig `json:"landscape,omitempty" yaml:"landscape,omitempty"`
+
+	// Subprojects lists structured sub-project entries for large projects.
+	// governance.sub_project_list may still link to a human-readable list.
+	Subprojects []Subproject `json:"subprojects,omitempty" yaml:"subprojects,omitempty"`
 }
 
 // SlackChannel represents a single Slack channel for a project.
@@ -201,6 +206,20 @@ type SlackChannel struct {
 	Primary   bool   `json:"primary,omitempty" yaml:"primary,omitempty"`     // Whether this is the primary channel for end-users
 }
 
+// Subproject is a sub-project of a larger project, such as a Kubernetes SIG
+// subproject. Fields share their names and rules with the top-level project;
+// an empty Maturity means the subproject shares the project's phase.
+type Subproject struct {
+	Name          string            `json:"name" yaml:"name"`
+	Slug          string            `json:"slug,omitempty" yaml:"slug,omitempty"`
+	Description   string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Website       string            `json:"website,omitempty" yaml:"website,omitempty"`
+	Repositories  []RepositoryEntry `json:"repositories" yaml:"repositories"`
+	ProjectLeads  StringOrSlice     `json:"project_lead,omitempty" yaml:"project_lead,omitempty"`
+	SlackChannels []SlackChannel    `json:"slack_channels,omitempty" yaml:"slack_channels,omitempty"`
+	Maturity      string            `json:"maturity,omitempty" yaml:"maturity,omitempty"` // sandbox, incubating, graduated or archived
+}
+
 // LandscapeConfig maps the project to its CNCF Landscape location
 type LandscapeConfig struct {
 	Category    string `json:"category" yaml:"category"`
@@ -291,10 +310,166 @@ type MaintainerEntry struct {
 	Teams     []Team `json:"teams" yaml:"teams"`
 }
 
-// Team represents a GitHub team and its members
+// Team represents a GitHub team and its members. Members may be written as
+// plain handles or as objects with contact details; see TeamMember.
 type Team struct {
 	Name    string   `json:"name" yaml:"name"`
 	Members []string `json:"members" yaml:"members"`
+
+	// MemberDetails holds the members written in the expanded form, keyed by
+	// handle. Members written as plain handles have no entry.
+	MemberDetails map[string]TeamMember `json:"-" yaml:"-"`
+}
+
+// teamFields is the file form of a Team, with members in either form.
+type teamFields struct {
+	Name    string       `json:"name" yaml:"name"`
+	Members []TeamMember `json:"members" yaml:"members"`
+}
+
+// TeamMembers returns every member with its details, in order.
+func (t Team) TeamMembers() []TeamMember {
+	members := make([]TeamMember, len(t.Members))
+	for i, h := range t.Members {
+		members[i] = t.MemberDetails[h]
+		members[i].Handle = h
+	}
+	return members
+}
+
+// setMembers stores members as handles plus the details of expanded ones.
+func (t *Team) setMembers(members []TeamMember) {
+	t.Members = make([]string, len(members))
+	t.MemberDetails = nil
+	for i, m := range members {
+		t.Members[i] = m.Handle
+		if m.isHandleOnly() {
+			continue
+		}
+		if t.MemberDetails == nil {
+			t.MemberDetails = make(map[string]TeamMember)
+		}
+		t.MemberDetails[m.Handle] = m
+	}
+}
+
+// UnmarshalYAML accepts members as plain handles or as mappings.
+func (t *Team) UnmarshalYAML(value *yaml.Node) error {
+	var aux teamFields
+	if err := value.Decode(&aux); err != nil {
+		return err
+	}
+	*t = Team{Name: aux.Name}
+	if aux.Members != nil {
+		t.setMembers(aux.Members)
+	}
+	return nil
+}
+
+// MarshalYAML writes members with details in the expanded form and the rest
+// as plain handles.
+func (t Team) MarshalYAML() (interface{}, error) {
+	return teamFields{Name: t.Name, Members: t.TeamMembers()}, nil
+}
+
+// UnmarshalJSON accepts members as plain handles or as objects.
+func (t *Team) UnmarshalJSON(data []byte) error {
+	var aux teamFields
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*t = Team{Name: aux.Name}
+	if aux.Members != nil {
+		t.setMembers(aux.Members)
+	}
+	return nil
+}
+
+// MarshalJSON mirrors MarshalYAML.
+func (t Team) MarshalJSON() ([]byte, error) {
+	return json.Marshal(teamFields{Name: t.Name, Members: t.TeamMembers()})
+}
+
+// TeamMember is a member of a maintainers team with its contact details. It
+// supports two YAML forms:
+//
+//	members:
+//	  - "alice"                          # plain GitHub handle (backward-compatible)
+//	  - handle: "bob"                    # expanded object with contact details
+//	    name: "Bob Smith"
+//	    email: "bob@example.com"
+//	    company: "Example Corp"
+//	    role: "maintainer"
+type TeamMember struct {
+	Handle  string `json:"handle" yaml:"handle"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email   string `json:"email,omitempty" yaml:"email,omitempty"`
+	Company string `json:"company,omitempty" yaml:"company,omitempty"`
+	Role    string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// teamMemberFields mirrors TeamMember without its custom (un)marshalers.
+type teamMemberFields struct {
+	Handle  string `json:"handle" yaml:"handle"`
+	Name    string `json:"name,omitempty" yaml:"name,omitempty"`
+	Email   string `json:"email,omitempty" yaml:"email,omitempty"`
+	Company string `json:"company,omitempty" yaml:"company,omitempty"`
+	Role    string `json:"role,omitempty" yaml:"role,omitempty"`
+}
+
+// isHandleOnly reports whether the member carries nothing but a handle.
+func (m TeamMember) isHandleOnly() bool {
+	return m.Name == "" && m.Email == "" && m.Company == "" && m.Role == ""
+}
+
+// UnmarshalYAML allows a team member to be either a plain handle or a mapping.
+func (m *TeamMember) UnmarshalYAML(value *yaml.Node) error {
+	switch value.Kind {
+	case yaml.ScalarNode:
+		*m = TeamMember{Handle: value.Value}
+		return nil
+	case yaml.MappingNode:
+		var aux teamMemberFields
+		if err := value.Decode(&aux); err != nil {
+			return err
+		}
+		*m = TeamMember(aux)
+		return nil
+	default:
+		return fmt.Errorf("expected string or mapping for team member, got YAML node type %v", value.Tag)
+	}
+}
+
+// MarshalYAML writes a member with only a handle as a plain string, so
+// existing maintainers.yaml files round-trip unchanged.
+func (m TeamMember) MarshalYAML() (interface{}, error) {
+	if m.isHandleOnly() {
+		return m.Handle, nil
+	}
+	return teamMemberFields(m), nil
+}
+
+// UnmarshalJSON allows a team member to be either a plain handle or an object.
+func (m *TeamMember) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*m = TeamMember{Handle: s}
+		return nil
+	}
+	var aux teamMemberFields
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	*m = TeamMember(aux)
+	return nil
+}
+
+// MarshalJSON mirrors MarshalYAML: a plain string when only the handle is set.
+func (m TeamMember) MarshalJSON() ([]byte, error) {
+	if m.isHandleOnly() {
+		return json.Marshal(m.Handle)
+	}
+	return json.Marshal(teamMemberFields(m))
 }
 
 // MaintainerLifecycle represents maintainer lifecycle documentation
@@ -307,32 +482,51 @@ type MaintainerLifecycle struct {
 
 // MaintainerValidationResult captures validation results for maintainers
 type MaintainerValidationResult struct {
-	ProjectID             string   `json:"project_id" yaml:"project_id"`
-	Org                   string   `json:"org,omitempty" yaml:"org,omitempty"`
-	Valid                 bool     `json:"valid" yaml:"valid"`
-	Errors                []string `json:"errors,omitempty" yaml:"errors,omitempty"`
-	VerificationAttempted bool     `json:"verification_attempted" yaml:"verification_attempted"`
-	VerificationPassed    bool     `json:"verification_passed" yaml:"verification_passed"`
-	VerifiedHandles       []string `json:"verified_handles,omitempty" yaml:"verified_handles,omitempty"`
+	ProjectID             string         `json:"project_id" yaml:"project_id"`
+	File                  string         `json:"file,omitempty" yaml:"file,omitempty"` // Maintainers file the entry was read from
+	Org                   string         `json:"org,omitempty" yaml:"org,omitempty"`
+	Valid                 bool           `json:"valid" yaml:"valid"`
+	Errors                []string       `json:"errors,omitempty" yaml:"errors,omitempty"`
+	Diagnostics           []Diagnostic   `json:"diagnostics,omitempty" yaml:"diagnostics,omitempty"` // Errors plus warnings, with field paths and source positions
+	VerificationAttempted bool           `json:"verification_attempted" yaml:"verification_attempted"`
+	VerificationPassed    bool           `json:"verification_passed" yaml:"verification_passed"` // False while any handle is unverified
+	VerifiedHandles       []string       `json:"verified_handles,omitempty" yaml:"verified_handles,omitempty"`
+	UnverifiedHandles     []string       `json:"unverified_handles,omitempty" yaml:"unverified_handles,omitempty"` // Handles the verifier could not check (service unavailable)
+	Companies             []CompanyShare `json:"companies,omitempty" yaml:"companies,omitempty"`                   // project-maintainers per company, largest first
 }
 
 // Config represents the validator configuration
 type Config struct {
-	ProjectListURL string `yaml:"project_list_url"`
-	CacheDir       string `yaml:"cache_dir"`
-	OutputFormat   string `yaml:"output_format"` // json, yaml, text
+	ProjectListURL string `yaml:"project_list_url"` // File, URL, or enterprise://SLUG to discover .project repos
+	CacheDir       string `yaml:"cache_dir"`        // Cache location; see OpenCacheStore (directory, cas://dir or s3://bucket/prefix)
+	OutputFormat   string `yaml:"output_format"`    // json, yaml, text
+
+	// Fetch tuning for ValidateProjects; zero values use the Default* settings.
+	Concurrency           int     `yaml:"concurrency"`              // Parallel fetches
+	HostRequestsPerSecond float64 `yaml:"host_requests_per_second"` // Rate limit per host
+	MaxRetries            int     `yaml:"max_retries"`              // Retries on 429/5xx/network errors; negative disables
+
+	// AuditMaxAgeYears overrides DefaultAuditMaxAgeYears; negative disables
+	// the audit freshness check.
+	AuditMaxAgeYears int `yaml:"audit_max_age_years"`
+
+	// Org filters for an enterprise:// project list (glob patterns).
+	IncludeOrgs []string `yaml:"include_orgs"`
+	ExcludeOrgs []string `yaml:"exclude_orgs"`
 }
 
 // ValidationResult represents the result of validating a project
 type ValidationResult struct {
-	URL          string    `json:"url"`
-	ProjectName  string    `json:"project_name,omitempty"`
-	Valid        bool      `json:"valid"`
-	Errors       []string  `json:"errors,omitempty"`
-	Changed      bool      `json:"changed"`
-	LastChecked  time.Time `json:"last_checked"`
-	PreviousHash string    `json:"previous_hash,omitempty"`
-	CurrentHash  string    `json:"current_hash"`
+	URL          string        `json:"url"`
+	ProjectName  string        `json:"project_name,omitempty"`
+	Valid        bool          `json:"valid"`
+	Errors       []string      `json:"errors,omitempty"`
+	Diagnostics  []Diagnostic  `json:"diagnostics,omitempty"` // Errors plus warnings, with field paths and source positions
+	Changed      bool          `json:"changed"`
+	LastChecked  time.Time     `json:"last_checked"`
+	PreviousHash string        `json:"previous_hash,omitempty"`
+	CurrentHash  string        `json:"current_hash"`
+	FieldChanges []FieldChange `json:"field_changes,omitempty"` // Field-level differences from the cached content, when Changed
 }
 
 // CacheEntry represents cached project data
@@ -341,19 +535,32 @@ type CacheEntry struct {
 	Hash        string    `json:"hash"`
 	LastChecked time.Time `json:"last_checked"`
 	Content     string    `json:"content"`
+	ETag        string    `json:"etag,omitempty"` // Sent as If-None-Match on the next fetch
 }
 
 // Cache manages cached project data
 type Cache struct {
 	Entries map[string]CacheEntry `json:"entries"`
-	dir     string
+	store   CacheStore
+	mu      sync.Mutex // guards Entries during concurrent validation
 }
 
 // ProjectValidator validates remote project YAML files
 type ProjectValidator struct {
-	config *Config
-	cache  *Cache
-	client *http.Client
+	config   *Config
+	cache    *Cache
+	client   *http.Client
+	schema   *JSONSchema       // checked alongside the Go rules; nil disables the check
+	policy   *Policy           // maturity-aware field rules; nil disables them
+	source   ProjectListSource // overrides Config.ProjectListURL when set
+	packages *PackageResolver  // live package_managers lookups; nil disables them
+	audits   *AuditFreshness   // security audit age rule; nil disables it
+	verifier HandleVerifier    // maintainer handle verification; nil uses HandleVerifierFromEnv
+
+	envMu       sync.Mutex // guards the verifier built from the environment
+	envResolved bool
+	envKey      string
+	envVerifier HandleVerifier
 }
 
 // ProjectListEntry represents a single entry in the project list
@@ -0,0 +1,177 @@
+package projects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContext is the number of unchanged lines shown around each change.
+const diffContext = 3
+
+// UnifiedDiff renders the line differences between a and b in unified diff
+// format, or "" when they are equal.
+func UnifiedDiff(oldName, newName string, a, b []byte) string {
+	if string(a) == string(b) {
+		return ""
+	}
+	ops := diffLines(splitLines(string(a)), splitLines(string(b)))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", oldName, newName)
+	for start := 0; start < len(ops); {
+		// Find the next change and the end of its hunk.
+		first := start
+		for first < len(ops) && ops[first].kind == ' ' {
+			first++
+		}
+		if first == len(ops) {
+			break
+		}
+		from := max(first-diffContext, start)
+		end := first
+		for i := first; i < len(ops); i++ {
+			if ops[i].kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+		to := min(end+diffContext, len(ops))
+
+		hunk := ops[from:to]
+		oldStart, newStart := ops[from].oldLine, ops[from].newLine
+		var oldCount, newCount int
+		for _, op := range hunk {
+			if op.kind != '+' {
+				oldCount++
+			}
+			if op.kind != '-' {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%s +%s @@\n", hunkRange(oldStart, oldCount), hunkRange(newStart, newCount))
+		for _, op := range hunk {
+			out.WriteByte(op.kind)
+			out.WriteString(op.text)
+			if !strings.HasSuffix(op.text, "\n") {
+				out.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		start = to
+	}
+	return out.String()
+}
+
+// diffOp is one line of a diff: ' ' kept, '-' removed, '+' added. oldLine and
+// newLine are the 1-based positions the line would have in each file.
+type diffOp struct {
+	kind             byte
+	text             string
+	oldLine, newLine int
+}
+
+// diffLines computes a shortest line diff of a and b with Myers' algorithm.
+// Memory grows with the square of the number of changed lines, not with
+// the file size, so diffing a large CSV with a few changed rows is cheap.
+// Within a change, deletions come before insertions.
+func diffLines(a, b []string) []diffOp {
+	n, m := len(a), len(b)
+	// trace[d][k+d] is the furthest x reached on diagonal k = x-y after d
+	// edits.
+	var trace [][]int
+	prev := []int{0}
+	for d := 0; ; d++ {
+		v := make([]int, 2*d+1)
+		at := func(k int) int { return prev[k+d-1] } // x on diagonal k after d-1 edits
+		done := false
+		for k := -d; k <= d; k += 2 {
+			var x int
+			switch {
+			case d == 0:
+				x = 0
+			case k == -d || (k != d && at(k-1) < at(k+1)):
+				x = at(k + 1) // insertion
+			default:
+				x = at(k-1) + 1 // deletion
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[k+d] = x
+			if x >= n && y >= m {
+				done = true
+			}
+		}
+		trace = append(trace, v)
+		prev = v
+		if done {
+			break
+		}
+	}
+
+	// Walk back from the end, collecting ops in reverse.
+	var rev []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		p := trace[d-1]
+		at := func(k int) int { return p[k+d-1] }
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+		for x > prevX && y > prevY {
+			rev = append(rev, diffOp{' ', a[x-1], x, y})
+			x--
+			y--
+		}
+		if x == prevX {
+			rev = append(rev, diffOp{'+', b[y-1], x + 1, y})
+			y--
+		} else {
+			rev = append(rev, diffOp{'-', a[x-1], x, y + 1})
+			x--
+		}
+	}
+	for x > 0 && y > 0 {
+		rev = append(rev, diffOp{' ', a[x-1], x, y})
+		x--
+		y--
+	}
+
+	ops := make([]diffOp, len(rev))
+	for i, op := range rev {
+		ops[len(rev)-1-i] = op
+	}
+	return ops
+}
+
+// splitLines splits s after each newline, keeping the newlines.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// hunkRange formats a hunk header range. An empty range starts at the line
+// before it, as in GNU diff.
+func hunkRange(start, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", start-1)
+	case 1:
+		return fmt.Sprintf("%d", start)
+	}
+	return fmt.Sprintf("%d,%d", start, count)
+}
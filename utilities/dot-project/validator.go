@@ -11,9 +11,10 @@ import (
 	"net/mail"
 	"net/url"
 	"os"
-	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -39,10 +40,15 @@ func NewProjectValidator(configPath string) (*ProjectValidator, error) {
 		config: config,
 		cache:  cache,
 		client: &http.Client{Timeout: DefaultHTTPTimeout},
+		schema: defaultProjectSchema(),
+		policy: defaultMaturityPolicy(),
+		audits: auditFreshnessFromConfig(config),
 	}, nil
 }
 
-// ValidateProjects validates all projects in the project list
+// ValidateProjects validates all projects in the project list. Projects are
+// fetched by a bounded worker pool (Config.Concurrency) with per-host rate
+// limiting and retries; results keep the order of the project list.
 func (pv *ProjectValidator) ValidateProjects() ([]ValidationResult, error) {
 	// Load project list
 	projectURLs, err := pv.loadProjectList()
@@ -50,20 +56,49 @@ func (pv *ProjectValidator) ValidateProjects() ([]ValidationResult, error) {
 		return nil, fmt.Errorf("failed to load project list: %v", err)
 	}
 
-	var results []ValidationResult
-	for _, projectURL := range projectURLs {
-		result, err := pv.validateProject(projectURL)
-		if err != nil {
-			log.Printf("Error validating project %s: %v", projectURL, err)
-			result = ValidationResult{
-				URL:         projectURL,
-				Valid:       false,
-				Errors:      []string{err.Error()},
-				LastChecked: time.Now(),
+	// Compare every project against the cache as it was before this run, so
+	// that duplicate URLs and worker scheduling cannot affect Changed.
+	previous := pv.cache.snapshot()
+	f := newFetcher(pv.client, pv.config)
+
+	workers := DefaultFetchConcurrency
+	if pv.config != nil && pv.config.Concurrency > 0 {
+		workers = pv.config.Concurrency
+	}
+	if workers > len(projectURLs) {
+		workers = len(projectURLs)
+	}
+
+	results := make([]ValidationResult, len(projectURLs))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				projectURL := projectURLs[i]
+				cached, hasCached := previous[projectURL]
+				result, err := pv.validateProjectWith(f, projectURL, cached, hasCached)
+				if err != nil {
+					log.Printf("Error validating project %s: %v", projectURL, err)
+					result = ValidationResult{
+						URL:         projectURL,
+						Valid:       false,
+						Errors:      []string{err.Error()},
+						Diagnostics: []Diagnostic{errorDiag("", RuleFetch, "%s", err.Error())},
+						LastChecked: time.Now(),
+					}
+				}
+				results[i] = result
 			}
-		}
-		results = append(results, result)
+		}()
 	}
+	for i := range projectURLs {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
 
 	// Save cache
 	if err := pv.cache.save(); err != nil {
@@ -75,61 +110,109 @@ func (pv *ProjectValidator) ValidateProjects() ([]ValidationResult, error) {
 
 // validateProject validates a single project YAML file
 func (pv *ProjectValidator) validateProject(url string) (ValidationResult, error) {
+	cached, hasCached := pv.cache.get(url)
+	return pv.validateProjectWith(newFetcher(pv.client, pv.config), url, cached, hasCached)
+}
+
+// validateProjectWith validates one project against its previous cache
+// entry, fetching through f. A cached ETag makes the fetch conditional; on
+// 304 Not Modified the cached content is validated again.
+func (pv *ProjectValidator) validateProjectWith(f *fetcher, url string, cached CacheEntry, hasCached bool) (ValidationResult, error) {
 	result := ValidationResult{
 		URL:         url,
 		LastChecked: time.Now(),
 	}
 
 	// Fetch content
-	content, err := pv.fetchContent(url)
+	etag := ""
+	if hasCached && cached.Content != "" {
+		etag = cached.ETag
+	}
+	resp, err := f.fetch(url, etag)
 	if err != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("Failed to fetch content: %v", err))
+		d := errorDiag("", RuleFetch, "Failed to fetch content: %v", err)
+		result.Diagnostics = append(result.Diagnostics, d)
+		result.Errors = append(result.Errors, d.Message)
 		return result, nil
 	}
+	content := resp.Content
+	if resp.NotModified {
+		content = cached.Content
+	}
 
 	// Calculate hash
 	hash := calculateHash(content)
 	result.CurrentHash = hash
 
 	// Check if changed
-	if cached, exists := pv.cache.Entries[url]; exists {
+	if hasCached {
 		result.PreviousHash = cached.Hash
 		result.Changed = cached.Hash != hash
+		if result.Changed && cached.Content != "" {
+			if changes, err := DiffProjectYAML(cached.Content, content); err == nil {
+				result.FieldChanges = changes
+			}
+		}
 	} else {
 		result.Changed = true // New project
 	}
 
 	// Parse and validate YAML
-	var project Project
-	decoder := yaml.NewDecoder(strings.NewReader(content))
-	decoder.KnownFields(true)
-	if err := decoder.Decode(&project); err != nil {
-		result.Errors = append(result.Errors, fmt.Sprintf("YAML parsing error: %v", err))
-		result.Valid = false
-	} else {
+	project, diags, parsed := validateProjectContent(content)
+	if parsed {
 		result.ProjectName = project.Name
-		// Validate project structure
-		validationErrors := validateProjectStruct(project)
-		if len(validationErrors) > 0 {
-			result.Errors = append(result.Errors, validationErrors...)
-			result.Valid = false
-		} else {
-			result.Valid = true
+		if pv.schema != nil {
+			diags = append(diags, checkSchemaAgreement(pv.schema, content, diags)...)
+		}
+		if pv.policy != nil {
+			diags = append(diags, locatePolicyDiagnostics(pv.policy.Evaluate(project), content)...)
+		}
+		if pv.audits != nil {
+			diags = append(diags, locatePolicyDiagnostics(pv.audits.Evaluate(project), content)...)
+		}
+		if pv.packages != nil {
+			diags = append(diags, locatePolicyDiagnostics(pv.packages.Diagnostics(project), content)...)
 		}
 	}
+	result.Diagnostics = diags
+	result.Errors = append(result.Errors, diagnosticMessages(diags, SeverityError)...)
+	result.Valid = !hasErrorDiagnostics(diags)
 
 	// Update cache
-	pv.cache.Entries[url] = CacheEntry{
+	pv.cache.put(CacheEntry{
 		URL:         url,
 		Hash:        hash,
 		LastChecked: time.Now(),
 		Content:     content,
-	}
+		ETag:        resp.ETag,
+	})
 
 	return result, nil
 }
 
-// loadProjectList loads the list of project URLs
+// validateProjectContent decodes raw project.yaml content strictly and runs
+// the structural checks, returning diagnostics positioned against the source.
+// parsed is false when the YAML could not be decoded into a Project.
+func validateProjectContent(content string) (project Project, diags []Diagnostic, parsed bool) {
+	decoder := yaml.NewDecoder(strings.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&project); err != nil {
+		return project, yamlErrorDiagnostics(err), false
+	}
+
+	diags = validateProjectDiagnostics(project)
+
+	// Decode a second time into a node tree purely for source positions;
+	// Node.Decode cannot enforce KnownFields, so the strict pass above stays.
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &root); err == nil {
+		LocateDiagnostics(diags, &root)
+	}
+	return project, diags, true
+}
+
+// loadProjectList loads the list of project URLs from the configured
+// ProjectListSource, an enterprise:// location, or a project list file.
 func (pv *ProjectValidator) loadProjectList() ([]string, error) {
 	// For compatibility, check if projectListURL is set, otherwise use a default projectlist.yaml
 	var projectListURL string
@@ -139,6 +222,22 @@ func (pv *ProjectValidator) loadProjectList() ([]string, error) {
 		projectListURL = "testdata/projectlist.yaml" // Default to local file
 	}
 
+	source := pv.source
+	if source == nil && strings.HasPrefix(projectListURL, EnterpriseListPrefix) {
+		source = newEnterpriseSource(projectListURL, pv.config)
+	}
+	if source != nil {
+		entries, err := source.ProjectList()
+		if err != nil {
+			return nil, err
+		}
+		urls := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			urls = append(urls, entry.URL)
+		}
+		return urls, nil
+	}
+
 	var content string
 	var err error
 
@@ -201,6 +300,95 @@ func (pv *ProjectValidator) fetchContent(url string) (string, error) {
 	return string(content), nil
 }
 
+// validateLeads checks project_lead style entries: each must be a GitHub
+// handle or an org/team-name reference. field is the path of the list.
+func validateLeads(field string, leads StringOrSlice) []Diagnostic {
+	var diags []Diagnostic
+	for i, rawLead := range leads {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		lead := strings.TrimSpace(rawLead)
+		lead = strings.TrimPrefix(lead, "@")
+		if lead == "" {
+			diags = append(diags, errorDiag(path, RuleNonEmpty, "%s cannot be empty or just '@'", path))
+		} else if strings.Contains(lead, "/") {
+			parts := strings.Split(lead, "/")
+			if len(parts) != 2 {
+				diags = append(diags, errorDiag(path, RuleProjectLeadFormat, "%s team format must be org/team-name (got too many segments): %s", path, rawLead))
+			} else if parts[0] == "" {
+				diags = append(diags, errorDiag(path, RuleProjectLeadFormat, "%s team format requires a non-empty org (expected org/team-name): %s", path, rawLead))
+			} else if parts[1] == "" {
+				diags = append(diags, errorDiag(path, RuleProjectLeadFormat, "%s team format requires a non-empty team name (expected org/team-name): %s", path, rawLead))
+			}
+		}
+	}
+	return diags
+}
+
+// validateSlackChannels checks a slack_channels list. field is the path of
+// the list.
+func validateSlackChannels(field string, channels []SlackChannel) []Diagnostic {
+	var diags []Diagnostic
+	primarySlackCount := 0
+	for i, ch := range channels {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if ch.Name == "" {
+			diags = append(diags, errorDiag(path+".name", RuleRequired, "%s.name is required", path))
+		} else if !strings.HasPrefix(ch.Name, "#") {
+			diags = append(diags, errorDiag(path+".name", RuleSlackChannelName, "%s.name must start with '#', got: %s", path, ch.Name).
+				withFix("use %q", "#"+ch.Name))
+		}
+		if ch.Link != "" && !isValidURL(ch.Link) {
+			diags = append(diags, errorDiag(path+".link", RuleURLFormat, "%s.link is not a valid URL: %s", path, ch.Link))
+		}
+		diags = append(diags, slackChannelDiagnostics(path, ch)...)
+		if ch.Primary {
+			primarySlackCount++
+		}
+	}
+	if primarySlackCount > 1 {
+		diags = append(diags, errorDiag(field, RuleSinglePrimary, "at most one %s entry may be marked primary, found %d", field, primarySlackCount).
+			withFix("keep primary: true on a single channel"))
+	}
+	return diags
+}
+
+// validateRepositories checks a non-empty repositories list for valid,
+// distinct URLs and at most one primary entry. field is the path of the list.
+func validateRepositories(field string, repos []RepositoryEntry) []Diagnostic {
+	if len(repos) == 0 {
+		return []Diagnostic{errorDiag(field, RuleRequired, "%s is required and cannot be empty", field)}
+	}
+	var diags []Diagnostic
+	primaryRepoCount := 0
+	firstIndex := make(map[string]int)
+	for i, repo := range repos {
+		path := fmt.Sprintf("%s[%d]", field, i)
+		if repo.URL == "" {
+			diags = append(diags, errorDiag(path, RuleRequired, "%s has an empty URL", path))
+		} else if !isValidURL(repo.URL) {
+			diags = append(diags, errorDiag(path, RuleURLFormat, "%s is not a valid URL: %s", path, repo.URL))
+		} else if j, dup := firstIndex[normalizeRepoURL(repo.URL)]; dup {
+			diags = append(diags, errorDiag(path, RuleDuplicateRepository, "%s duplicates %s[%d]: %s", path, field, j, repo.URL).
+				withFix("remove the duplicate entry"))
+		} else {
+			firstIndex[normalizeRepoURL(repo.URL)] = i
+		}
+		for j, tag := range repo.Tags {
+			if tag == "" {
+				diags = append(diags, errorDiag(fmt.Sprintf("%s.tags[%d]", path, j), RuleNonEmpty, "%s.tags[%d] must not be empty", path, j))
+			}
+		}
+		if repo.Primary {
+			primaryRepoCount++
+		}
+	}
+	if primaryRepoCount > 1 {
+		diags = append(diags, errorDiag(field, RuleSinglePrimary, "at most one repository may be marked primary, found %d", primaryRepoCount).
+			withFix("keep primary: true on a single repository"))
+	}
+	return diags
+}
+
 // ValidMaturityPhases lists the allowed maturity phase values
 var ValidMaturityPhases = map[string]bool{
 	"sandbox":    true,
@@ -209,7 +397,8 @@ var ValidMaturityPhases = map[string]bool{
 	"archived":   true,
 }
 
-// SupportedSchemaVersions lists the schema versions this validator supports
+// SupportedSchemaVersions lists the schema versions this validator supports.
+// It mirrors the schema version registry; use RegisterSchemaVersion to extend it.
 var SupportedSchemaVersions = []string{"1.0.0"}
 
 // ValidateProjectStruct is the exported wrapper for project structure validation
@@ -217,96 +406,81 @@ func ValidateProjectStruct(project Project) []string {
 	return validateProjectStruct(project)
 }
 
-// validateProjectStruct validates the project structure
+// ValidateProjectDiagnostics is the exported wrapper returning structured
+// diagnostics instead of plain error strings.
+func ValidateProjectDiagnostics(project Project) []Diagnostic {
+	return validateProjectDiagnostics(project)
+}
+
+// validateProjectStruct validates the project structure and returns the
+// error-severity messages.
 func validateProjectStruct(project Project) []string {
-	var errors []string
+	return diagnosticMessages(validateProjectDiagnostics(project), SeverityError)
+}
+
+// validateProjectDiagnostics validates the project structure
+func validateProjectDiagnostics(project Project) []Diagnostic {
+	var diags []Diagnostic
 
 	// Required fields
 	if project.Name == "" {
-		errors = append(errors, "name is required")
+		diags = append(diags, errorDiag("name", RuleRequired, "name is required"))
 	}
 	if project.Description == "" {
-		errors = append(errors, "description is required")
+		diags = append(diags, errorDiag("description", RuleRequired, "description is required"))
 	}
 
 	// Validate slug
 	if project.Slug == "" {
-		errors = append(errors, "slug is required")
+		diags = append(diags, errorDiag("slug", RuleRequired, "slug is required"))
 	} else if !isValidSlug(project.Slug) {
-		errors = append(errors, fmt.Sprintf("slug must be lowercase alphanumeric with hyphens, got: %s", project.Slug))
+		d := errorDiag("slug", RuleSlugFormat, "slug must be lowercase alphanumeric with hyphens, got: %s", project.Slug)
+		if s := suggestSlug(project.Slug); s != "" {
+			d = d.withFix("use slug %q", s)
+		}
+		diags = append(diags, d)
 	}
 
 	// Validate project_lead (optional; each entry must be a valid GitHub handle
 	// or a GitHub team reference of the form org/team-name).
 	// Accepts a single string (scalar) or a list for projects with multiple leads.
-	for i, rawLead := range project.ProjectLeads {
-		lead := strings.TrimSpace(rawLead)
-		lead = strings.TrimPrefix(lead, "@")
-		if lead == "" {
-			errors = append(errors, fmt.Sprintf("project_lead[%d] cannot be empty or just '@'", i))
-		} else if strings.Contains(lead, "/") {
-			parts := strings.Split(lead, "/")
-			if len(parts) != 2 {
-				errors = append(errors, fmt.Sprintf("project_lead[%d] team format must be org/team-name (got too many segments): %s", i, rawLead))
-			} else if parts[0] == "" {
-				errors = append(errors, fmt.Sprintf("project_lead[%d] team format requires a non-empty org (expected org/team-name): %s", i, rawLead))
-			} else if parts[1] == "" {
-				errors = append(errors, fmt.Sprintf("project_lead[%d] team format requires a non-empty team name (expected org/team-name): %s", i, rawLead))
-			}
-		}
-	}
+	diags = append(diags, validateLeads("project_lead", project.ProjectLeads)...)
 
 	// Validate slack_channels (optional list of structured channels)
-	primarySlackCount := 0
-	for i, ch := range project.SlackChannels {
-		if ch.Name == "" {
-			errors = append(errors, fmt.Sprintf("slack_channels[%d].name is required", i))
-		} else if !strings.HasPrefix(ch.Name, "#") {
-			errors = append(errors, fmt.Sprintf("slack_channels[%d].name must start with '#', got: %s", i, ch.Name))
-		}
-		if ch.Link != "" && !isValidURL(ch.Link) {
-			errors = append(errors, fmt.Sprintf("slack_channels[%d].link is not a valid URL: %s", i, ch.Link))
-		}
-		if ch.Primary {
-			primarySlackCount++
-		}
-	}
-	if primarySlackCount > 1 {
-		errors = append(errors, fmt.Sprintf("at most one slack_channels entry may be marked primary, found %d", primarySlackCount))
-	}
+	diags = append(diags, validateSlackChannels("slack_channels", project.SlackChannels)...)
 
 	// Validate schema version
 	if project.SchemaVersion == "" {
-		errors = append(errors, "schema_version is required")
+		diags = append(diags, errorDiag("schema_version", RuleRequired, "schema_version is required").
+			withFix("add schema_version: %q", LatestSchemaVersion()))
 	} else {
-		supported := false
-		for _, v := range SupportedSchemaVersions {
-			if project.SchemaVersion == v {
-				supported = true
-				break
+		if lookupSchemaVersion(project.SchemaVersion) < 0 {
+			d := errorDiag("schema_version", RuleSchemaVersion, "unsupported schema_version: %s (supported: %v)", project.SchemaVersion, SupportedSchemaVersions).
+				withFix("set schema_version to one of %v", SupportedSchemaVersions)
+			if trimmed := strings.TrimLeft(project.SchemaVersion, "vV"); lookupSchemaVersion(trimmed) >= 0 {
+				d = d.withFix("use %q (without the leading 'v')", trimmed)
 			}
+			diags = append(diags, d)
 		}
-		if !supported {
-			errors = append(errors, fmt.Sprintf("unsupported schema_version: %s (supported: %v)", project.SchemaVersion, SupportedSchemaVersions))
-		}
+		diags = append(diags, schemaVersionDiagnostics(project)...)
 	}
 
 	// Validate maturity log
 	if len(project.MaturityLog) == 0 {
-		errors = append(errors, "maturity_log is required and cannot be empty")
+		diags = append(diags, errorDiag("maturity_log", RuleRequired, "maturity_log is required and cannot be empty"))
 	} else {
 		for i, entry := range project.MaturityLog {
 			if entry.Phase == "" {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].phase is required", i))
+				diags = append(diags, errorDiag(fmt.Sprintf("maturity_log[%d].phase", i), RuleRequired, "maturity_log[%d].phase is required", i))
 			}
 			if entry.Phase != "" && !ValidMaturityPhases[entry.Phase] {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].phase has invalid value %q (allowed: sandbox, incubating, graduated, archived)", i, entry.Phase))
+				diags = append(diags, errorDiag(fmt.Sprintf("maturity_log[%d].phase", i), RuleMaturityPhase, "maturity_log[%d].phase has invalid value %q (allowed: sandbox, incubating, graduated, archived)", i, entry.Phase))
 			}
 			if entry.Date.IsZero() {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].date is required", i))
+				diags = append(diags, errorDiag(fmt.Sprintf("maturity_log[%d].date", i), RuleRequired, "maturity_log[%d].date is required", i))
 			}
 			if entry.Issue == "" {
-				errors = append(errors, fmt.Sprintf("maturity_log[%d].issue is required", i))
+				diags = append(diags, errorDiag(fmt.Sprintf("maturity_log[%d].issue", i), RuleRequired, "maturity_log[%d].issue is required", i))
 			}
 		}
 
@@ -314,94 +488,68 @@ func validateProjectStruct(project Project) []string {
 		for i := 1; i < len(project.MaturityLog); i++ {
 			if !project.MaturityLog[i-1].Date.IsZero() && !project.MaturityLog[i].Date.IsZero() {
 				if project.MaturityLog[i].Date.Before(project.MaturityLog[i-1].Date) {
-					errors = append(errors, fmt.Sprintf("maturity_log[%d].date (%s) is before maturity_log[%d].date (%s); entries must be in chronological order",
+					diags = append(diags, errorDiag(fmt.Sprintf("maturity_log[%d].date", i), RuleMaturityOrder, "maturity_log[%d].date (%s) is before maturity_log[%d].date (%s); entries must be in chronological order",
 						i, project.MaturityLog[i].Date.Format("2006-01-02"),
-						i-1, project.MaturityLog[i-1].Date.Format("2006-01-02")))
+						i-1, project.MaturityLog[i-1].Date.Format("2006-01-02")).
+						withFix("sort maturity_log entries by date, oldest first"))
 				}
 			}
 		}
 	}
 
 	// Validate repositories
-	if len(project.Repositories) == 0 {
-		errors = append(errors, "repositories is required and cannot be empty")
-	} else {
-		primaryRepoCount := 0
-		for i, repo := range project.Repositories {
-			if repo.URL == "" {
-				errors = append(errors, fmt.Sprintf("repositories[%d] has an empty URL", i))
-			} else if !isValidURL(repo.URL) {
-				errors = append(errors, fmt.Sprintf("repositories[%d] is not a valid URL: %s", i, repo.URL))
-			}
-			for j, tag := range repo.Tags {
-				if tag == "" {
-					errors = append(errors, fmt.Sprintf("repositories[%d].tags[%d] must not be empty", i, j))
-				}
-			}
-			if repo.Primary {
-				primaryRepoCount++
-			}
-		}
-		if primaryRepoCount > 1 {
-			errors = append(errors, fmt.Sprintf("at most one repository may be marked primary, found %d", primaryRepoCount))
-		}
-	}
+	diags = append(diags, validateRepositories("repositories", project.Repositories)...)
 
 	// Validate URLs
 	if project.Website != "" && !isValidURL(project.Website) {
-		errors = append(errors, fmt.Sprintf("website is not a valid URL: %s", project.Website))
+		diags = append(diags, errorDiag("website", RuleURLFormat, "website is not a valid URL: %s", project.Website))
 	}
 	if project.Artwork != "" && !isValidURL(project.Artwork) {
-		errors = append(errors, fmt.Sprintf("artwork is not a valid URL: %s", project.Artwork))
+		diags = append(diags, errorDiag("artwork", RuleURLFormat, "artwork is not a valid URL: %s", project.Artwork))
 	}
 
 	// Validate social links
-	for platform, rawURL := range project.Social {
-		if !isValidURL(rawURL) {
-			errors = append(errors, fmt.Sprintf("social.%s is not a valid URL: %s", platform, rawURL))
-		}
-	}
+	diags = append(diags, socialDiagnostics(project.Social)...)
 
-	// Validate audits
+	// Validate audits. Dates up to a day ahead are allowed for time zones.
+	tomorrow := time.Now().Add(24 * time.Hour)
 	for i, audit := range project.Audits {
 		if audit.Date.IsZero() {
-			errors = append(errors, fmt.Sprintf("audits[%d].date is required", i))
+			diags = append(diags, errorDiag(fmt.Sprintf("audits[%d].date", i), RuleRequired, "audits[%d].date is required", i))
+		} else if audit.Date.After(tomorrow) {
+			diags = append(diags, errorDiag(fmt.Sprintf("audits[%d].date", i), RuleFutureDate, "audits[%d].date %s is in the future", i, audit.Date.Format("2006-01-02")).
+				withFix("use the date the audit report was published"))
 		}
 		if audit.Type == "" {
-			errors = append(errors, fmt.Sprintf("audits[%d].type is required", i))
+			diags = append(diags, errorDiag(fmt.Sprintf("audits[%d].type", i), RuleRequired, "audits[%d].type is required", i))
 		}
 		if audit.URL == "" {
-			errors = append(errors, fmt.Sprintf("audits[%d].url is required", i))
+			diags = append(diags, errorDiag(fmt.Sprintf("audits[%d].url", i), RuleRequired, "audits[%d].url is required", i))
 		} else if !isValidURL(audit.URL) {
-			errors = append(errors, fmt.Sprintf("audits[%d].url is not a valid URL: %s", i, audit.URL))
+			diags = append(diags, errorDiag(fmt.Sprintf("audits[%d].url", i), RuleURLFormat, "audits[%d].url is not a valid URL: %s", i, audit.URL))
 		}
 	}
 
+	// Validate subprojects with the top-level rules
+	diags = append(diags, validateSubprojects(project.Subprojects)...)
+
 	// Validate PathRef fields: if a *PathRef is present, its path must not be empty.
-	// Top-level PathRef
-	errors = append(errors, validatePathRefs([]pathRefCheck{
-		{project.Adopters, "adopters"},
-	})...)
+	diags = append(diags, validatePathRefs(projectPathRefs(project))...)
 
 	// Security section
 	if project.Security != nil {
-		errors = append(errors, validatePathRefs([]pathRefCheck{
-			{project.Security.Policy, "security.policy"},
-			{project.Security.ThreatModel, "security.threat_model"},
-		})...)
-
 		if project.Security.Contact != nil {
 			if project.Security.Contact.Email == "" && project.Security.Contact.AdvisoryURL == "" {
-				errors = append(errors, "security.contact must have at least one of email or advisory_url")
+				diags = append(diags, errorDiag("security.contact", RuleSecurityContact, "security.contact must have at least one of email or advisory_url"))
 			}
 			if project.Security.Contact.Email != "" {
 				if _, err := mail.ParseAddress(project.Security.Contact.Email); err != nil {
-					errors = append(errors, fmt.Sprintf("security.contact.email is not a valid email: %s", project.Security.Contact.Email))
+					diags = append(diags, errorDiag("security.contact.email", RuleEmailFormat, "security.contact.email is not a valid email: %s", project.Security.Contact.Email))
 				}
 			}
 			if project.Security.Contact.AdvisoryURL != "" {
 				if !githubAdvisoryURLPattern.MatchString(project.Security.Contact.AdvisoryURL) {
-					errors = append(errors, fmt.Sprintf("security.contact.advisory_url must be a valid GitHub Security Advisory URL (https://github.com/{org}/{repo}/security/advisories/new), got: %s", project.Security.Contact.AdvisoryURL))
+					diags = append(diags, errorDiag("security.contact.advisory_url", RuleAdvisoryURL, "security.contact.advisory_url must be a valid GitHub Security Advisory URL (https://github.com/{org}/{repo}/security/advisories/new), got: %s", project.Security.Contact.AdvisoryURL))
 				}
 			}
 		}
@@ -410,87 +558,52 @@ func validateProjectStruct(project Project) []string {
 	// Governance section
 	if project.Governance != nil {
 		ml := project.Governance.MaintainerLifecycle
-		errors = append(errors, validatePathRefs([]pathRefCheck{
-			{project.Governance.Contributing, "governance.contributing"},
-			{project.Governance.Codeowners, "governance.codeowners"},
-			{project.Governance.GovernanceDoc, "governance.governance_doc"},
-			{project.Governance.GitVoteConfig, "governance.gitvote_config"},
-			{project.Governance.VendorNeutralityStatement, "governance.vendor_neutrality_statement"},
-			{project.Governance.DecisionMakingProcess, "governance.decision_making_process"},
-			{project.Governance.RolesAndTeams, "governance.roles_and_teams"},
-			{project.Governance.CodeOfConduct, "governance.code_of_conduct"},
-			{project.Governance.SubProjectList, "governance.sub_project_list"},
-			{project.Governance.SubProjectDocs, "governance.sub_project_docs"},
-			{project.Governance.ContributorLadder, "governance.contributor_ladder"},
-			{project.Governance.ChangeProcess, "governance.change_process"},
-			{project.Governance.CommsChannels, "governance.comms_channels"},
-			{project.Governance.CommunityCalendar, "governance.community_calendar"},
-			{project.Governance.ContributorGuide, "governance.contributor_guide"},
-			{ml.OnboardingDoc, "governance.maintainer_lifecycle.onboarding_doc"},
-			{ml.ProgressionLadder, "governance.maintainer_lifecycle.progression_ladder"},
-			{ml.OffboardingPolicy, "governance.maintainer_lifecycle.offboarding_policy"},
-		})...)
-
 		for i, u := range ml.MentoringProgram {
 			if !isValidURL(u) {
-				errors = append(errors, fmt.Sprintf("governance.maintainer_lifecycle.mentoring_program[%d] is not a valid URL: %s", i, u))
+				diags = append(diags, errorDiag(fmt.Sprintf("governance.maintainer_lifecycle.mentoring_program[%d]", i), RuleURLFormat, "governance.maintainer_lifecycle.mentoring_program[%d] is not a valid URL: %s", i, u))
 			}
 		}
 	}
 
 	// Legal section
 	if project.Legal != nil {
-		errors = append(errors, validatePathRefs([]pathRefCheck{
-			{project.Legal.License, "legal.license"},
-		})...)
-
 		if project.Legal.IdentityType != nil {
 			if project.Legal.IdentityType.HasCLA && !project.Legal.IdentityType.HasDCO && !project.Legal.IdentityType.CLAOnly {
-				errors = append(errors, "legal.identity_type: has_cla requires has_dco (CLA cannot be used without DCO; set cla_only: true if this project has an exception)")
+				diags = append(diags, errorDiag("legal.identity_type", RuleIdentityType, "legal.identity_type: has_cla requires has_dco (CLA cannot be used without DCO; set cla_only: true if this project has an exception)").
+					withFix("set has_dco: true, or cla_only: true if this project has an approved exception"))
 			}
 			if project.Legal.IdentityType.CLAOnly && !project.Legal.IdentityType.HasCLA {
-				errors = append(errors, "legal.identity_type: cla_only requires has_cla to be true")
+				diags = append(diags, errorDiag("legal.identity_type", RuleIdentityType, "legal.identity_type: cla_only requires has_cla to be true").
+					withFix("set has_cla: true or remove cla_only"))
 			}
-			errors = append(errors, validatePathRefs([]pathRefCheck{
-				{project.Legal.IdentityType.DCOURL, "legal.identity_type.dco_url"},
-				{project.Legal.IdentityType.CLAURL, "legal.identity_type.cla_url"},
-			})...)
 		}
 	}
 
 	// Validate package_managers: each key must have at least one non-empty value.
-	for key, vals := range project.PackageManagers {
+	for _, key := range sortedKeys(project.PackageManagers) {
+		vals := project.PackageManagers[key]
 		if len(vals) == 0 {
-			errors = append(errors, fmt.Sprintf("package_managers.%s must have at least one value", key))
+			diags = append(diags, errorDiag("package_managers."+key, RuleNonEmpty, "package_managers.%s must have at least one value", key))
 		}
 		for j, v := range vals {
 			if strings.TrimSpace(v) == "" {
-				errors = append(errors, fmt.Sprintf("package_managers.%s[%d] value must not be empty", key, j))
+				diags = append(diags, errorDiag(fmt.Sprintf("package_managers.%s[%d]", key, j), RuleNonEmpty, "package_managers.%s[%d] value must not be empty", key, j))
 			}
 		}
 	}
+	diags = append(diags, packageManagerDiagnostics(project.PackageManagers)...)
 
 	// Landscape section
 	if project.Landscape != nil {
 		if project.Landscape.Category == "" {
-			errors = append(errors, "landscape.category is required when landscape section is present")
+			diags = append(diags, errorDiag("landscape.category", RuleRequired, "landscape.category is required when landscape section is present"))
 		}
 		if project.Landscape.Subcategory == "" {
-			errors = append(errors, "landscape.subcategory is required when landscape section is present")
+			diags = append(diags, errorDiag("landscape.subcategory", RuleRequired, "landscape.subcategory is required when landscape section is present"))
 		}
 	}
 
-	// Documentation section
-	if project.Documentation != nil {
-		errors = append(errors, validatePathRefs([]pathRefCheck{
-			{project.Documentation.Readme, "documentation.readme"},
-			{project.Documentation.Support, "documentation.support"},
-			{project.Documentation.Architecture, "documentation.architecture"},
-			{project.Documentation.API, "documentation.api"},
-		})...)
-	}
-
-	return errors
+	return diags
 }
 
 // pathRefCheck pairs a *PathRef with its label for table-driven validation.
@@ -499,15 +612,98 @@ type pathRefCheck struct {
 	label string
 }
 
+// projectPathRefs lists every PathRef field of a project, present or not,
+// labelled with its project.yaml path.
+func projectPathRefs(project Project) []pathRefCheck {
+	checks := []pathRefCheck{
+		{project.Adopters, "adopters"},
+	}
+	if project.Security != nil {
+		checks = append(checks,
+			pathRefCheck{project.Security.Policy, "security.policy"},
+			pathRefCheck{project.Security.ThreatModel, "security.threat_model"},
+		)
+	}
+	if g := project.Governance; g != nil {
+		ml := g.MaintainerLifecycle
+		checks = append(checks, []pathRefCheck{
+			{g.Contributing, "governance.contributing"},
+			{g.Codeowners, "governance.codeowners"},
+			{g.GovernanceDoc, "governance.governance_doc"},
+			{g.GitVoteConfig, "governance.gitvote_config"},
+			{g.VendorNeutralityStatement, "governance.vendor_neutrality_statement"},
+			{g.DecisionMakingProcess, "governance.decision_making_process"},
+			{g.RolesAndTeams, "governance.roles_and_teams"},
+			{g.CodeOfConduct, "governance.code_of_conduct"},
+			{g.SubProjectList, "governance.sub_project_list"},
+			{g.SubProjectDocs, "governance.sub_project_docs"},
+			{g.ContributorLadder, "governance.contributor_ladder"},
+			{g.ChangeProcess, "governance.change_process"},
+			{g.CommsChannels, "governance.comms_channels"},
+			{g.CommunityCalendar, "governance.community_calendar"},
+			{g.ContributorGuide, "governance.contributor_guide"},
+			{ml.OnboardingDoc, "governance.maintainer_lifecycle.onboarding_doc"},
+			{ml.ProgressionLadder, "governance.maintainer_lifecycle.progression_ladder"},
+			{ml.OffboardingPolicy, "governance.maintainer_lifecycle.offboarding_policy"},
+		}...)
+	}
+	if project.Legal != nil {
+		checks = append(checks, pathRefCheck{project.Legal.License, "legal.license"})
+		if id := project.Legal.IdentityType; id != nil {
+			checks = append(checks,
+				pathRefCheck{id.DCOURL, "legal.identity_type.dco_url"},
+				pathRefCheck{id.CLAURL, "legal.identity_type.cla_url"},
+			)
+		}
+	}
+	if d := project.Documentation; d != nil {
+		checks = append(checks, []pathRefCheck{
+			{d.Readme, "documentation.readme"},
+			{d.Support, "documentation.support"},
+			{d.Architecture, "documentation.architecture"},
+			{d.API, "documentation.api"},
+		}...)
+	}
+	return checks
+}
+
 // validatePathRefs checks that each non-nil PathRef has a non-empty path.
-func validatePathRefs(checks []pathRefCheck) []string {
-	var errs []string
+func validatePathRefs(checks []pathRefCheck) []Diagnostic {
+	var diags []Diagnostic
 	for _, c := range checks {
 		if c.ref != nil && c.ref.Path == "" {
-			errs = append(errs, fmt.Sprintf("%s.path is required", c.label))
+			diags = append(diags, errorDiag(c.label+".path", RuleRequired, "%s.path is required", c.label))
 		}
 	}
-	return errs
+	return diags
+}
+
+// sortedKeys returns the keys of m in sorted order so map-driven validation
+// produces deterministic output.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// suggestSlug derives a valid slug from s (lowercased, runs of other
+// characters collapsed to a single hyphen), or "" if nothing usable remains.
+func suggestSlug(s string) string {
+	var b strings.Builder
+	lastHyphen := true
+	for _, c := range strings.ToLower(s) {
+		if (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') {
+			b.WriteRune(c)
+			lastHyphen = false
+		} else if !lastHyphen {
+			b.WriteByte('-')
+			lastHyphen = true
+		}
+	}
+	return strings.TrimRight(b.String(), "-")
 }
 
 // isValidSlug checks if a string is a valid project slug (lowercase alphanumeric + hyphens)
@@ -580,49 +776,63 @@ func loadConfig(path string) (*Config, error) {
 	return &config, nil
 }
 
-// loadCache loads cache from disk
-func loadCache(dir string) (*Cache, error) {
-	cache := &Cache{
-		Entries: make(map[string]CacheEntry),
-		dir:     dir,
+// loadCache opens the cache store at location (see OpenCacheStore) and
+// loads its entries.
+func loadCache(location string) (*Cache, error) {
+	store, err := OpenCacheStore(location)
+	if err != nil {
+		return nil, err
 	}
+	return loadCacheFrom(store)
+}
 
-	// Ensure cache directory exists
-	if err := os.MkdirAll(dir, 0755); err != nil {
-		return nil, fmt.Errorf("failed to create cache directory %q: %w", dir, err)
+// loadCacheFrom loads a cache from store.
+func loadCacheFrom(store CacheStore) (*Cache, error) {
+	entries, err := store.Load()
+	if err != nil {
+		return nil, err
 	}
+	return &Cache{Entries: entries, store: store}, nil
+}
 
-	cachePath := filepath.Join(dir, "cache.json")
-	if _, err := os.Stat(cachePath); os.IsNotExist(err) {
-		return cache, nil // New cache
+// save merges the cache into its store.
+func (c *Cache) save() error {
+	if c.store == nil {
+		return nil
 	}
-
-	data, err := os.ReadFile(cachePath)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read cache file %q: %w", cachePath, err)
+	c.mu.Lock()
+	entries := make(map[string]CacheEntry, len(c.Entries))
+	for k, v := range c.Entries {
+		entries[k] = v
 	}
+	c.mu.Unlock()
+	return c.store.Save(entries)
+}
 
-	if err := json.Unmarshal(data, &cache.Entries); err != nil {
-		log.Printf("Warning: cache file %q is corrupted, removing and starting fresh: %v", cachePath, err)
-		if removeErr := os.Remove(cachePath); removeErr != nil {
-			log.Printf("Warning: failed to remove corrupted cache file %q: %v", cachePath, removeErr)
-		}
-		cache.Entries = make(map[string]CacheEntry)
-		return cache, nil
-	}
+// get returns the cache entry for url.
+func (c *Cache) get(url string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.Entries[url]
+	return entry, ok
+}
 
-	return cache, nil
+// put stores an entry under its URL.
+func (c *Cache) put(entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Entries[entry.URL] = entry
 }
 
-// save saves cache to disk
-func (c *Cache) save() error {
-	data, err := json.MarshalIndent(c.Entries, "", "  ")
-	if err != nil {
-		return err
+// snapshot returns a copy of the cache entries.
+func (c *Cache) snapshot() map[string]CacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entries := make(map[string]CacheEntry, len(c.Entries))
+	for k, v := range c.Entries {
+		entries[k] = v
 	}
-
-	cachePath := filepath.Join(c.dir, "cache.json")
-	return os.WriteFile(cachePath, data, 0644)
+	return entries
 }
 
 // GenerateDiff generates a diff report for changed projects
@@ -635,7 +845,9 @@ func (pv *ProjectValidator) GenerateDiff(results []ValidationResult) string {
 	errorCount := 0
 
 	for _, result := range results {
-		if result.Changed || !result.Valid {
+		diags := reportDiagnostics(result.Errors, result.Diagnostics)
+		hasNotes := len(diags) > 0 && result.Valid
+		if result.Changed || !result.Valid || hasNotes {
 			if result.Changed {
 				changedCount++
 				diff.WriteString(fmt.Sprintf("CHANGED: %s (%s)\n", result.ProjectName, result.URL))
@@ -645,9 +857,18 @@ func (pv *ProjectValidator) GenerateDiff(results []ValidationResult) string {
 			if !result.Valid {
 				errorCount++
 				diff.WriteString(fmt.Sprintf("INVALID: %s (%s)\n", result.ProjectName, result.URL))
-				for _, err := range result.Errors {
-					diff.WriteString(fmt.Sprintf("  - %s\n", err))
+			} else if hasNotes {
+				label := "NOTES"
+				for _, d := range diags {
+					if d.Severity == SeverityWarning {
+						label = "WARNINGS"
+						break
+					}
 				}
+				diff.WriteString(fmt.Sprintf("%s: %s (%s)\n", label, result.ProjectName, result.URL))
+			}
+			for _, d := range diags {
+				diff.WriteString(formatDiagnosticLine(d))
 			}
 			diff.WriteString("\n")
 		}
@@ -670,9 +891,9 @@ func NewValidator(cacheDir string) *ProjectValidator {
 	cache, err := loadCache(cacheDir)
 	if err != nil {
 		log.Printf("Warning: failed to load cache from %s, starting with empty cache: %v", cacheDir, err)
-		cache = &Cache{
-			Entries: make(map[string]CacheEntry),
-			dir:     cacheDir,
+		cache = &Cache{Entries: make(map[string]CacheEntry)}
+		if store, storeErr := OpenCacheStore(cacheDir); storeErr == nil {
+			cache.store = store
 		}
 	}
 
@@ -680,6 +901,9 @@ func NewValidator(cacheDir string) *ProjectValidator {
 		config: config,
 		cache:  cache,
 		client: &http.Client{Timeout: DefaultHTTPTimeout},
+		schema: defaultProjectSchema(),
+		policy: defaultMaturityPolicy(),
+		audits: auditFreshnessFromConfig(config),
 	}
 }
 
@@ -706,6 +930,10 @@ func (pv *ProjectValidator) FormatResults(results []ValidationResult, format str
 			return "", err
 		}
 		return string(data), nil
+	case "sarif":
+		return FormatSARIF(results, nil)
+	case "github":
+		return formatGitHubAnnotations(projectAnnotatedFiles(results), "projects"), nil
 	case "text":
 		return pv.GenerateDiff(results), nil
 	default:
@@ -961,6 +961,7 @@ func TestValidateProjectsErrorCaptured(t *testing.T) {
 	writeFile(t, listPath, "projects:\n  - url: \""+srv.URL+"/project.yaml\"\n")
 
 	pv := NewValidator(cacheDir)
+	pv.config.MaxRetries = -1 // fail fast; retries are covered in fetch_test.go
 	results, err := pv.ValidateAll(listPath)
 	if err != nil {
 		t.Fatalf("ValidateAll: %v", err)
@@ -0,0 +1,540 @@
+package projects
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Helpers for editing project.yaml through yaml.Node so that comments, key
+// order and quoting survive a rewrite. Used by schema upgrades, lint fixes
+// and the maintainers reconciliation.
+
+// parseYAMLDocument parses content into a document node.
+func parseYAMLDocument(content []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 {
+		return nil, fmt.Errorf("document is empty")
+	}
+	if doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("top-level YAML value must be a mapping")
+	}
+	return &doc, nil
+}
+
+// encodeYAMLLines serializes a node with the two-space indent used
+// throughout the .project templates, as lines indented by indent spaces.
+func encodeYAMLLines(n *yaml.Node, indent int) ([]string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(n); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i, l := range lines {
+		lines[i] = strings.Repeat(" ", indent) + l
+	}
+	return lines, nil
+}
+
+// documentRoot returns the top-level mapping of a document node.
+func documentRoot(doc *yaml.Node) *yaml.Node {
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		return doc.Content[0]
+	}
+	return doc
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	if m == nil || m.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingScalar sets key to a string scalar in a mapping node, updating
+// the existing value in place (keeping its comments) or appending a new pair.
+func setMappingScalar(m *yaml.Node, key, value string) {
+	if v := mappingValue(m, key); v != nil {
+		v.Kind = yaml.ScalarNode
+		v.Tag = "!!str"
+		v.Value = value
+		v.Content = nil
+		return
+	}
+	m.Content = append(m.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value},
+	)
+}
+
+// yamlEditor edits a YAML document in its source text, using the positions
+// of parsed nodes, so that everything it does not touch keeps its exact
+// bytes: blank lines, comment alignment and quoting. Each operation reports
+// whether it could be applied; content it cannot edit safely in place, such
+// as multi-line scalars or flow sequences, is left alone.
+type yamlEditor struct {
+	lines   [][]rune // source lines without line endings; yaml.v3 columns count runes
+	crlf    bool
+	final   bool // the source ends with a line ending
+	deleted map[int]bool
+	moved   map[int][]int    // first line of a block -> the lines that replace it
+	inserts map[int][]string // lines added after a line
+}
+
+func newYAMLEditor(content []byte) *yamlEditor {
+	text := string(content)
+	e := &yamlEditor{
+		crlf:    strings.Contains(text, "\r\n"),
+		final:   strings.HasSuffix(text, "\n"),
+		deleted: make(map[int]bool),
+		moved:   make(map[int][]int),
+		inserts: make(map[int][]string),
+	}
+	text = strings.TrimSuffix(text, "\n")
+	for _, l := range strings.Split(text, "\n") {
+		e.lines = append(e.lines, []rune(strings.TrimSuffix(l, "\r")))
+	}
+	return e
+}
+
+// line returns the 1-based source line n, or nil if out of range.
+func (e *yamlEditor) line(n int) []rune {
+	if n < 1 || n > len(e.lines) {
+		return nil
+	}
+	return e.lines[n-1]
+}
+
+// setScalar replaces the source text of scalar node n with value, rendered
+// in the node's quoting style.
+func (e *yamlEditor) setScalar(n *yaml.Node, value string) bool {
+	if !e.replaceScalar(n, renderScalar(value, n.Style)) {
+		return false
+	}
+	n.Value = value
+	return true
+}
+
+// replaceScalar replaces the source text of scalar node n with raw text. A
+// trailing comment aligned with padding keeps its column where the padding
+// allows; one after a single space stays so.
+func (e *yamlEditor) replaceScalar(n *yaml.Node, raw string) bool {
+	line := e.line(n.Line)
+	start := n.Column - 1
+	if n.Kind != yaml.ScalarNode || line == nil || start < 0 || start >= len(line) {
+		return false
+	}
+	end, ok := scalarEnd(line, start, n.Style)
+	if !ok {
+		return false
+	}
+	var decoded string
+	if err := yaml.Unmarshal([]byte(string(line[start:end])), &decoded); err != nil || decoded != n.Value {
+		return false // not the single-line token we expected
+	}
+	text := []rune(raw)
+
+	rest := line[end:]
+	pad := 0
+	for pad < len(rest) && rest[pad] == ' ' {
+		pad++
+	}
+	if pad > 1 && pad < len(rest) && rest[pad] == '#' {
+		newPad := max(1, pad-(len(text)-(end-start)))
+		rest = append([]rune(strings.Repeat(" ", newPad)), rest[pad:]...)
+	}
+	updated := append(append(append([]rune{}, line[:start]...), text...), rest...)
+	e.lines[n.Line-1] = updated
+	return true
+}
+
+// scalarEnd returns the column just past the scalar token starting at
+// start, or false when the token does not end on this line.
+func scalarEnd(line []rune, start int, style yaml.Style) (int, bool) {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\\' {
+				i++
+			} else if line[i] == '"' {
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case style&yaml.SingleQuotedStyle != 0:
+		for i := start + 1; i < len(line); i++ {
+			if line[i] == '\'' {
+				if i+1 < len(line) && line[i+1] == '\'' {
+					i++
+					continue
+				}
+				return i + 1, true
+			}
+		}
+		return 0, false
+	case style&(yaml.LiteralStyle|yaml.FoldedStyle|yaml.FlowStyle) != 0:
+		return 0, false
+	}
+	// A plain scalar stops at a comment, or at the ": " ending a mapping key.
+	end := len(line)
+	for i := start; i < len(line); i++ {
+		if line[i] == '#' && i > start && line[i-1] == ' ' {
+			end = i
+			break
+		}
+		if line[i] == ':' && (i+1 == len(line) || line[i+1] == ' ') {
+			end = i
+			break
+		}
+	}
+	for end > start && line[end-1] == ' ' {
+		end--
+	}
+	return end, true
+}
+
+// renderScalar writes value in the given quoting style. Plain values that
+// YAML would misread are double-quoted instead.
+func renderScalar(value string, style yaml.Style) string {
+	switch {
+	case style&yaml.DoubleQuotedStyle != 0:
+		return strconv.Quote(value)
+	case style&yaml.SingleQuotedStyle != 0:
+		return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+	}
+	out, err := yaml.Marshal(value)
+	if err != nil || strings.TrimSuffix(string(out), "\n") != value {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// lastLine returns the last source line of node n, judged by its
+// descendants.
+func lastLine(n *yaml.Node) int {
+	last := n.Line
+	for _, c := range n.Content {
+		last = max(last, lastLine(c))
+	}
+	return last
+}
+
+// itemSpan returns the source lines of block sequence item n: from the
+// dash, extended upwards over the comment lines directly above it, to its
+// last line. It fails for items that do not start their own line.
+func (e *yamlEditor) itemSpan(n *yaml.Node) (int, int, bool) {
+	line := e.line(n.Line)
+	if line == nil || n.Column-1 > len(line) || n.Style&yaml.FlowStyle != 0 {
+		return 0, 0, false
+	}
+	if strings.TrimSpace(string(line[:n.Column-1])) != "-" {
+		return 0, 0, false
+	}
+	start := n.Line
+	for start > 1 && strings.HasPrefix(strings.TrimSpace(string(e.line(start-1))), "#") {
+		start--
+	}
+	return start, lastLine(n), true
+}
+
+// deleteItem removes block sequence item n and the comments directly above
+// it.
+func (e *yamlEditor) deleteItem(n *yaml.Node) bool {
+	start, end, ok := e.itemSpan(n)
+	if !ok {
+		return false
+	}
+	for l := start; l <= end; l++ {
+		e.deleted[l] = true
+	}
+	return true
+}
+
+// reorderItems rewrites the block sequence items so that the item at
+// position i moves to position i of sorted. Each item takes its comment
+// lines along; blank lines between items stay where they are.
+func (e *yamlEditor) reorderItems(items, sorted []*yaml.Node) bool {
+	type span struct{ start, end int }
+	spans := make(map[*yaml.Node]span, len(items))
+	prevEnd := 0
+	for _, n := range items {
+		start, end, ok := e.itemSpan(n)
+		if !ok || start <= prevEnd {
+			return false
+		}
+		spans[n] = span{start, end}
+		prevEnd = end
+	}
+	for i, n := range items {
+		s, to := spans[n], spans[sorted[i]]
+		for l := s.start; l <= s.end; l++ {
+			e.deleted[l] = true
+		}
+		e.moved[s.start] = nil
+		for l := to.start; l <= to.end; l++ {
+			e.moved[s.start] = append(e.moved[s.start], l)
+		}
+	}
+	return true
+}
+
+// insertAfter adds lines after source line n.
+func (e *yamlEditor) insertAfter(n int, lines ...string) {
+	e.inserts[n] = append(e.inserts[n], lines...)
+}
+
+// bytes returns the edited document.
+func (e *yamlEditor) bytes() []byte {
+	var out []string
+	for i, l := range e.lines {
+		n := i + 1
+		if block, ok := e.moved[n]; ok {
+			for _, m := range block {
+				out = append(out, string(e.line(m)))
+			}
+		} else if !e.deleted[n] {
+			out = append(out, string(l))
+		}
+		out = append(out, e.inserts[n]...)
+	}
+	eol := "\n"
+	if e.crlf {
+		eol = "\r\n"
+	}
+	text := strings.Join(out, eol)
+	if e.final {
+		text += eol
+	}
+	return []byte(text)
+}
+
+// blockEnd returns the last line of the block starting at line start that
+// continues over the lines indented deeper than indent, such as the body of
+// a block scalar. Blank lines inside the block belong to it.
+func (e *yamlEditor) blockEnd(start, indent int) int {
+	end := start
+	for l := start + 1; l <= len(e.lines); l++ {
+		text := string(e.line(l))
+		trimmed := strings.TrimLeft(text, " ")
+		if trimmed == "" {
+			continue
+		}
+		if len(text)-len(trimmed) <= indent {
+			break
+		}
+		end = l
+	}
+	return end
+}
+
+// pairSpan returns the source lines of the mapping pair k: v, from the
+// comment lines directly above the key to the value's last line. It fails
+// for keys that do not start their own line.
+func (e *yamlEditor) pairSpan(k, v *yaml.Node) (int, int, bool) {
+	line := e.line(k.Line)
+	if line == nil || k.Column-1 > len(line) || strings.TrimSpace(string(line[:k.Column-1])) != "" {
+		return 0, 0, false
+	}
+	start := k.Line
+	for start > 1 && strings.HasPrefix(strings.TrimSpace(string(e.line(start-1))), "#") {
+		start--
+	}
+	return start, max(lastLine(v), e.blockEnd(k.Line, k.Column-1)), true
+}
+
+// nodeState is what a nodeSnapshot remembers about one node.
+type nodeState struct {
+	kind    yaml.Kind
+	value   string
+	content []*yaml.Node
+}
+
+// nodeSnapshot records a parsed node tree so that edits made to the tree
+// afterwards can be written back to the source text with applyTreeEdits.
+type nodeSnapshot map[*yaml.Node]nodeState
+
+func snapshotNodes(n *yaml.Node) nodeSnapshot {
+	s := make(nodeSnapshot)
+	var record func(n *yaml.Node)
+	record = func(n *yaml.Node) {
+		s[n] = nodeState{kind: n.Kind, value: n.Value, content: append([]*yaml.Node(nil), n.Content...)}
+		for _, c := range n.Content {
+			record(c)
+		}
+	}
+	record(n)
+	return s
+}
+
+// applyTreeEdits writes the changes made to the tree under n since s was
+// taken into the source text: changed scalars, and pairs or items added to
+// or removed from block collections. Anything else, such as a replaced
+// collection or an edit inside a flow collection, cannot be written in
+// place and is an error naming the path.
+func (e *yamlEditor) applyTreeEdits(n *yaml.Node, s nodeSnapshot, path string) error {
+	was, ok := s[n]
+	if !ok || was.kind != n.Kind {
+		return fmt.Errorf("%s: replaced values cannot be written in place", displayPath(path))
+	}
+	switch n.Kind {
+	case yaml.ScalarNode:
+		if n.Value == was.value {
+			return nil
+		}
+		value := n.Value
+		n.Value = was.value // replaceScalar matches the source against the parsed value
+		if !e.setScalar(n, value) {
+			n.Value = value
+			return fmt.Errorf("%s: %q cannot be rewritten in place", displayPath(path), was.value)
+		}
+		return nil
+	case yaml.MappingNode:
+		return e.applyCollectionEdits(n, was.content, 2, s, path)
+	case yaml.SequenceNode:
+		return e.applyCollectionEdits(n, was.content, 1, s, path)
+	}
+	return nil
+}
+
+// applyCollectionEdits applies applyTreeEdits to a mapping (stride 2) or
+// sequence (stride 1) whose children were orig when the snapshot was taken.
+func (e *yamlEditor) applyCollectionEdits(n *yaml.Node, orig []*yaml.Node, stride int, s nodeSnapshot, path string) error {
+	childPath := func(i int) string {
+		if stride == 1 {
+			return fmt.Sprintf("%s[%d]", path, i)
+		}
+		return joinDiffPath(path, n.Content[i].Value)
+	}
+	changed := len(orig) != len(n.Content)
+	for i := range orig {
+		changed = changed || orig[i] != n.Content[i]
+	}
+	if !changed {
+		for i, c := range n.Content {
+			if err := e.applyTreeEdits(c, s, childPath(i-i%stride)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if n.Style&yaml.FlowStyle != 0 {
+		return fmt.Errorf("%s: flow collections cannot be edited in place", displayPath(path))
+	}
+
+	// Entries are identified by their first node: the key of a pair, or the
+	// item itself.
+	kept := make(map[*yaml.Node]bool)
+	for i := 0; i < len(n.Content); i += stride {
+		if _, ok := s[n.Content[i]]; ok {
+			kept[n.Content[i]] = true
+		}
+	}
+	span := func(entry []*yaml.Node) (int, int, bool) {
+		if stride == 1 {
+			return e.itemSpan(entry[0])
+		}
+		return e.pairSpan(entry[0], entry[1])
+	}
+	next := 0 // position of the next kept entry in orig
+	for i := 0; i < len(orig); i += stride {
+		if kept[orig[i]] {
+			continue
+		}
+		start, end, ok := span(orig[i : i+stride])
+		if !ok {
+			return fmt.Errorf("%s: entries that do not start their own line cannot be removed in place", displayPath(path))
+		}
+		for l := start; l <= end; l++ {
+			e.deleted[l] = true
+		}
+	}
+
+	// New entries go after the entry before them, at its indentation.
+	var prev []*yaml.Node
+	indent := -1
+	for i := 0; i < len(orig); i += stride {
+		if kept[orig[i]] {
+			indent = strings.IndexFunc(string(e.line(orig[i].Line)), func(r rune) bool { return r != ' ' })
+			break
+		}
+	}
+	if indent < 0 {
+		return fmt.Errorf("%s: entries cannot be added in place once all others are removed", displayPath(path))
+	}
+	var pending [][]string
+	for i := 0; i < len(n.Content); i += stride {
+		entry := n.Content[i : i+stride]
+		if !kept[entry[0]] {
+			var node *yaml.Node
+			if stride == 1 {
+				node = &yaml.Node{Kind: yaml.SequenceNode, Content: entry}
+			} else {
+				node = &yaml.Node{Kind: yaml.MappingNode, Content: entry}
+			}
+			lines, err := encodeYAMLLines(node, indent)
+			if err != nil {
+				return fmt.Errorf("%s: %w", displayPath(path), err)
+			}
+			pending = append(pending, lines)
+			continue
+		}
+		for next < len(orig) && !kept[orig[next]] {
+			next += stride
+		}
+		if next >= len(orig) || orig[next] != entry[0] {
+			return fmt.Errorf("%s: reordered entries cannot be written in place", displayPath(path))
+		}
+		next += stride
+		if len(pending) > 0 {
+			start, _, ok := span(entry)
+			if !ok {
+				return fmt.Errorf("%s: entries that do not start their own line cannot be edited in place", displayPath(path))
+			}
+			for _, lines := range pending {
+				e.insertAfter(start-1, lines...)
+			}
+			pending = nil
+		}
+		for _, c := range entry {
+			if err := e.applyTreeEdits(c, s, childPath(i)); err != nil {
+				return err
+			}
+		}
+		prev = entry
+	}
+	if len(pending) > 0 {
+		_, end, ok := span(prev)
+		if !ok {
+			return fmt.Errorf("%s: entries that do not start their own line cannot be edited in place", displayPath(path))
+		}
+		for _, lines := range pending {
+			e.insertAfter(end, lines...)
+		}
+	}
+	return nil
+}
+
+// displayPath names the document root in errors.
+func displayPath(path string) string {
+	if path == "" {
+		return "document"
+	}
+	return path
+}